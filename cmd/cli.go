@@ -0,0 +1,127 @@
+// Package cmd 提供命令行工具入口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/db"
+	"jank.com/jank_blog/internal/logger"
+	"jank.com/jank_blog/internal/utils"
+	category "jank.com/jank_blog/pkg/serve/service/category"
+	"jank.com/jank_blog/pkg/serve/service/importer"
+)
+
+// 命令行子命令
+const (
+	CMD_CATEGORY_CHECK = "category-check" // 类目树完整性检查与修复
+	CMD_COMMENT_IMPORT = "comment-import" // 从 Disqus 或 WordPress 导出文件导入评论
+)
+
+// Run 执行命令行子命令
+// 参数：
+//   - args: 命令行参数，args[0] 为子命令名称
+func Run(args []string) {
+	switch args[0] {
+	case CMD_CATEGORY_CHECK:
+		flags := flag.NewFlagSet(CMD_CATEGORY_CHECK, flag.ExitOnError)
+		repair := flags.Bool("repair", false, "在事务中修复检测到的问题")
+		_ = flags.Parse(args[1:])
+		checkCategoryTree(*repair)
+	case CMD_COMMENT_IMPORT:
+		flags := flag.NewFlagSet(CMD_COMMENT_IMPORT, flag.ExitOnError)
+		source := flags.String("source", "", "导入来源：disqus 或 wordpress")
+		file := flags.String("file", "", "导出文件路径")
+		dryRun := flags.Bool("dry-run", false, "只输出导入报告，不写入数据库")
+		ownerEmail := flags.String("owner-email", "", "博主在来源平台使用的邮箱，该邮箱的评论关联到博主账户并标记为博主回复")
+		ownerAccount := flags.String("owner-account", "", "关联的本站账户邮箱，默认与 -owner-email 相同")
+		_ = flags.Parse(args[1:])
+		if *source == "" || *file == "" {
+			flags.Usage()
+			os.Exit(2)
+		}
+		importComments(*source, *file, &importer.ImportOptions{
+			DryRun:       *dryRun,
+			OwnerEmail:   *ownerEmail,
+			OwnerAccount: *ownerAccount,
+		})
+	default:
+		log.Fatalf("未知命令: %s，可用命令: %s [-repair]、%s -source <disqus|wordpress> -file <path> [-dry-run] [-owner-email <email>] [-owner-account <email>]",
+			args[0], CMD_CATEGORY_CHECK, CMD_COMMENT_IMPORT)
+	}
+}
+
+// checkCategoryTree 检查类目树完整性并输出报告，未修复且存在问题时以状态码 1 退出
+// 参数：
+//   - repair: 是否修复
+func checkCategoryTree(repair bool) {
+	config := initCLI()
+
+	db.New(config)
+
+	report, err := category.CheckCategoryTree(utils.NewDetachedContext(), repair)
+	if err != nil {
+		log.Fatalf("类目树检查失败: %v", err)
+	}
+
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatalf("序列化检查报告失败: %v", err)
+	}
+	fmt.Println(string(output))
+
+	if len(report.Issues) > 0 && !repair {
+		os.Exit(1)
+	}
+}
+
+// importComments 导入评论导出文件并输出导入报告，可重复执行
+// 参数：
+//   - source: 导入来源
+//   - path: 导出文件路径
+//   - opts: 导入选项
+func importComments(source, path string, opts *importer.ImportOptions) {
+	config := initCLI()
+
+	db.New(config)
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("打开导出文件失败: %v", err)
+	}
+	defer f.Close()
+
+	report, err := importer.ImportComments(utils.NewDetachedContext(), source, f, opts)
+	if err != nil {
+		log.Fatalf("导入评论失败: %v", err)
+	}
+
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatalf("序列化导入报告失败: %v", err)
+	}
+	fmt.Println(string(output))
+}
+
+// initCLI 初始化命令行工具所需的配置与日志组件
+// 返回值：
+//   - *configs.Config: 应用配置
+func initCLI() *configs.Config {
+	if err := configs.Init(configs.DefaultConfigPath); err != nil {
+		log.Fatalf("配置初始化失败: %v", err)
+	}
+
+	config, err := configs.LoadConfig()
+	if err != nil {
+		log.Fatalf("获取配置失败: %v", err)
+	}
+
+	logger.New()
+	return config
+}
@@ -16,7 +16,10 @@ import (
 	"jank.com/jank_blog/internal/middleware"
 	"jank.com/jank_blog/internal/oss"
 	"jank.com/jank_blog/internal/redis"
+	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/router"
+	audit "jank.com/jank_blog/pkg/serve/service/audit"
+	webmention "jank.com/jank_blog/pkg/serve/service/webmention"
 )
 
 // Start 启动服务
@@ -32,6 +35,12 @@ func Start() {
 		return
 	}
 
+	// 校验 JWT 签名密钥配置
+	if err := utils.InitJWTKeyring(); err != nil {
+		log.Fatalf("JWT 密钥初始化失败: %v", err)
+		return
+	}
+
 	// 初始化 Logger
 	logger.New()
 
@@ -53,6 +62,12 @@ func Start() {
 	// 初始化 MinIO 客户端
 	oss.New(config)
 
+	// 启动审计日志：记录配置热更新并定期清理过期日志
+	audit.Start()
+
+	// 启动 Webmention 校验协程：重新校验重启前遗留的待校验记录
+	webmention.Start()
+
 	// 注册路由
 	router.New(app)
 
@@ -15,11 +15,27 @@ import (
 
 // AppConfig 应用配置
 type AppConfig struct {
-	AppName string        `mapstructure:"APP_NAME"`
-	AppHost string        `mapstructure:"APP_HOST"`
-	AppPort string        `mapstructure:"APP_PORT"`
-	Email   EmailConfig   `mapstructure:"EMAIL"`
-	Swagger SwaggerConfig `mapstructure:"SWAGGER"`
+	AppName      string             `mapstructure:"APP_NAME"`
+	AppHost      string             `mapstructure:"APP_HOST"`
+	AppPort      string             `mapstructure:"APP_PORT"`
+	Site         SiteConfig         `mapstructure:"SITE"`
+	Email        EmailConfig        `mapstructure:"EMAIL"`
+	Swagger      SwaggerConfig      `mapstructure:"SWAGGER"`
+	Webmention   WebmentionConfig   `mapstructure:"WEBMENTION"`
+	Comment      CommentConfig      `mapstructure:"COMMENT"`
+	Spam         SpamConfig         `mapstructure:"SPAM"`
+	Notification NotificationConfig `mapstructure:"NOTIFICATION"`
+	JWT          JWTConfig          `mapstructure:"JWT"`
+	LoginGuard   LoginGuardConfig   `mapstructure:"LOGIN_GUARD"`
+	Audit        AuditConfig        `mapstructure:"AUDIT"`
+	OAuth        OAuthConfig        `mapstructure:"OAUTH"`
+}
+
+// SiteConfig 站点对外访问配置
+type SiteConfig struct {
+	SiteURL         string `mapstructure:"SITE_URL"`          // 博客前端对外访问地址
+	ApiURL          string `mapstructure:"API_URL"`           // 后端 API 对外访问地址
+	PostURLTemplate string `mapstructure:"POST_URL_TEMPLATE"` // 文章访问地址模板，{id} 会被替换为文章 ID
 }
 
 // EmailConfig 邮箱配置
@@ -35,6 +51,97 @@ type SwaggerConfig struct {
 	SwaggerEnabled bool   `mapstructure:"SWAGGER_ENABLED"`
 }
 
+// WebmentionConfig Webmention 配置
+type WebmentionConfig struct {
+	WebmentionEnabled      bool `mapstructure:"WEBMENTION_ENABLED"`       // 是否启用 Webmention 收发
+	WebmentionAllowPrivate bool `mapstructure:"WEBMENTION_ALLOW_PRIVATE"` // 是否允许访问内网地址，仅用于本地调试
+}
+
+// CommentConfig 评论配置
+type CommentConfig struct {
+	GuestCommentEnabled bool   `mapstructure:"GUEST_COMMENT_ENABLED"` // 是否允许游客评论
+	GuestRateLimit      int    `mapstructure:"GUEST_RATE_LIMIT"`      // 单个 IP 在时间窗口内允许的评论次数
+	GuestRateWindow     int    `mapstructure:"GUEST_RATE_WINDOW"`     // 限流时间窗口，单位秒
+	ModerationPolicy    string `mapstructure:"MODERATION_POLICY"`     // 审核策略：none 直接通过，links 含链接时待审，all 全部待审
+	AutoApproveKnown    bool   `mapstructure:"AUTO_APPROVE_KNOWN"`    // 是否自动通过已有评论被审核通过的登录评论者
+	MaxReplyDepth       int    `mapstructure:"MAX_REPLY_DEPTH"`       // 评论列表内联展示的最大回复层级，更深的回复需按需加载
+	ReplyPageSize       int    `mapstructure:"REPLY_PAGE_SIZE"`       // 每条评论内联展示及按需加载的回复条数
+	EditWindow          int    `mapstructure:"EDIT_WINDOW"`           // 评论发表后允许作者编辑的时间窗口，单位秒，0 表示不允许编辑
+}
+
+// NotificationConfig 邮件通知配置
+type NotificationConfig struct {
+	NotificationEnabled bool   `mapstructure:"NOTIFICATION_ENABLED"` // 是否发送评论邮件通知
+	OwnerEmail          string `mapstructure:"OWNER_EMAIL"`          // 接收新评论通知的博主邮箱，为空时不通知
+	UnsubscribeSecret   string `mapstructure:"UNSUBSCRIBE_SECRET"`   // 退订链接签名密钥
+}
+
+// SpamConfig 垃圾评论检测配置
+type SpamConfig struct {
+	SpamCheckers       []string `mapstructure:"SPAM_CHECKERS"`         // 启用的检测器：heuristic、bayes、akismet，按顺序执行
+	SpamMaxLinks       int      `mapstructure:"SPAM_MAX_LINKS"`        // 单条评论允许的最大链接数
+	SpamBlockedWords   []string `mapstructure:"SPAM_BLOCKED_WORDS"`    // 屏蔽关键词，不区分大小写
+	SpamRateLimit      int      `mapstructure:"SPAM_RATE_LIMIT"`       // 单个 IP 在时间窗口内的评论数超过该值时判定为垃圾评论
+	SpamRateWindow     int      `mapstructure:"SPAM_RATE_WINDOW"`      // 发帖频率统计窗口，单位秒
+	BayesThreshold     float64  `mapstructure:"BAYES_THRESHOLD"`       // 贝叶斯分类器判定为垃圾评论的概率阈值
+	BayesMinTrainCount int64    `mapstructure:"BAYES_MIN_TRAIN_COUNT"` // 垃圾与正常样本各自达到该数量后分类器才生效
+	AkismetEndpoint    string   `mapstructure:"AKISMET_ENDPOINT"`      // Akismet 兼容服务地址
+	AkismetAPIKey      string   `mapstructure:"AKISMET_API_KEY"`       // Akismet API 密钥
+}
+
+// JWTConfig JWT 签名配置
+type JWTConfig struct {
+	ActiveKid          string         `mapstructure:"ACTIVE_KID"`           // 当前用于签发令牌的密钥 kid
+	AccessTokenExpire  int            `mapstructure:"ACCESS_TOKEN_EXPIRE"`  // Access Token 有效期，单位秒
+	RefreshTokenExpire int            `mapstructure:"REFRESH_TOKEN_EXPIRE"` // Refresh Token 有效期，单位秒
+	Keys               []JWTKeyConfig `mapstructure:"KEYS"`                 // 签名密钥列表，非当前 kid 的密钥仅用于校验已签发的令牌
+}
+
+// JWTKeyConfig JWT 签名密钥配置
+type JWTKeyConfig struct {
+	Kid            string `mapstructure:"KID"`              // 密钥标识，写入令牌头部的 kid
+	Algorithm      string `mapstructure:"ALGORITHM"`        // 签名算法：HS256、RS256、EdDSA
+	Secret         string `mapstructure:"SECRET"`           // HS256 密钥，至少 32 字节
+	PrivateKeyFile string `mapstructure:"PRIVATE_KEY_FILE"` // RS256、EdDSA 的 PEM 私钥文件路径，仅签发令牌时需要
+	PublicKeyFile  string `mapstructure:"PUBLIC_KEY_FILE"`  // RS256、EdDSA 的 PEM 公钥文件路径，未配置时由私钥推导
+}
+
+// LoginGuardConfig 登录防暴力破解配置
+type LoginGuardConfig struct {
+	AccountMaxFailures int `mapstructure:"ACCOUNT_MAX_FAILURES"` // 同一账户累计失败达到该次数后开始锁定，0 表示不按账户锁定
+	IPMaxFailures      int `mapstructure:"IP_MAX_FAILURES"`      // 同一 IP 累计失败达到该次数后开始锁定，0 表示不按 IP 锁定
+	BaseLockSeconds    int `mapstructure:"BASE_LOCK_SECONDS"`    // 首次锁定时长，单位秒，此后每次失败翻倍
+	MaxLockSeconds     int `mapstructure:"MAX_LOCK_SECONDS"`     // 单次锁定时长上限，单位秒
+	FailureWindow      int `mapstructure:"FAILURE_WINDOW"`       // 失败计数保留时长，单位秒，自最近一次失败起计算
+}
+
+// AuditConfig 审计日志配置
+type AuditConfig struct {
+	RetentionDays int `mapstructure:"RETENTION_DAYS"` // 审计日志保留天数，超期的日志会被定期清理，0 表示永久保留
+}
+
+// OAuthConfig 第三方登录配置
+type OAuthConfig struct {
+	OAuthEnabled bool                  `mapstructure:"OAUTH_ENABLED"` // 是否启用第三方登录
+	RedirectURL  string                `mapstructure:"REDIRECT_URL"`  // 授权回调地址，指向前端回调页面，{provider} 会被替换为提供方名称
+	AllowPrivate bool                  `mapstructure:"ALLOW_PRIVATE"` // 是否允许访问内网地址，仅用于对接本地模拟提供方调试
+	Providers    []OAuthProviderConfig `mapstructure:"PROVIDERS"`     // 第三方登录提供方列表
+}
+
+// OAuthProviderConfig 第三方登录提供方配置
+type OAuthProviderConfig struct {
+	Name         string   `mapstructure:"NAME"`          // 提供方名称，作为接口参数与账户绑定记录的标识
+	Type         string   `mapstructure:"TYPE"`          // 提供方类型：github、google、oidc
+	DisplayName  string   `mapstructure:"DISPLAY_NAME"`  // 登录按钮展示名称，为空时使用提供方名称
+	ClientID     string   `mapstructure:"CLIENT_ID"`     // OAuth 客户端 ID
+	ClientSecret string   `mapstructure:"CLIENT_SECRET"` // OAuth 客户端密钥
+	Issuer       string   `mapstructure:"ISSUER"`        // OIDC Issuer，oidc 类型必填，通过发现文档获取各端点，google 类型默认为 https://accounts.google.com
+	AuthURL      string   `mapstructure:"AUTH_URL"`      // github 类型的授权地址，为空时使用 GitHub 默认地址
+	TokenURL     string   `mapstructure:"TOKEN_URL"`     // github 类型的令牌地址，为空时使用 GitHub 默认地址
+	APIURL       string   `mapstructure:"API_URL"`       // github 类型的 API 地址，为空时使用 GitHub 默认地址
+	Scopes       []string `mapstructure:"SCOPES"`        // 授权范围，为空时使用提供方类型的默认范围
+}
+
 // DatabaseConfig 数据库配置
 type DatabaseConfig struct {
 	DBDialect  string `mapstructure:"DB_DIALECT"`
@@ -86,10 +193,16 @@ type Config struct {
 // DefaultConfigPath 默认配置文件路径
 const DefaultConfigPath = "./configs/config.yml"
 
+// ReloadHook 配置热更新回调，参数为发生变化的配置项路径及其变更前后的值
+type ReloadHook func(changes map[string][2]interface{})
+
 var (
 	globalConfig  *Config      // 全局配置实例
 	configLock    sync.RWMutex // 配置读写锁
 	viperInstance *viper.Viper // viper实例
+
+	reloadHooks     []ReloadHook // 配置热更新回调
+	reloadHooksLock sync.Mutex   // 配置热更新回调锁
 )
 
 // Init 初始化配置
@@ -132,6 +245,23 @@ func LoadConfig() (*Config, error) {
 	return &configCopy, nil
 }
 
+// ConfigFilePath 获取当前使用的配置文件路径
+// 返回值：
+//   - string: 配置文件路径
+func ConfigFilePath() string {
+	return viperInstance.ConfigFileUsed()
+}
+
+// OnReload 注册配置热更新回调，配置文件变更且校验通过后依次调用
+// 参数：
+//   - hook: 配置热更新回调
+func OnReload(hook ReloadHook) {
+	reloadHooksLock.Lock()
+	defer reloadHooksLock.Unlock()
+
+	reloadHooks = append(reloadHooks, hook)
+}
+
 // monitorConfigChanges 监听配置变更
 func monitorConfigChanges() {
 	viperInstance.WatchConfig()
@@ -142,25 +272,48 @@ func monitorConfigChanges() {
 			return
 		}
 
-		configLock.Lock()
-		defer configLock.Unlock()
-
-		oldConfig := *globalConfig
-		changes := make(map[string][2]interface{})
-
-		if !compareStructs(oldConfig, newConfig, "", changes) {
-			log.Printf("配置类型不一致，变更被阻止")
+		changes, ok := applyConfig(newConfig)
+		if !ok || len(changes) == 0 {
 			return
 		}
 
-		globalConfig = &newConfig
-
-		for path, values := range changes {
-			log.Printf("配置项 [%s] 发生变化: %v -> %v", path, values[0], values[1])
+		// 回调在释放配置锁后执行，回调中可以安全地读取配置
+		reloadHooksLock.Lock()
+		hooks := append([]ReloadHook(nil), reloadHooks...)
+		reloadHooksLock.Unlock()
+		for _, hook := range hooks {
+			hook(changes)
 		}
 	})
 }
 
+// applyConfig 替换全局配置并收集变更
+// 参数：
+//   - newConfig: 新配置
+//
+// 返回值：
+//   - map[string][2]interface{}: 发生变化的配置项
+//   - bool: 新配置是否生效，配置类型不一致时变更被阻止
+func applyConfig(newConfig Config) (map[string][2]interface{}, bool) {
+	configLock.Lock()
+	defer configLock.Unlock()
+
+	oldConfig := *globalConfig
+	changes := make(map[string][2]interface{})
+
+	if !compareStructs(oldConfig, newConfig, "", changes) {
+		log.Printf("配置类型不一致，变更被阻止")
+		return nil, false
+	}
+
+	globalConfig = &newConfig
+
+	for path, values := range changes {
+		log.Printf("配置项 [%s] 发生变化: %v -> %v", path, values[0], values[1])
+	}
+	return changes, true
+}
+
 // compareStructs 比较结构体并收集变更
 // 参数：
 //   - oldObj: 旧结构体
@@ -4,15 +4,26 @@
 package db
 
 import (
+	"errors"
 	"fmt"
 	"log"
 
+	"gorm.io/gorm"
+
 	"jank.com/jank_blog/internal/global"
 	"jank.com/jank_blog/internal/model"
+	account "jank.com/jank_blog/internal/model/account"
+	comment "jank.com/jank_blog/internal/model/comment"
+	post "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
 )
 
 // autoMigrate 执行数据库表结构自动迁移
 func autoMigrate() error {
+	if err := migrateCommentImportID(); err != nil {
+		return fmt.Errorf("迁移评论导入 ID 失败 %w", err)
+	}
+
 	err := global.DB.AutoMigrate(
 		model.GetAllModels()...,
 	)
@@ -20,8 +31,80 @@ func autoMigrate() error {
 		return fmt.Errorf("数据库自动迁移失败 %w", err)
 	}
 
+	if err := ensureAdminAccount(); err != nil {
+		return fmt.Errorf("初始化管理员账户失败 %w", err)
+	}
+
 	log.Println("数据库自动迁移成功...")
 	global.SysLog.Info("数据库自动迁移成功...")
 
 	return nil
 }
+
+// migrateCommentImportID 评论导入 ID 改为可空并建立 (import_source, import_id) 唯一索引前，将站内评论的空字符串置为 NULL，
+// 否则已有的站内评论会违反唯一约束
+// 返回值：
+//   - error: 操作过程中的错误
+func migrateCommentImportID() error {
+	migrator := global.DB.Migrator()
+	if !migrator.HasTable(&comment.Comment{}) || !migrator.HasColumn(&comment.Comment{}, "import_id") {
+		return nil
+	}
+
+	columnTypes, err := migrator.ColumnTypes(&comment.Comment{})
+	if err != nil {
+		return err
+	}
+	for _, columnType := range columnTypes {
+		if columnType.Name() != "import_id" {
+			continue
+		}
+		if nullable, ok := columnType.Nullable(); ok && !nullable {
+			if err := migrator.AlterColumn(&comment.Comment{}, "ImportID"); err != nil {
+				return err
+			}
+		}
+		break
+	}
+
+	return global.DB.Model(&comment.Comment{}).Where("import_id = ?", "").
+		UpdateColumn("import_id", gorm.Expr("NULL")).Error
+}
+
+// ensureAdminAccount 从单用户版本升级时不存在管理员：将最早注册的账户设为管理员，并将没有作者的文章归属给该账户
+// 返回值：
+//   - error: 操作过程中的错误
+func ensureAdminAccount() error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		var admins int64
+		if err := tx.Model(&account.Account{}).
+			Where("role = ? AND deleted = ?", utils.ROLE_ADMIN, false).
+			Count(&admins).Error; err != nil {
+			return err
+		}
+		if admins > 0 {
+			return nil
+		}
+
+		var first account.Account
+		err := tx.Where("deleted = ?", false).Order("gmt_create ASC, id ASC").First(&first).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&account.Account{}).Where("id = ?", first.ID).
+			UpdateColumn("role", utils.ROLE_ADMIN).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&post.Post{}).Where("author_id = ?", 0).
+			UpdateColumn("author_id", first.ID).Error; err != nil {
+			return err
+		}
+
+		global.SysLog.Infof("已将最早注册的账户「%s」设为管理员", first.Email)
+		return nil
+	})
+}
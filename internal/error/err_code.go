@@ -10,6 +10,11 @@ const (
 	SERVER_ERR  = 10000
 	BAD_REQUEST = 20000
 
+	TOO_MANY_REQUESTS = 20001
+	FORBIDDEN         = 20002
+	UNAUTHORIZED      = 20003
+	ACCOUNT_LOCKED    = 20004
+
 	SEND_IMG_VERIFICATION_CODE_FAIL   = 10001
 	SEND_EMAIL_VERIFICATION_CODE_FAIL = 10002
 )
@@ -21,6 +26,11 @@ var CodeMsg = map[int]string{
 	SERVER_ERR:  "服务端异常",
 	BAD_REQUEST: "错误请求",
 
+	TOO_MANY_REQUESTS: "请求过于频繁，请稍后再试",
+	FORBIDDEN:         "无权执行此操作",
+	UNAUTHORIZED:      "未登录或登录已失效",
+	ACCOUNT_LOCKED:    "登录失败次数过多，已被临时锁定",
+
 	SEND_IMG_VERIFICATION_CODE_FAIL:   "图形验证码发送失败",
 	SEND_EMAIL_VERIFICATION_CODE_FAIL: "邮箱验证码发送失败",
 }
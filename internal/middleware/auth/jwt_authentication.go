@@ -4,7 +4,7 @@
 package auth_middleware
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -18,22 +18,21 @@ import (
 type JWTConfig struct {
 	Authorization string // 认证头名称
 	TokenPrefix   string // Token前缀
-	RefreshToken  string // 刷新令牌头名称
-	UserCache     string // 用户缓存键前缀
 }
 
 // DefaultJWTConfig 默认配置
 var DefaultJWTConfig = JWTConfig{
 	Authorization: "Authorization",
 	TokenPrefix:   "Bearer ",
-	RefreshToken:  "Refresh-Token",
-	UserCache:     "USER_CACHE",
 }
 
-// AuthMiddleware 处理 JWT 认证中间件
+// AuthMiddleware 处理 JWT 认证中间件；指定授权范围时，同时接受带有其中任一授权范围的个人访问令牌
+// 参数：
+//   - scopes: 路由接受的个人访问令牌授权范围，为空时仅接受登录会话签发的 Access-Token
+//
 // 返回值：
 //   - echo.MiddlewareFunc: Echo 框架中间件函数
-func AuthMiddleware() echo.MiddlewareFunc {
+func AuthMiddleware(scopes ...string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			// 从请求头中提取 Access-Token
@@ -43,38 +42,99 @@ func AuthMiddleware() echo.MiddlewareFunc {
 			}
 			tokenString := strings.TrimPrefix(AuthorizationHeader, DefaultJWTConfig.TokenPrefix)
 
-			// 验证 Access-Token；若 Access-Token 已过期或无效，则尝试使用 Refresh Token 刷新
-			_, err := utils.ValidateJWTToken(tokenString, false)
-			if err != nil {
-				refreshTokenHeader := c.Request().Header.Get(DefaultJWTConfig.RefreshToken)
-				if refreshTokenHeader == "" {
-					return echo.NewHTTPError(http.StatusUnauthorized, "Access-Token 已过期，请提供 Refresh-Token")
-				}
-
-				newTokens, err := utils.RefreshTokenLogic(refreshTokenHeader)
-				if err != nil {
-					return echo.NewHTTPError(http.StatusUnauthorized, "Refresh-Token 无效或已过期，请重新登录")
+			// 个人访问令牌按授权范围校验，不关联登录会话
+			if utils.IsAccessToken(tokenString) {
+				if err := authenticateAccessToken(c, tokenString, scopes); err != nil {
+					return err
 				}
-
-				// 设置新的 Access-Token 和 Refresh-Token 到响应头 Authorization 和 Refresh-Token
-				c.Response().Header().Set(DefaultJWTConfig.Authorization, DefaultJWTConfig.TokenPrefix+newTokens["Authorization"])
-				c.Response().Header().Set(DefaultJWTConfig.RefreshToken, newTokens["Refresh-Token"])
-				tokenString = newTokens["Authorization"]
+				return next(c)
 			}
 
-			// 从 access_token 中解析 accountID
-			accountID, err := utils.ParseAccountFromJWT(tokenString)
+			// 从 access_token 中解析 accountID 与会话ID
+			accountID, sessionID, err := utils.ParseSessionFromJWT(tokenString)
 			if err != nil {
-				return echo.NewHTTPError(http.StatusUnauthorized, "Access-Token 解析失败，请重新登录")
+				return echo.NewHTTPError(http.StatusUnauthorized, "Access-Token 无效或已过期，请通过 /account/refreshToken 刷新或重新登录")
 			}
 
 			// 检验会话有效性
-			sessionCacheKey := fmt.Sprintf("%s:%d", DefaultJWTConfig.UserCache, accountID)
-			if sessionVal, err := global.RedisClient.Get(c.Request().Context(), sessionCacheKey).Result(); err != nil || sessionVal == "" {
+			if !isSessionActive(c, accountID, sessionID) {
 				return echo.NewHTTPError(http.StatusUnauthorized, "会话已失效，请重新登录")
 			}
 
+			role, err := getAccountRole(accountID)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "账户不存在或已被删除，请重新登录")
+			}
+
+			c.Set(utils.AUTH_ACCOUNT_ID_CONTEXT_KEY, accountID)
+			c.Set(utils.AUTH_ACCOUNT_ROLE_CONTEXT_KEY, role)
+			c.Set(utils.AUTH_SESSION_ID_CONTEXT_KEY, sessionID)
+			return next(c)
+		}
+	}
+}
+
+// OptionalAuthMiddleware 可选的 JWT 认证中间件，携带有效 Access-Token 时记录账户信息，否则按匿名请求放行；
+// 携带个人访问令牌时按授权范围校验，校验失败直接拒绝而不降级为匿名请求
+// 参数：
+//   - scopes: 路由接受的个人访问令牌授权范围，为空时拒绝个人访问令牌
+//
+// 返回值：
+//   - echo.MiddlewareFunc: Echo 框架中间件函数
+func OptionalAuthMiddleware(scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			AuthorizationHeader := c.Request().Header.Get(DefaultJWTConfig.Authorization)
+			if AuthorizationHeader == "" {
+				return next(c)
+			}
+			tokenString := strings.TrimPrefix(AuthorizationHeader, DefaultJWTConfig.TokenPrefix)
+
+			if utils.IsAccessToken(tokenString) {
+				if err := authenticateAccessToken(c, tokenString, scopes); err != nil {
+					return err
+				}
+				return next(c)
+			}
+
+			accountID, sessionID, err := utils.ParseSessionFromJWT(tokenString)
+			if err != nil || !isSessionActive(c, accountID, sessionID) {
+				return next(c)
+			}
+
+			role, err := getAccountRole(accountID)
+			if err != nil {
+				return next(c)
+			}
+
+			c.Set(utils.AUTH_ACCOUNT_ID_CONTEXT_KEY, accountID)
+			c.Set(utils.AUTH_ACCOUNT_ROLE_CONTEXT_KEY, role)
+			c.Set(utils.AUTH_SESSION_ID_CONTEXT_KEY, sessionID)
 			return next(c)
 		}
 	}
 }
+
+// isSessionActive 检验令牌所属的登录会话是否有效，有效时刷新会话的最近活跃时间
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//   - sessionID: 会话ID
+//
+// 返回值：
+//   - bool: 会话是否有效
+func isSessionActive(c echo.Context, accountID int64, sessionID string) bool {
+	session, err := utils.GetSession(c, accountID, sessionID)
+	if err != nil || session == nil {
+		return false
+	}
+
+	if err := utils.TouchSession(c, session); err != nil {
+		// 读取会话后会话被并发注销时，不再视为有效
+		if errors.Is(err, utils.ErrSessionNotFound) {
+			return false
+		}
+		global.SysLog.Warnf("刷新会话「%s」活跃时间失败: %v", sessionID, err)
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+// Package auth_middleware 提供基于角色的权限校验中间件
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package auth_middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+)
+
+// PermissionMiddleware 校验当前账户的角色是否拥有全部指定权限，需注册在 AuthMiddleware 之后
+// 参数：
+//   - permissions: 路由所需的权限
+//
+// 返回值：
+//   - echo.MiddlewareFunc: Echo 框架中间件函数
+func PermissionMiddleware(permissions ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, authenticated := utils.GetAuthAccountID(c); !authenticated {
+				return echo.NewHTTPError(http.StatusUnauthorized, "请先登录")
+			}
+
+			for _, permission := range permissions {
+				if !utils.HasPermission(c, permission) {
+					return echo.NewHTTPError(http.StatusForbidden, "当前账户角色无权执行此操作")
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// getAccountRole 获取账户当前的角色，角色变更即时生效
+// 参数：
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - string: 账户角色
+//   - error: 账户不存在或查询失败时返回错误
+func getAccountRole(accountID int64) (string, error) {
+	var acc account.Account
+	if err := global.DB.Select("id", "role").
+		Where("id = ? AND deleted = ?", accountID, false).
+		First(&acc).Error; err != nil {
+		return "", err
+	}
+	return acc.Role, nil
+}
@@ -0,0 +1,25 @@
+// Package model 提供个人访问令牌数据模型定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// AccessToken 个人访问令牌，供自动化脚本调用接口，仅保存摘要，撤销后逻辑删除
+type AccessToken struct {
+	base.Base
+	AccountID  int64  `gorm:"type:bigint;not null;index" json:"account_id"`            // 所属账户ID
+	Name       string `gorm:"type:varchar(64);not null" json:"name"`                   // 令牌名称，便于区分用途
+	TokenHash  string `gorm:"type:varchar(64);not null;uniqueIndex" json:"token_hash"` // 令牌 SHA-256 摘要
+	TokenHint  string `gorm:"type:varchar(16);not null" json:"token_hint"`             // 令牌末尾字符，便于识别
+	Scopes     string `gorm:"type:varchar(255);not null" json:"scopes"`                // 授权范围，以逗号分隔
+	ExpiresAt  int64  `gorm:"type:bigint;not null;default:0" json:"expires_at"`        // 过期时间，0 表示永不过期
+	LastUsedAt int64  `gorm:"type:bigint;not null;default:0" json:"last_used_at"`      // 最近使用时间，0 表示从未使用
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (AccessToken) TableName() string {
+	return "account_access_tokens"
+}
@@ -8,11 +8,16 @@ import "jank.com/jank_blog/internal/model/base"
 // Account 用户账户模型
 type Account struct {
 	base.Base
-	Phone    string `gorm:"type:varchar(32);unique;default:null" json:"phone"` // 手机号，次登录方式
-	Email    string `gorm:"type:varchar(64);unique;not null" json:"email"`     // 邮箱，主登录方式
-	Password string `gorm:"type:varchar(255);not null" json:"password"`        // 加密密码
-	Nickname string `gorm:"type:varchar(64);not null" json:"nickname"`         // 昵称
-	Avatar   string `gorm:"type:varchar(255);default:null" json:"avatar"`      // 用户头像
+	Phone    string `gorm:"type:varchar(32);unique;default:null" json:"phone"`         // 手机号，次登录方式
+	Email    string `gorm:"type:varchar(64);unique;not null" json:"email"`             // 邮箱，主登录方式
+	Password string `gorm:"type:varchar(255);not null" json:"password"`                // 加密密码
+	Nickname string `gorm:"type:varchar(64);not null" json:"nickname"`                 // 昵称
+	Avatar   string `gorm:"type:varchar(255);default:null" json:"avatar"`              // 用户头像
+	Role     string `gorm:"type:varchar(16);not null;default:'commenter'" json:"role"` // 角色：admin、editor、author、commenter
+
+	TotpSecret   string `gorm:"type:varchar(64);not null;default:''" json:"totp_secret"` // TOTP 密钥，未启用时为待确认的密钥
+	TotpEnabled  bool   `gorm:"type:boolean;not null;default:false" json:"totp_enabled"` // 是否已启用双因素认证
+	TotpLastStep int64  `gorm:"type:bigint;not null;default:0" json:"totp_last_step"`    // 最近一次校验通过的时间步，防止验证码重放
 }
 
 // TableName 指定表名
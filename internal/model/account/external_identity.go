@@ -0,0 +1,24 @@
+// Package model 提供第三方登录身份数据模型定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// ExternalIdentity 第三方登录身份，记录第三方账号与本站账户的绑定关系，解除绑定后逻辑删除
+type ExternalIdentity struct {
+	base.Base
+	AccountID   int64  `gorm:"type:bigint;not null;index" json:"account_id"`                          // 绑定的账户ID
+	Provider    string `gorm:"type:varchar(32);not null;index:idx_external_identity" json:"provider"` // 提供方名称，对应配置中的 NAME
+	Subject     string `gorm:"type:varchar(255);not null;index:idx_external_identity" json:"subject"` // 第三方账号唯一标识，OIDC 为 sub，GitHub 为用户 ID
+	Email       string `gorm:"type:varchar(64);not null;default:''" json:"email"`                     // 第三方账号邮箱
+	DisplayName string `gorm:"type:varchar(64);not null;default:''" json:"display_name"`              // 第三方账号名称
+	LastLoginAt int64  `gorm:"type:bigint;not null;default:0" json:"last_login_at"`                   // 最近一次通过该身份登录的时间，0 表示从未登录
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (ExternalIdentity) TableName() string {
+	return "account_external_identities"
+}
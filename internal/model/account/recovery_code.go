@@ -0,0 +1,20 @@
+// Package model 提供双因素认证恢复码数据模型定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// RecoveryCode 双因素认证的一次性恢复码，仅保存摘要，使用后逻辑删除
+type RecoveryCode struct {
+	base.Base
+	AccountID int64  `gorm:"type:bigint;not null;index" json:"account_id"` // 所属账户ID
+	CodeHash  string `gorm:"type:varchar(64);not null" json:"code_hash"`   // 恢复码 SHA-256 摘要
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (RecoveryCode) TableName() string {
+	return "account_recovery_codes"
+}
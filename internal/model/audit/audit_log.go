@@ -0,0 +1,74 @@
+// Package model 提供审计日志数据模型定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// 审计事件
+const (
+	AUDIT_ACTION_LOGIN           = "account.login"           // 登录成功
+	AUDIT_ACTION_LOGIN_FAILED    = "account.login_failed"    // 登录失败
+	AUDIT_ACTION_ACCOUNT_LOCK    = "account.lock"            // 登录失败次数过多，账户或 IP 被临时锁定
+	AUDIT_ACTION_ACCOUNT_UNLOCK  = "account.unlock"          // 管理员解除登录锁定
+	AUDIT_ACTION_PASSWORD_CHANGE = "account.password_change" // 登录后修改密码
+	AUDIT_ACTION_PASSWORD_RESET  = "account.password_reset"  // 通过邮箱验证码找回密码
+	AUDIT_ACTION_ROLE_UPDATE     = "account.role_update"     // 管理员修改账户角色
+	AUDIT_ACTION_EMAIL_CHANGE    = "account.email_change"    // 修改账户邮箱
+	AUDIT_ACTION_ACCOUNT_EXPORT  = "account.export"          // 导出账户数据
+	AUDIT_ACTION_ACCOUNT_DELETE  = "account.delete"          // 注销账户
+	AUDIT_ACTION_OAUTH_LINK      = "account.oauth_link"      // 绑定第三方账号，首次第三方登录自动创建账户时同样记录
+	AUDIT_ACTION_OAUTH_UNLINK    = "account.oauth_unlink"    // 解除第三方账号绑定
+
+	AUDIT_ACTION_POST_CREATE = "post.create" // 创建文章
+	AUDIT_ACTION_POST_UPDATE = "post.update" // 更新文章
+	AUDIT_ACTION_POST_DELETE = "post.delete" // 删除文章
+
+	AUDIT_ACTION_CATEGORY_CREATE = "category.create" // 创建类目
+	AUDIT_ACTION_CATEGORY_UPDATE = "category.update" // 更新或移动类目
+	AUDIT_ACTION_CATEGORY_DELETE = "category.delete" // 删除类目
+	AUDIT_ACTION_CATEGORY_MERGE  = "category.merge"  // 合并类目
+
+	AUDIT_ACTION_COMMENT_CREATE   = "comment.create"   // 发表评论
+	AUDIT_ACTION_COMMENT_UPDATE   = "comment.update"   // 编辑评论
+	AUDIT_ACTION_COMMENT_DELETE   = "comment.delete"   // 删除评论
+	AUDIT_ACTION_COMMENT_MODERATE = "comment.moderate" // 批量审核评论
+
+	AUDIT_ACTION_OSS_UPLOAD = "oss.upload" // 上传文件
+	AUDIT_ACTION_OSS_DELETE = "oss.delete" // 删除文件
+
+	AUDIT_ACTION_CONFIG_RELOAD = "config.reload" // 配置热更新
+)
+
+// 审计对象类型
+const (
+	AUDIT_TARGET_ACCOUNT  = "account"  // 账户，对象标识为邮箱
+	AUDIT_TARGET_IP       = "ip"       // 客户端 IP
+	AUDIT_TARGET_POST     = "post"     // 文章，对象标识为文章 ID
+	AUDIT_TARGET_CATEGORY = "category" // 类目，对象标识为类目 ID
+	AUDIT_TARGET_COMMENT  = "comment"  // 评论，对象标识为评论 ID，批量审核时为逗号分隔的 ID 列表
+	AUDIT_TARGET_OBJECT   = "object"   // 对象存储文件，对象标识为 bucket/对象名
+	AUDIT_TARGET_CONFIG   = "config"   // 配置文件
+)
+
+// AuditLog 审计日志，只追加不修改，仅按保留策略清理
+type AuditLog struct {
+	base.Base
+	Action     string       `gorm:"type:varchar(64);not null;index" json:"action"`                                  // 审计事件
+	ActorID    int64        `gorm:"type:bigint;not null;default:0;index" json:"actor_id"`                           // 操作者账户 ID，游客或系统操作为 0
+	ActorEmail string       `gorm:"type:varchar(64);not null;default:''" json:"actor_email"`                        // 操作者邮箱，登录相关事件记录提交的邮箱
+	IP         string       `gorm:"type:varchar(64);not null;default:'';index" json:"ip"`                           // 操作者 IP，系统操作为空
+	RequestID  string       `gorm:"type:varchar(64);not null;default:''" json:"request_id"`                         // 请求 ID，系统操作为空
+	TargetType string       `gorm:"type:varchar(32);not null;default:'';index:idx_audit_target" json:"target_type"` // 审计对象类型
+	TargetID   string       `gorm:"type:varchar(255);not null;default:'';index:idx_audit_target" json:"target_id"`  // 审计对象标识
+	Before     base.JSONMap `gorm:"type:json" json:"before"`                                                        // 变更前摘要
+	After      base.JSONMap `gorm:"type:json" json:"after"`                                                         // 变更后摘要
+	Detail     string       `gorm:"type:varchar(255);not null;default:''" json:"detail"`                            // 补充说明，如登录失败原因
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
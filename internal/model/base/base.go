@@ -34,11 +34,18 @@ type JSONMap map[string]interface{}
 // 返回值：
 //   - error: 操作过程中的错误
 func (j *JSONMap) Scan(value interface{}) error {
-	bytes, ok := value.([]byte)
-	if !ok {
+	// 空值按字符串写入，部分驱动（如 SQLite）读取时返回 string
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, j)
+	case string:
+		return json.Unmarshal([]byte(v), j)
+	case nil:
+		*j = nil
+		return nil
+	default:
 		return errors.New("数据类型错误，无法转换为 []byte 类型")
 	}
-	return json.Unmarshal(bytes, j)
 }
 
 // Value 将 JSONMap 转换为 json 数据存储到数据库
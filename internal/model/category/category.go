@@ -12,6 +12,7 @@ type Category struct {
 	Description string      `gorm:"type:varchar(255);default:''" json:"description"` // 类目描述
 	ParentID    int64       `gorm:"index;default:null" json:"parent_id"`             // 父类目ID
 	Path        string      `gorm:"type:varchar(225);not null;index" json:"path"`    // 类目路径
+	Position    int         `gorm:"type:int;not null;default:0" json:"position"`     // 同级类目中的排序位置，从 0 开始
 	Children    []*Category `gorm:"-" json:"children"`                               // 子类目，不存储在数据库，用于递归构建树结构
 }
 
@@ -5,14 +5,43 @@ package model
 
 import "jank.com/jank_blog/internal/model/base"
 
+// 评论审核状态
+const (
+	COMMENT_STATUS_PENDING  = "pending"  // 待审核
+	COMMENT_STATUS_APPROVED = "approved" // 已通过
+	COMMENT_STATUS_SPAM     = "spam"     // 垃圾评论
+	COMMENT_STATUS_REJECTED = "rejected" // 已拒绝
+)
+
+// 评论列表排序方式
+const (
+	COMMENT_SORT_NEWEST    = "newest"    // 最新优先
+	COMMENT_SORT_OLDEST    = "oldest"    // 最早优先
+	COMMENT_SORT_REACTIONS = "reactions" // 互动最多优先
+)
+
 // Comment 评论模型
 type Comment struct {
 	base.Base
-	Content          string     `gorm:"type:varchar(1024);not null" json:"content"`          // 评论内容
-	AccountId        int64      `gorm:"type:bigint;not null;index" json:"account_id"`        // 所属用户ID
-	PostId           int64      `gorm:"type:bigint;not null;index" json:"post_id"`           // 所属文章ID
-	ReplyToCommentId int64      `gorm:"type:bigint;default:null" json:"reply_to_comment_id"` // 目标评论ID
-	Replies          []*Comment `gorm:"-" json:"replies"`                                    // 子评论列表，用于构建图结构
+	Content          string     `gorm:"type:text;not null" json:"content"`                                                        // 评论内容（Markdown 源文本）
+	ContentHTML      string     `gorm:"type:text" json:"content_html"`                                                            // 按评论 Markdown 子集渲染并清洗后的 HTML
+	AccountId        int64      `gorm:"type:bigint;not null;index" json:"account_id"`                                             // 所属用户ID，游客评论为 0
+	PostId           int64      `gorm:"type:bigint;not null;index" json:"post_id"`                                                // 所属文章ID
+	ReplyToCommentId int64      `gorm:"type:bigint;default:null" json:"reply_to_comment_id"`                                      // 目标评论ID
+	AuthorName       string     `gorm:"type:varchar(64);not null;default:''" json:"author_name"`                                  // 评论者昵称
+	AuthorEmail      string     `gorm:"type:varchar(255);not null;default:''" json:"author_email"`                                // 评论者邮箱，不对外展示
+	AuthorWebsite    string     `gorm:"type:varchar(255);not null;default:''" json:"author_website"`                              // 评论者个人网站
+	AuthorIP         string     `gorm:"type:varchar(64);not null;default:''" json:"author_ip"`                                    // 评论者 IP，不对外展示
+	IsAuthorReply    bool       `gorm:"type:boolean;not null;default:false" json:"is_author_reply"`                               // 是否为博主回复
+	Status           string     `gorm:"type:varchar(16);not null;default:'approved';index" json:"status"`                         // 审核状态
+	ReactionCount    int64      `gorm:"type:bigint;not null;default:0;index" json:"reaction_count"`                               // 互动（点赞）次数
+	EditTokenHash    string     `gorm:"type:varchar(64);not null;default:''" json:"edit_token_hash"`                              // 游客编辑凭证的 SHA-256 摘要
+	IsEdited         bool       `gorm:"type:boolean;not null;default:false" json:"is_edited"`                                     // 是否被编辑过
+	EditedAt         int64      `gorm:"type:bigint;not null;default:0" json:"edited_at"`                                          // 最近一次编辑时间
+	SpamLabel        string     `gorm:"type:varchar(8);not null;default:''" json:"spam_label"`                                    // 已用于训练垃圾评论分类器的标签：spam、ham，未训练为空
+	ImportSource     string     `gorm:"type:varchar(16);not null;default:'';uniqueIndex:idx_comment_import" json:"import_source"` // 导入来源：disqus、wordpress，站内评论为空
+	ImportID         *string    `gorm:"type:varchar(64);uniqueIndex:idx_comment_import" json:"import_id"`                         // 导入来源中的评论 ID，站内评论为 NULL，不参与唯一约束
+	Replies          []*Comment `gorm:"-" json:"replies"`                                                                         // 子评论列表，用于构建图结构
 }
 
 // TableName 指定表名
@@ -0,0 +1,22 @@
+// Package model 提供评论编辑历史数据模型定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// CommentRevision 评论编辑前的历史版本
+type CommentRevision struct {
+	base.Base
+	CommentId       int64  `gorm:"type:bigint;not null;index" json:"comment_id"`            // 所属评论ID
+	Content         string `gorm:"type:text;not null" json:"content"`                       // 编辑前的评论内容
+	EditorAccountId int64  `gorm:"type:bigint;not null;default:0" json:"editor_account_id"` // 编辑者账户ID，游客编辑为 0
+	EditorIP        string `gorm:"type:varchar(64);not null;default:''" json:"editor_ip"`   // 编辑者 IP
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (CommentRevision) TableName() string {
+	return "comment_revisions"
+}
@@ -6,9 +6,14 @@ package model
 import (
 	account "jank.com/jank_blog/internal/model/account"
 	association "jank.com/jank_blog/internal/model/association"
+	audit "jank.com/jank_blog/internal/model/audit"
 	category "jank.com/jank_blog/internal/model/category"
 	comment "jank.com/jank_blog/internal/model/comment"
+	notification "jank.com/jank_blog/internal/model/notification"
+	oss "jank.com/jank_blog/internal/model/oss"
 	post "jank.com/jank_blog/internal/model/post"
+	spam "jank.com/jank_blog/internal/model/spam"
+	webmention "jank.com/jank_blog/internal/model/webmention"
 )
 
 // GetAllModels 获取并注册所有模型
@@ -18,6 +23,9 @@ func GetAllModels() []interface{} {
 	return []interface{}{
 		// account 模块
 		&account.Account{},
+		&account.RecoveryCode{},
+		&account.AccessToken{},
+		&account.ExternalIdentity{},
 
 		// post 模块
 		&post.Post{},
@@ -27,6 +35,22 @@ func GetAllModels() []interface{} {
 
 		// comment 模块
 		&comment.Comment{},
+		&comment.CommentRevision{},
+
+		// notification 模块
+		&notification.EmailPreference{},
+
+		// spam 模块
+		&spam.SpamToken{},
+
+		// webmention 模块
+		&webmention.Webmention{},
+
+		// oss 模块
+		&oss.OssObject{},
+
+		// audit 模块
+		&audit.AuditLog{},
 
 		// association 跨模块中间表
 		&association.PostCategory{},
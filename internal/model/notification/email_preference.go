@@ -0,0 +1,21 @@
+// Package model 提供邮件通知偏好数据模型定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// EmailPreference 按邮箱地址保存的邮件通知偏好，记录在首次退订时创建，没有记录的地址视为全部订阅
+type EmailPreference struct {
+	base.Base
+	Email          string `gorm:"type:varchar(255);not null;uniqueIndex" json:"email"` // 邮箱地址，统一小写
+	NotifyReplies  bool   `gorm:"type:boolean;not null" json:"notify_replies"`         // 是否接收评论回复通知
+	NotifyComments bool   `gorm:"type:boolean;not null" json:"notify_comments"`        // 是否接收新评论通知（博主）
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (EmailPreference) TableName() string {
+	return "email_preferences"
+}
@@ -0,0 +1,24 @@
+// Package model 提供对象存储文件数据模型定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// OssObject 通过接口上传到对象存储的文件，记录上传者以便导出账户数据，文件被删除后逻辑删除
+type OssObject struct {
+	base.Base
+	AccountID   int64  `gorm:"type:bigint;not null;index" json:"account_id"`              // 上传者账户ID
+	BucketName  string `gorm:"type:varchar(64);not null" json:"bucket_name"`              // 存储桶名称
+	ObjectName  string `gorm:"type:varchar(255);not null" json:"object_name"`             // 对象名称
+	FileName    string `gorm:"type:varchar(255);not null" json:"file_name"`               // 上传时的原始文件名
+	Size        int64  `gorm:"type:bigint;not null;default:0" json:"size"`                // 文件大小，单位字节
+	ContentType string `gorm:"type:varchar(128);not null;default:''" json:"content_type"` // 文件 MIME 类型
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (OssObject) TableName() string {
+	return "oss_objects"
+}
@@ -4,6 +4,8 @@
 package model
 
 import (
+	"time"
+
 	"jank.com/jank_blog/internal/model/base"
 )
 
@@ -11,10 +13,33 @@ import (
 type Post struct {
 	base.Base
 	Title           string `gorm:"type:varchar(255);not null;index" json:"title"`               // 标题
+	Slug            string `gorm:"type:varchar(255);not null;default:'';index" json:"slug"`     // 别名，用于匹配迁移前的文章地址
+	AuthorID        int64  `gorm:"type:bigint;not null;default:0;index" json:"author_id"`       // 作者账户ID
 	Image           string `gorm:"type:varchar(255)" json:"image"`                              // 图片
 	Visibility      bool   `gorm:"type:boolean;not null;default:false;index" json:"visibility"` // 可见性，默认不可见
 	ContentMarkdown string `gorm:"type:text" json:"contentMarkdown"`                            // Markdown 内容
 	ContentHTML     string `gorm:"type:text" json:"contentHtml"`                                // 渲染后的 HTML 内容
+
+	CommentsDisabled       bool `gorm:"type:boolean;not null;default:false" json:"commentsDisabled"`      // 是否关闭评论
+	CommentsCloseAfterDays int  `gorm:"type:int;not null;default:0" json:"commentsCloseAfterDays"`        // 发布多少天后自动关闭评论，0 表示不限
+	CommentsLoginRequired  bool `gorm:"type:boolean;not null;default:false" json:"commentsLoginRequired"` // 是否仅允许登录用户评论
+}
+
+// CommentsOpen 判断文章当前是否开放评论（不含登录限制）
+// 参数：
+//   - now: 当前时间
+//
+// 返回值：
+//   - bool: 是否开放评论
+func (p *Post) CommentsOpen(now time.Time) bool {
+	if p.CommentsDisabled {
+		return false
+	}
+	if p.CommentsCloseAfterDays > 0 {
+		closeAt := time.Unix(p.GmtCreate, 0).AddDate(0, 0, p.CommentsCloseAfterDays)
+		return now.Before(closeAt)
+	}
+	return true
 }
 
 // TableName 指定表名
@@ -0,0 +1,24 @@
+// Package model 提供垃圾评论贝叶斯分类器的数据模型定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// SPAM_TOKEN_DOCUMENTS 记录训练样本总数的保留词元，分词结果不会包含 # 字符
+const SPAM_TOKEN_DOCUMENTS = "#documents"
+
+// SpamToken 贝叶斯分类器的词元统计模型，计数为包含该词元的样本数
+type SpamToken struct {
+	base.Base
+	Token     string `gorm:"type:varchar(64);not null;uniqueIndex" json:"token"` // 词元
+	SpamCount int64  `gorm:"type:bigint;not null;default:0" json:"spam_count"`   // 垃圾样本计数
+	HamCount  int64  `gorm:"type:bigint;not null;default:0" json:"ham_count"`    // 正常样本计数
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (SpamToken) TableName() string {
+	return "spam_tokens"
+}
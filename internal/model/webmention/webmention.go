@@ -0,0 +1,34 @@
+// Package model 提供 Webmention 数据模型定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// Webmention 状态常量
+const (
+	WEBMENTION_STATUS_PENDING  = "pending"  // 待校验
+	WEBMENTION_STATUS_VERIFIED = "verified" // 校验通过
+	WEBMENTION_STATUS_REJECTED = "rejected" // 校验失败
+)
+
+// Webmention 外部站点对文章的 Webmention 互动模型
+type Webmention struct {
+	base.Base
+	Source     string `gorm:"type:varchar(1024);not null" json:"source"`                       // 来源页面地址
+	Target     string `gorm:"type:varchar(1024);not null" json:"target"`                       // 目标文章地址
+	PostId     int64  `gorm:"type:bigint;not null;index" json:"post_id"`                       // 所属文章ID
+	Type       string `gorm:"type:varchar(32);not null;default:'mention'" json:"type"`         // 互动类型：mention、reply、like、repost、bookmark
+	Status     string `gorm:"type:varchar(32);not null;default:'pending';index" json:"status"` // 校验状态
+	Title      string `gorm:"type:varchar(255);default:''" json:"title"`                       // 来源页面标题
+	Author     string `gorm:"type:varchar(255);default:''" json:"author"`                      // 来源页面作者
+	VerifiedAt int64  `gorm:"type:bigint;default:0" json:"verified_at"`                        // 最近一次校验时间
+	Attempts   int    `gorm:"type:int;not null;default:0" json:"-"`                            // 因来源页面暂时无法访问而失败的校验次数
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (Webmention) TableName() string {
+	return "webmentions"
+}
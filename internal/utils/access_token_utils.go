@@ -0,0 +1,160 @@
+// Package utils 提供个人访问令牌生成与授权范围工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 个人访问令牌授权范围
+const (
+	SCOPE_POSTS_WRITE       = "posts:write"       // 发布、编辑、删除文章
+	SCOPE_CATEGORIES_WRITE  = "categories:write"  // 管理类目
+	SCOPE_COMMENTS_MODERATE = "comments:moderate" // 审核评论
+	SCOPE_OSS_READ          = "oss:read"          // 下载、列举对象存储文件
+	SCOPE_OSS_WRITE         = "oss:write"         // 上传、下载、删除对象存储文件
+)
+
+const (
+	ACCESS_TOKEN_PREFIX      = "jank_pat_" // 个人访问令牌前缀，用于与 JWT 区分
+	accessTokenBytes         = 32          // 个人访问令牌随机字节数
+	accessTokenHintLength    = 4           // 个人访问令牌展示的末尾字符数
+	accessTokenScopeSplitter = ","         // 授权范围存储分隔符
+)
+
+// AUTH_TOKEN_SCOPES_CONTEXT_KEY 使用个人访问令牌认证时存储在 Echo 上下文中的授权范围键名
+const AUTH_TOKEN_SCOPES_CONTEXT_KEY = "auth_token_scopes"
+
+// scopePermissions 授权范围对应的权限，令牌的实际权限为账户角色权限与授权范围权限的交集
+var scopePermissions = map[string][]string{
+	SCOPE_POSTS_WRITE:       {PERMISSION_POST_CREATE, PERMISSION_POST_EDIT_ANY},
+	SCOPE_CATEGORIES_WRITE:  {PERMISSION_CATEGORY_MANAGE},
+	SCOPE_COMMENTS_MODERATE: {PERMISSION_COMMENT_MODERATE},
+	SCOPE_OSS_READ:          {PERMISSION_FILE_MANAGE},
+	SCOPE_OSS_WRITE:         {PERMISSION_FILE_MANAGE},
+}
+
+// IsValidScope 判断授权范围是否存在
+// 参数：
+//   - scope: 授权范围
+//
+// 返回值：
+//   - bool: 授权范围是否存在
+func IsValidScope(scope string) bool {
+	_, ok := scopePermissions[scope]
+	return ok
+}
+
+// RoleCanGrantScope 判断角色能否创建带有该授权范围的令牌，角色需至少拥有授权范围中的一项权限
+// 参数：
+//   - role: 账户角色
+//   - scope: 授权范围
+//
+// 返回值：
+//   - bool: 是否可以授予
+func RoleCanGrantScope(role, scope string) bool {
+	for _, permission := range scopePermissions[scope] {
+		if RoleHasPermission(role, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopesGrantPermission 判断授权范围是否包含权限
+// 参数：
+//   - scopes: 授权范围列表
+//   - permission: 权限
+//
+// 返回值：
+//   - bool: 是否包含
+func ScopesGrantPermission(scopes []string, permission string) bool {
+	for _, scope := range scopes {
+		for _, p := range scopePermissions[scope] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetAuthTokenScopes 获取当前请求所用个人访问令牌的授权范围
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - []string: 授权范围
+//   - bool: 请求是否使用个人访问令牌认证
+func GetAuthTokenScopes(c echo.Context) ([]string, bool) {
+	scopes, ok := c.Get(AUTH_TOKEN_SCOPES_CONTEXT_KEY).([]string)
+	return scopes, ok
+}
+
+// IsAccessToken 判断令牌字符串是否为个人访问令牌
+// 参数：
+//   - token: 令牌字符串
+//
+// 返回值：
+//   - bool: 是否为个人访问令牌
+func IsAccessToken(token string) bool {
+	return strings.HasPrefix(token, ACCESS_TOKEN_PREFIX)
+}
+
+// GenerateAccessToken 生成个人访问令牌
+// 返回值：
+//   - string: 令牌明文，仅在创建时返回一次
+//   - string: 令牌摘要
+//   - string: 令牌末尾字符
+//   - error: 生成过程中的错误
+func GenerateAccessToken() (string, string, string, error) {
+	buf := make([]byte, accessTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", fmt.Errorf("生成个人访问令牌失败: %w", err)
+	}
+
+	token := ACCESS_TOKEN_PREFIX + base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashAccessToken(token), token[len(token)-accessTokenHintLength:], nil
+}
+
+// HashAccessToken 计算个人访问令牌摘要，令牌为高熵随机串，直接使用 SHA-256
+// 参数：
+//   - token: 令牌明文
+//
+// 返回值：
+//   - string: 十六进制摘要
+func HashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// JoinScopes 将授权范围列表序列化为存储格式
+// 参数：
+//   - scopes: 授权范围列表
+//
+// 返回值：
+//   - string: 以逗号分隔的授权范围
+func JoinScopes(scopes []string) string {
+	return strings.Join(scopes, accessTokenScopeSplitter)
+}
+
+// SplitScopes 将存储格式的授权范围解析为列表
+// 参数：
+//   - scopes: 以逗号分隔的授权范围
+//
+// 返回值：
+//   - []string: 授权范围列表
+func SplitScopes(scopes string) []string {
+	if scopes == "" {
+		return []string{}
+	}
+	return strings.Split(scopes, accessTokenScopeSplitter)
+}
@@ -0,0 +1,24 @@
+// Package utils 提供异步任务上下文工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/labstack/echo/v4"
+)
+
+// detachedEcho 仅用于构造脱离请求生命周期的上下文
+var detachedEcho = echo.New()
+
+// NewDetachedContext 创建脱离 HTTP 请求生命周期的 Echo 上下文
+// Echo 的请求上下文会在响应结束后被回收复用，异步任务需使用独立的上下文访问 mapper 层
+// 返回值：
+//   - echo.Context: 独立的 Echo 上下文
+func NewDetachedContext() echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(context.Background())
+	return detachedEcho.NewContext(req, httptest.NewRecorder())
+}
@@ -31,7 +31,15 @@ var emailServers = map[string]struct {
 	"outlook": {"smtp.office365.com", 587, false}, // Outlook 使用 TLS 加密
 }
 
-// SendEmail 发送邮件到指定邮箱
+// EmailMessage 邮件内容
+type EmailMessage struct {
+	Subject  string            // 邮件主题
+	TextBody string            // 纯文本正文
+	HTMLBody string            // HTML 正文，非空时作为纯文本正文的替代内容
+	Headers  map[string]string // 额外的邮件头，如 List-Unsubscribe
+}
+
+// SendEmail 发送注册验证码纯文本邮件到指定邮箱
 // 参数：
 //   - content: 邮件内容
 //   - toEmails: 目标邮箱
@@ -40,6 +48,18 @@ var emailServers = map[string]struct {
 //   - bool: 发送成功返回 true，失败返回 false
 //   - error: 执行过程中的错误
 func SendEmail(content string, toEmails []string) (bool, error) {
+	return SendEmailMessage(&EmailMessage{Subject: EMAIL_SUBJECT, TextBody: content}, toEmails)
+}
+
+// SendEmailMessage 发送自定义主题、正文与邮件头的邮件到指定邮箱
+// 参数：
+//   - msg: 邮件内容
+//   - toEmails: 目标邮箱
+//
+// 返回值：
+//   - bool: 发送成功返回 true，失败返回 false
+//   - error: 执行过程中的错误
+func SendEmailMessage(msg *EmailMessage, toEmails []string) (bool, error) {
 	config, err := configs.LoadConfig()
 	if err != nil {
 		global.SysLog.Errorf("加载邮件配置失败: %v", err)
@@ -48,14 +68,23 @@ func SendEmail(content string, toEmails []string) (bool, error) {
 
 	// 获取SMTP配置
 	emailType := config.AppConfig.Email.EmailType
-	serverConfig := emailServers[emailType]
+	serverConfig, ok := emailServers[emailType]
+	if !ok {
+		return false, fmt.Errorf("不支持的邮箱类型: %s", emailType)
+	}
 
 	// 创建邮件
 	m := gomail.NewMessage()
 	m.SetHeader("From", config.AppConfig.Email.FromEmail)
 	m.SetHeader("To", toEmails...)
-	m.SetHeader("Subject", EMAIL_SUBJECT)
-	m.SetBody("text/plain", content)
+	m.SetHeader("Subject", msg.Subject)
+	for key, value := range msg.Headers {
+		m.SetHeader(key, value)
+	}
+	m.SetBody("text/plain", msg.TextBody)
+	if msg.HTMLBody != "" {
+		m.AddAlternative("text/html", msg.HTMLBody)
+	}
 
 	// 配置发送器
 	d := gomail.NewDialer(
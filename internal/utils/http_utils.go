@@ -0,0 +1,167 @@
+// Package utils 提供对外 HTTP 请求相关工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	HTTP_CLIENT_TIMEOUT    = 10 * time.Second // 对外请求超时时间
+	HTTP_MAX_REDIRECTS     = 5                // 最大重定向次数
+	HTTP_MAX_RESPONSE_SIZE = 1 << 20          // 读取响应体的最大字节数（1 MB）
+	HTTP_USER_AGENT        = "Jank-Blog"      // 对外请求使用的 User-Agent
+)
+
+// deniedNetworks 对外请求禁止连接的地址段：内网、回环、链路本地、运营商级 NAT、保留及文档地址等
+var deniedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",       // 本网络
+	"10.0.0.0/8",      // 私有网络
+	"100.64.0.0/10",   // 运营商级 NAT
+	"127.0.0.0/8",     // 回环
+	"169.254.0.0/16",  // 链路本地
+	"172.16.0.0/12",   // 私有网络
+	"192.0.0.0/24",    // IETF 协议分配
+	"192.0.2.0/24",    // 文档示例
+	"192.88.99.0/24",  // 6to4 中继任播
+	"192.168.0.0/16",  // 私有网络
+	"198.18.0.0/15",   // 基准测试
+	"198.51.100.0/24", // 文档示例
+	"203.0.113.0/24",  // 文档示例
+	"224.0.0.0/4",     // 组播
+	"240.0.0.0/4",     // 保留及广播
+	"::/128",          // 未指定地址
+	"::1/128",         // 回环
+	"64:ff9b::/96",    // NAT64
+	"64:ff9b:1::/48",  // 本地 NAT64
+	"100::/64",        // 丢弃前缀
+	"2001::/23",       // IETF 协议分配
+	"2001:db8::/32",   // 文档示例
+	"2002::/16",       // 6to4
+	"fc00::/7",        // 唯一本地地址
+	"fe80::/10",       // 链路本地
+	"fec0::/10",       // 站点本地（已废弃）
+	"ff00::/8",        // 组播
+)
+
+// NewSafeHTTPClient 创建访问外部地址的 HTTP 客户端，默认拒绝连接内网、回环等地址以防止 SSRF
+// 参数：
+//   - allowPrivate: 是否允许访问内网地址
+//
+// 返回值：
+//   - *http.Client: HTTP 客户端
+func NewSafeHTTPClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: HTTP_CLIENT_TIMEOUT,
+		Control: func(network, address string, conn syscall.RawConn) error {
+			if allowPrivate {
+				return nil
+			}
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if isDeniedIP(net.ParseIP(host)) {
+				return fmt.Errorf("禁止访问内网地址: %s", host)
+			}
+			return nil
+		},
+	}
+
+	// 不使用环境变量中的代理：经代理转发时拨号校验只能看到代理地址，无法拦截目标内网地址
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   HTTP_CLIENT_TIMEOUT,
+		ResponseHeaderTimeout: HTTP_CLIENT_TIMEOUT,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	return &http.Client{
+		Timeout:   HTTP_CLIENT_TIMEOUT,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= HTTP_MAX_REDIRECTS {
+				return fmt.Errorf("重定向次数超过 %d 次", HTTP_MAX_REDIRECTS)
+			}
+			return nil
+		},
+	}
+}
+
+// isDeniedIP 判断 IP 是否位于禁止访问的地址段
+// 参数：
+//   - ip: 待检查的 IP，无法解析时视为禁止
+//
+// 返回值：
+//   - bool: 是否禁止访问
+func isDeniedIP(ip net.IP) bool {
+	if ip == nil {
+		return true
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	for _, network := range deniedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// mustParseCIDRs 解析 CIDR 列表，格式错误时直接 panic
+// 参数：
+//   - cidrs: CIDR 字符串列表
+//
+// 返回值：
+//   - []*net.IPNet: 解析后的地址段
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(fmt.Sprintf("解析 CIDR 失败: %s", cidr))
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// ReadLimitedBody 读取响应体，超过上限的部分会被截断
+// 参数：
+//   - body: 响应体
+//
+// 返回值：
+//   - []byte: 读取到的内容
+//   - error: 读取过程中的错误
+func ReadLimitedBody(body io.Reader) ([]byte, error) {
+	return io.ReadAll(io.LimitReader(body, HTTP_MAX_RESPONSE_SIZE))
+}
+
+// NewOutgoingRequest 创建带默认 User-Agent 的对外请求
+// 参数：
+//   - ctx: 请求上下文
+//   - method: 请求方法
+//   - rawURL: 请求地址
+//   - body: 请求体
+//
+// 返回值：
+//   - *http.Request: HTTP 请求
+//   - error: 创建过程中的错误
+func NewOutgoingRequest(ctx context.Context, method, rawURL string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", HTTP_USER_AGENT)
+	return req, nil
+}
@@ -0,0 +1,259 @@
+// Package utils 提供JWT签名密钥加载与轮换工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"jank.com/jank_blog/configs"
+)
+
+const (
+	JWT_ALGORITHM_HS256 = "HS256" // HMAC-SHA256 对称签名
+	JWT_ALGORITHM_RS256 = "RS256" // RSA-SHA256 非对称签名
+	JWT_ALGORITHM_EDDSA = "EdDSA" // Ed25519 非对称签名
+
+	jwtMinSecretBytes = 32   // HS256 密钥最小字节数
+	jwtMinRSABits     = 2048 // RSA 密钥最小位数
+)
+
+// JWTPublicKey 可对外公开的 JWT 校验公钥
+type JWTPublicKey struct {
+	Kid       string           // 密钥标识
+	Algorithm string           // 签名算法
+	PublicKey crypto.PublicKey // 公钥，*rsa.PublicKey 或 ed25519.PublicKey
+}
+
+// jwtKey 已加载的 JWT 密钥
+type jwtKey struct {
+	kid       string            // 密钥标识
+	method    jwt.SigningMethod // 签名算法
+	signKey   interface{}       // 签名密钥，仅配置私钥或对称密钥时存在
+	verifyKey interface{}       // 校验密钥
+}
+
+// jwtKeyring 由配置加载的 JWT 密钥集合
+type jwtKeyring struct {
+	active        *jwtKey            // 当前用于签发令牌的密钥
+	keys          map[string]*jwtKey // 按 kid 索引的全部密钥
+	order         []string           // 密钥在配置中的顺序
+	accessExpire  time.Duration      // Access Token 有效期
+	refreshExpire time.Duration      // Refresh Token 有效期
+}
+
+var (
+	jwtKeyringLock   sync.Mutex
+	jwtKeyringCache  *jwtKeyring       // 已加载的密钥集合
+	jwtKeyringSource configs.JWTConfig // 密钥集合对应的配置，配置热更新后重新加载
+)
+
+// InitJWTKeyring 加载并校验 JWT 签名密钥配置，启动时调用以尽早暴露配置错误
+// 返回值：
+//   - error: 加载过程中的错误
+func InitJWTKeyring() error {
+	_, err := loadJWTKeyring()
+	return err
+}
+
+// JWTPublicKeys 获取全部非对称密钥的公钥，用于对外发布 JWKS，对称密钥不会被公开
+// 返回值：
+//   - []JWTPublicKey: 公钥列表
+//   - error: 加载过程中的错误
+func JWTPublicKeys() ([]JWTPublicKey, error) {
+	keyring, err := loadJWTKeyring()
+	if err != nil {
+		return nil, err
+	}
+
+	publicKeys := make([]JWTPublicKey, 0, len(keyring.order))
+	for _, kid := range keyring.order {
+		key := keyring.keys[kid]
+		if key.method == jwt.SigningMethodHS256 {
+			continue
+		}
+		publicKeys = append(publicKeys, JWTPublicKey{
+			Kid:       key.kid,
+			Algorithm: key.method.Alg(),
+			PublicKey: key.verifyKey,
+		})
+	}
+	return publicKeys, nil
+}
+
+// loadJWTKeyring 获取 JWT 密钥集合，配置未变化时复用已加载的密钥
+// 返回值：
+//   - *jwtKeyring: 密钥集合
+//   - error: 加载过程中的错误
+func loadJWTKeyring() (*jwtKeyring, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("加载 JWT 配置失败: %w", err)
+	}
+
+	jwtKeyringLock.Lock()
+	defer jwtKeyringLock.Unlock()
+
+	if jwtKeyringCache != nil && reflect.DeepEqual(jwtKeyringSource, cfg.AppConfig.JWT) {
+		return jwtKeyringCache, nil
+	}
+
+	keyring, err := buildJWTKeyring(cfg.AppConfig.JWT)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtKeyringCache = keyring
+	jwtKeyringSource = cfg.AppConfig.JWT
+	return keyring, nil
+}
+
+// buildJWTKeyring 根据配置构建 JWT 密钥集合
+// 参数：
+//   - cfg: JWT 配置
+//
+// 返回值：
+//   - *jwtKeyring: 密钥集合
+//   - error: 构建过程中的错误
+func buildJWTKeyring(cfg configs.JWTConfig) (*jwtKeyring, error) {
+	if cfg.AccessTokenExpire <= 0 || cfg.RefreshTokenExpire <= 0 {
+		return nil, fmt.Errorf("JWT 令牌有效期必须大于 0")
+	}
+	if cfg.RefreshTokenExpire < cfg.AccessTokenExpire {
+		return nil, fmt.Errorf("Refresh Token 有效期不能短于 Access Token 有效期")
+	}
+
+	keyring := &jwtKeyring{
+		keys:          make(map[string]*jwtKey, len(cfg.Keys)),
+		accessExpire:  time.Duration(cfg.AccessTokenExpire) * time.Second,
+		refreshExpire: time.Duration(cfg.RefreshTokenExpire) * time.Second,
+	}
+	for _, keyCfg := range cfg.Keys {
+		if keyCfg.Kid == "" {
+			return nil, fmt.Errorf("JWT 密钥缺少 KID")
+		}
+		if _, exists := keyring.keys[keyCfg.Kid]; exists {
+			return nil, fmt.Errorf("JWT 密钥 kid「%s」重复", keyCfg.Kid)
+		}
+
+		key, err := loadJWTKey(keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("加载 JWT 密钥「%s」失败: %w", keyCfg.Kid, err)
+		}
+		keyring.keys[key.kid] = key
+		keyring.order = append(keyring.order, key.kid)
+	}
+
+	active, ok := keyring.keys[cfg.ActiveKid]
+	if !ok {
+		return nil, fmt.Errorf("未找到 ACTIVE_KID「%s」对应的 JWT 密钥", cfg.ActiveKid)
+	}
+	if active.signKey == nil {
+		return nil, fmt.Errorf("JWT 密钥「%s」未配置私钥，无法用于签发令牌", active.kid)
+	}
+	keyring.active = active
+
+	return keyring, nil
+}
+
+// loadJWTKey 按算法加载单个 JWT 密钥
+// 参数：
+//   - cfg: 密钥配置
+//
+// 返回值：
+//   - *jwtKey: 已加载的密钥
+//   - error: 加载过程中的错误
+func loadJWTKey(cfg configs.JWTKeyConfig) (*jwtKey, error) {
+	key := &jwtKey{kid: cfg.Kid}
+
+	switch cfg.Algorithm {
+	case JWT_ALGORITHM_HS256:
+		if len(cfg.Secret) < jwtMinSecretBytes {
+			return nil, fmt.Errorf("HS256 密钥长度不能少于 %d 字节", jwtMinSecretBytes)
+		}
+		key.method = jwt.SigningMethodHS256
+		key.signKey = []byte(cfg.Secret)
+		key.verifyKey = []byte(cfg.Secret)
+
+	case JWT_ALGORITHM_RS256:
+		key.method = jwt.SigningMethodRS256
+		if cfg.PrivateKeyFile != "" {
+			pemBytes, err := os.ReadFile(cfg.PrivateKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("读取私钥文件失败: %w", err)
+			}
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, fmt.Errorf("解析 RSA 私钥失败: %w", err)
+			}
+			key.signKey = privateKey
+			key.verifyKey = &privateKey.PublicKey
+		}
+		if cfg.PublicKeyFile != "" {
+			pemBytes, err := os.ReadFile(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("读取公钥文件失败: %w", err)
+			}
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, fmt.Errorf("解析 RSA 公钥失败: %w", err)
+			}
+			if key.verifyKey != nil && !publicKey.Equal(key.verifyKey) {
+				return nil, fmt.Errorf("RSA 公钥与私钥不匹配")
+			}
+			key.verifyKey = publicKey
+		}
+		if key.verifyKey == nil {
+			return nil, fmt.Errorf("RS256 密钥需配置私钥或公钥文件")
+		}
+		if bits := key.verifyKey.(*rsa.PublicKey).N.BitLen(); bits < jwtMinRSABits {
+			return nil, fmt.Errorf("RSA 密钥长度不能少于 %d 位，当前为 %d 位", jwtMinRSABits, bits)
+		}
+
+	case JWT_ALGORITHM_EDDSA:
+		key.method = jwt.SigningMethodEdDSA
+		if cfg.PrivateKeyFile != "" {
+			pemBytes, err := os.ReadFile(cfg.PrivateKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("读取私钥文件失败: %w", err)
+			}
+			privateKey, err := jwt.ParseEdPrivateKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, fmt.Errorf("解析 Ed25519 私钥失败: %w", err)
+			}
+			key.signKey = privateKey
+			key.verifyKey = privateKey.(ed25519.PrivateKey).Public()
+		}
+		if cfg.PublicKeyFile != "" {
+			pemBytes, err := os.ReadFile(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("读取公钥文件失败: %w", err)
+			}
+			publicKey, err := jwt.ParseEdPublicKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, fmt.Errorf("解析 Ed25519 公钥失败: %w", err)
+			}
+			if key.verifyKey != nil && !publicKey.(ed25519.PublicKey).Equal(key.verifyKey) {
+				return nil, fmt.Errorf("Ed25519 公钥与私钥不匹配")
+			}
+			key.verifyKey = publicKey
+		}
+		if key.verifyKey == nil {
+			return nil, fmt.Errorf("EdDSA 密钥需配置私钥或公钥文件")
+		}
+
+	default:
+		return nil, fmt.Errorf("不支持的签名算法「%s」，可选值: %s, %s, %s", cfg.Algorithm, JWT_ALGORITHM_HS256, JWT_ALGORITHM_RS256, JWT_ALGORITHM_EDDSA)
+	}
+
+	return key, nil
+}
@@ -4,37 +4,59 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
 )
 
-var (
-	// 密钥和有效期配置
-	accessSecret      = []byte("jank-blog-secret")         // Access Token 使用的密钥
-	refreshSecret     = []byte("jank-blog-refresh-secret") // Refresh Token 使用的密钥
-	accessExpireTime  = time.Hour * 2                      // Access Token 有效期
-	refreshExpireTime = time.Hour * 48                     // Refresh Token 有效期
-	clockSkew         = 5 * time.Second                    // 允许的时间偏差量
+const (
+	clockSkew = 5 * time.Second // 允许的时间偏差量
+
+	jwtTypeAccess  = "access"  // Access Token 的 typ 声明
+	jwtTypeRefresh = "refresh" // Refresh Token 的 typ 声明
 )
 
+// AUTH_ACCOUNT_ID_CONTEXT_KEY 认证通过后存储在 Echo 上下文中的账户 ID 键名
+const AUTH_ACCOUNT_ID_CONTEXT_KEY = "auth_account_id"
+
+// GetAuthAccountID 获取当前请求已认证的账户 ID
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - int64: 账户ID
+//   - bool: 请求是否已认证
+func GetAuthAccountID(c echo.Context) (int64, bool) {
+	accountID, ok := c.Get(AUTH_ACCOUNT_ID_CONTEXT_KEY).(int64)
+	return accountID, ok && accountID != 0
+}
+
 // GenerateJWT 生成 Access Token 和 Refresh Token
 // 参数：
 //   - accountID: 账户ID
+//   - sessionID: 登录会话ID
+//   - refreshTokenID: Refresh Token 的唯一标识（jti），用于轮换与重用检测
 //
 // 返回值：
 //   - string: Access Token
 //   - string: Refresh Token
 //   - error: 生成过程中的错误
-func GenerateJWT(accountID int64) (string, string, error) {
-	accessTokenString, err := generateToken(accountID, accessSecret, accessExpireTime)
+func GenerateJWT(accountID int64, sessionID, refreshTokenID string) (string, string, error) {
+	keyring, err := loadJWTKeyring()
 	if err != nil {
 		return "", "", err
 	}
 
-	refreshTokenString, err := generateToken(accountID, refreshSecret, refreshExpireTime)
+	accessTokenString, err := generateToken(accountID, sessionID, "", jwtTypeAccess, keyring.active, keyring.accessExpire)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshTokenString, err := generateToken(accountID, sessionID, refreshTokenID, jwtTypeRefresh, keyring.active, keyring.refreshExpire)
 	if err != nil {
 		return "", "", err
 	}
@@ -53,21 +75,28 @@ func GenerateJWT(accountID int64) (string, string, error) {
 func ValidateJWTToken(tokenString string, isRefreshToken bool) (*jwt.Token, error) {
 	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
 
-	secret := accessSecret
-	if isRefreshToken {
-		secret = refreshSecret
+	keyring, err := loadJWTKeyring()
+	if err != nil {
+		return nil, err
 	}
 
-	token, err := validateToken(tokenString, secret)
+	token, err := validateToken(tokenString, keyring)
 	if err != nil {
 		return nil, err
 	}
 
+	expectedType := jwtTypeAccess
+	if isRefreshToken {
+		expectedType = jwtTypeRefresh
+	}
+
 	if claims, ok := token.Claims.(jwt.MapClaims); !ok || !token.Valid {
 		return nil, fmt.Errorf("无效 token")
+	} else if claims["typ"] != expectedType {
+		return nil, fmt.Errorf("token 类型不匹配")
 	} else {
-		if exp, ok := claims["exp"].(float64); ok {
-			if time.Now().UTC().Add(clockSkew).Unix() > int64(exp) {
+		if exp, ok := claimInt64(claims, "exp"); ok {
+			if time.Now().UTC().Add(clockSkew).Unix() > exp {
 				if isRefreshToken {
 					return nil, fmt.Errorf("refresh token 已过期，请重新登录")
 				}
@@ -81,34 +110,72 @@ func ValidateJWTToken(tokenString string, isRefreshToken bool) (*jwt.Token, erro
 	return token, nil
 }
 
-// RefreshTokenLogic 负责刷新 Token
+// RefreshTokenLogic 负责刷新 Token，每次刷新都会轮换 Refresh Token，旧令牌随即作废；
+// 已使用过的 Refresh Token 再次出现时视为泄露，注销其所属的整个登录会话
 // 参数：
+//   - c: Echo 上下文
 //   - refreshTokenString: 刷新令牌字符串
 //
 // 返回值：
 //   - map[string]string: 包含新的 Access Token 和 Refresh Token 的映射
-//   - error: 刷新过程中的错误
-func RefreshTokenLogic(refreshTokenString string) (map[string]string, error) {
-	token, err := ValidateJWTToken(refreshTokenString, true)
+//   - error: 刷新过程中的错误，检测到重用时为 ErrRefreshTokenReused
+func RefreshTokenLogic(c echo.Context, refreshTokenString string) (map[string]string, error) {
+	accountID, sessionID, refreshTokenID, err := ParseRefreshToken(refreshTokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := consumeRefreshToken(c, accountID, sessionID, refreshTokenID); err != nil {
+		return nil, err
+	}
+
+	newAccessToken, newRefreshToken, err := IssueSessionTokens(c, accountID, sessionID)
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		accountID := int64(claims["account_id"].(float64))
+	return map[string]string{
+		"Authorization": newAccessToken,
+		"Refresh-Token": newRefreshToken,
+	}, nil
+}
 
-		newAccessToken, newRefreshToken, err := GenerateJWT(accountID)
-		if err != nil {
-			return nil, err
-		}
+// ParseRefreshToken 从 Refresh Token 中提取 accountID、登录会话ID 与令牌唯一标识
+// 参数：
+//   - tokenString: 令牌字符串
+//
+// 返回值：
+//   - int64: 账户ID
+//   - string: 登录会话ID
+//   - string: 令牌唯一标识（jti）
+//   - error: 解析过程中的错误
+func ParseRefreshToken(tokenString string) (int64, string, string, error) {
+	token, err := ValidateJWTToken(tokenString, true)
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, "", "", fmt.Errorf("无法解析 refresh token 中的 claims")
+	}
+
+	accountID, ok := claimInt64(claims, "account_id")
+	if !ok {
+		return 0, "", "", fmt.Errorf("refresh token 中缺少 account_id")
+	}
+
+	sessionID, ok := claims["session_id"].(string)
+	if !ok || sessionID == "" {
+		return 0, "", "", fmt.Errorf("refresh token 中缺少 session_id")
+	}
 
-		return map[string]string{
-			"Authorization": newAccessToken,
-			"Refresh-Token": newRefreshToken,
-		}, nil
+	refreshTokenID, ok := claims["jti"].(string)
+	if !ok || refreshTokenID == "" {
+		return 0, "", "", fmt.Errorf("refresh token 中缺少 jti")
 	}
 
-	return nil, fmt.Errorf("refresh token 验证失败")
+	return accountID, sessionID, refreshTokenID, nil
 }
 
 // ParseAccountFromJWT 从 JWT 中提取 accountID
@@ -131,50 +198,100 @@ func ParseAccountFromJWT(tokenString string) (int64, error) {
 		return 0, fmt.Errorf("无法解析 access token 中的 claims")
 	}
 
-	accountID, ok := claims["account_id"].(float64)
+	accountID, ok := claimInt64(claims, "account_id")
 	if !ok {
 		return 0, fmt.Errorf("access token 中缺少 account_id")
 	}
 
-	return int64(accountID), nil
+	return accountID, nil
+}
+
+// ParseSessionFromJWT 从 Access Token 中提取 accountID 与登录会话ID
+// 参数：
+//   - tokenString: 令牌字符串
+//
+// 返回值：
+//   - int64: 账户ID
+//   - string: 登录会话ID
+//   - error: 解析过程中的错误
+func ParseSessionFromJWT(tokenString string) (int64, string, error) {
+	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
+
+	token, err := ValidateJWTToken(tokenString, false)
+	if err != nil {
+		return 0, "", err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, "", fmt.Errorf("无法解析 access token 中的 claims")
+	}
+
+	accountID, ok := claimInt64(claims, "account_id")
+	if !ok {
+		return 0, "", fmt.Errorf("access token 中缺少 account_id")
+	}
+
+	sessionID, ok := claims["session_id"].(string)
+	if !ok || sessionID == "" {
+		return 0, "", fmt.Errorf("access token 中缺少 session_id")
+	}
+
+	return accountID, sessionID, nil
 }
 
-// generateToken 通用的 token 生成函数
+// generateToken 通用的 token 生成函数，令牌头部写入签名密钥的 kid
 // 参数：
 //   - accountID: 账户ID
-//   - secret: 密钥
+//   - sessionID: 登录会话ID
+//   - tokenID: 令牌唯一标识（jti），为空时不写入
+//   - tokenType: 令牌类型，access 或 refresh
+//   - key: 签名密钥
 //   - expireTime: 过期时间
 //
 // 返回值：
 //   - string: 生成的令牌
 //   - error: 生成过程中的错误
-func generateToken(accountID int64, secret []byte, expireTime time.Duration) (string, error) {
+func generateToken(accountID int64, sessionID, tokenID, tokenType string, key *jwtKey, expireTime time.Duration) (string, error) {
 	claims := jwt.MapClaims{
 		"account_id": accountID,
+		"session_id": sessionID,
+		"typ":        tokenType,
 		"exp":        time.Now().UTC().Add(expireTime).Unix(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(secret)
+	if tokenID != "" {
+		claims["jti"] = tokenID
+	}
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
+	tokenString, err := token.SignedString(key.signKey)
 	if err != nil {
 		return "", err
 	}
 	return tokenString, nil
 }
 
-// validateToken 验证 token 是否有效
+// validateToken 按令牌头部的 kid 选择密钥验证 token 是否有效，算法须与密钥配置一致
 // 参数：
 //   - tokenString: 令牌字符串
-//   - secret: 密钥
+//   - keyring: 密钥集合
 //
 // 返回值：
 //   - *jwt.Token: 验证通过的令牌
 //   - error: 验证过程中的错误
-func validateToken(tokenString string, secret []byte) (*jwt.Token, error) {
-	token, err := jwt.ParseWithClaims(tokenString, jwt.MapClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+func validateToken(tokenString string, keyring *jwtKeyring) (*jwt.Token, error) {
+	// 雪花 ID 超出 float64 精度，数值声明按 json.Number 解析
+	parser := &jwt.Parser{UseJSONNumber: true}
+	token, err := parser.ParseWithClaims(tokenString, jwt.MapClaims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := keyring.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("未知的签名密钥 kid「%s」", kid)
+		}
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, jwt.ErrSignatureInvalid
 		}
-		return secret, nil
+		return key.verifyKey, nil
 	})
 
 	if err != nil {
@@ -182,3 +299,20 @@ func validateToken(tokenString string, secret []byte) (*jwt.Token, error) {
 	}
 	return token, nil
 }
+
+// claimInt64 读取整数类型的声明
+// 参数：
+//   - claims: 令牌声明
+//   - key: 声明名称
+//
+// 返回值：
+//   - int64: 声明值
+//   - bool: 声明是否存在且为整数
+func claimInt64(claims jwt.MapClaims, key string) (int64, bool) {
+	number, ok := claims[key].(json.Number)
+	if !ok {
+		return 0, false
+	}
+	value, err := number.Int64()
+	return value, err == nil
+}
@@ -0,0 +1,241 @@
+// Package utils 提供基于 Redis 的登录失败计数与临时锁定工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+)
+
+const (
+	LOGIN_FAILURE_CACHE_PREFIX = "LOGIN:FAILURE:" // 登录失败计数缓存前缀，键为 LOGIN:FAILURE:<维度>:<标识>
+	LOGIN_LOCK_CACHE_PREFIX    = "LOGIN:LOCK:"    // 登录锁定缓存前缀，键为 LOGIN:LOCK:<维度>:<标识>
+
+	LOGIN_GUARD_ACCOUNT = "account" // 按账户邮箱计数
+	LOGIN_GUARD_IP      = "ip"      // 按客户端 IP 计数
+
+	loginBackoffMaxShift = 20 // 指数退避的最大位移，防止锁定时长溢出
+)
+
+// LoginLock 登录锁定状态
+type LoginLock struct {
+	Kind       string        // 锁定维度：account 或 ip
+	RetryAfter time.Duration // 剩余锁定时长
+}
+
+// LoginFailure 一次登录失败的记录结果
+type LoginFailure struct {
+	Lock          *LoginLock // 本次失败触发的锁定，未触发时为 nil
+	AccountLocked bool       // 本次失败是否使账户开始进入锁定，仅在失败次数首次达到阈值时为 true
+	Failures      int64      // 账户累计失败次数
+}
+
+// CheckLoginLock 检查账户与客户端 IP 是否处于锁定期
+// 参数：
+//   - c: Echo 上下文
+//   - email: 登录邮箱
+//
+// 返回值：
+//   - *LoginLock: 锁定状态，未锁定时为 nil；两个维度均锁定时返回剩余时间更长的一个
+//   - error: 操作过程中的错误
+func CheckLoginLock(c echo.Context, email string) (*LoginLock, error) {
+	var lock *LoginLock
+	for _, kind := range []string{LOGIN_GUARD_ACCOUNT, LOGIN_GUARD_IP} {
+		ttl, err := global.RedisClient.PTTL(c.Request().Context(), loginGuardKey(LOGIN_LOCK_CACHE_PREFIX, kind, loginGuardIdentity(c, kind, email))).Result()
+		if err != nil {
+			return nil, fmt.Errorf("读取登录锁定状态失败: %w", err)
+		}
+		if ttl > 0 && (lock == nil || ttl > lock.RetryAfter) {
+			lock = &LoginLock{Kind: kind, RetryAfter: ttl}
+		}
+	}
+	return lock, nil
+}
+
+// RecordLoginFailure 记录一次登录失败，累计失败次数达到阈值后按指数退避锁定账户或 IP
+// 参数：
+//   - c: Echo 上下文
+//   - email: 登录邮箱，账户不存在时同样计数，避免通过锁定行为探测账户是否存在
+//
+// 返回值：
+//   - *LoginFailure: 记录结果
+//   - error: 操作过程中的错误
+func RecordLoginFailure(c echo.Context, email string) (*LoginFailure, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("加载登录防护配置失败: %w", err)
+	}
+	guard := cfg.AppConfig.LoginGuard
+
+	result := &LoginFailure{}
+	for _, kind := range []string{LOGIN_GUARD_ACCOUNT, LOGIN_GUARD_IP} {
+		maxFailures := guard.AccountMaxFailures
+		if kind == LOGIN_GUARD_IP {
+			maxFailures = guard.IPMaxFailures
+		}
+		if maxFailures <= 0 {
+			continue
+		}
+
+		identity := loginGuardIdentity(c, kind, email)
+		failures, lockFor, err := recordGuardFailure(c, kind, identity, maxFailures, guard)
+		if err != nil {
+			return nil, err
+		}
+
+		if kind == LOGIN_GUARD_ACCOUNT {
+			result.Failures = failures
+			result.AccountLocked = failures == int64(maxFailures)
+		}
+		if lockFor > 0 && (result.Lock == nil || lockFor > result.Lock.RetryAfter) {
+			result.Lock = &LoginLock{Kind: kind, RetryAfter: lockFor}
+		}
+	}
+	return result, nil
+}
+
+// ResetLoginFailures 登录成功后清除账户的失败计数与锁定，IP 维度的计数保留，防止攻击者用自有账户重置 IP 计数
+// 参数：
+//   - c: Echo 上下文
+//   - email: 登录邮箱
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func ResetLoginFailures(c echo.Context, email string) error {
+	return clearLoginGuard(c, LOGIN_GUARD_ACCOUNT, loginGuardIdentity(c, LOGIN_GUARD_ACCOUNT, email))
+}
+
+// UnlockLogin 解除账户或 IP 的登录锁定并清除失败计数
+// 参数：
+//   - c: Echo 上下文
+//   - kind: 锁定维度：account 或 ip
+//   - identity: 账户邮箱或 IP
+//
+// 返回值：
+//   - bool: 解除前是否处于锁定期
+//   - error: 操作过程中的错误
+func UnlockLogin(c echo.Context, kind, identity string) (bool, error) {
+	if kind == LOGIN_GUARD_ACCOUNT {
+		identity = normalizeLoginEmail(identity)
+	}
+
+	exists, err := global.RedisClient.Exists(c.Request().Context(), loginGuardKey(LOGIN_LOCK_CACHE_PREFIX, kind, identity)).Result()
+	if err != nil {
+		return false, fmt.Errorf("读取登录锁定状态失败: %w", err)
+	}
+	if err := clearLoginGuard(c, kind, identity); err != nil {
+		return false, err
+	}
+	return exists > 0, nil
+}
+
+// recordGuardFailure 累加单个维度的失败次数，达到阈值后设置锁定
+// 参数：
+//   - c: Echo 上下文
+//   - kind: 计数维度
+//   - identity: 计数对象标识
+//   - maxFailures: 开始锁定的失败次数
+//   - guard: 登录防护配置
+//
+// 返回值：
+//   - int64: 累计失败次数
+//   - time.Duration: 本次设置的锁定时长，未锁定时为 0
+//   - error: 操作过程中的错误
+func recordGuardFailure(c echo.Context, kind, identity string, maxFailures int, guard configs.LoginGuardConfig) (int64, time.Duration, error) {
+	ctx := c.Request().Context()
+	failureKey := loginGuardKey(LOGIN_FAILURE_CACHE_PREFIX, kind, identity)
+
+	failures, err := global.RedisClient.Incr(ctx, failureKey).Result()
+	if err != nil {
+		return 0, 0, fmt.Errorf("记录登录失败次数失败: %w", err)
+	}
+	if guard.FailureWindow > 0 {
+		if err := global.RedisClient.Expire(ctx, failureKey, time.Duration(guard.FailureWindow)*time.Second).Err(); err != nil {
+			return 0, 0, fmt.Errorf("设置登录失败计数过期时间失败: %w", err)
+		}
+	}
+
+	if failures < int64(maxFailures) {
+		return failures, 0, nil
+	}
+
+	// 达到阈值后每多失败一次，锁定时长翻倍，直至上限
+	shift := min(failures-int64(maxFailures), loginBackoffMaxShift)
+	lockFor := time.Duration(guard.BaseLockSeconds) * time.Second << shift
+	if maxLock := time.Duration(guard.MaxLockSeconds) * time.Second; maxLock > 0 && lockFor > maxLock {
+		lockFor = maxLock
+	}
+	if lockFor <= 0 {
+		return failures, 0, nil
+	}
+
+	if err := global.RedisClient.Set(ctx, loginGuardKey(LOGIN_LOCK_CACHE_PREFIX, kind, identity), failures, lockFor).Err(); err != nil {
+		return 0, 0, fmt.Errorf("设置登录锁定失败: %w", err)
+	}
+	return failures, lockFor, nil
+}
+
+// clearLoginGuard 清除单个维度的失败计数与锁定
+// 参数：
+//   - c: Echo 上下文
+//   - kind: 计数维度
+//   - identity: 计数对象标识
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func clearLoginGuard(c echo.Context, kind, identity string) error {
+	err := global.RedisClient.Del(c.Request().Context(),
+		loginGuardKey(LOGIN_FAILURE_CACHE_PREFIX, kind, identity),
+		loginGuardKey(LOGIN_LOCK_CACHE_PREFIX, kind, identity),
+	).Err()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("清除登录失败计数失败: %w", err)
+	}
+	return nil
+}
+
+// loginGuardIdentity 获取计数维度对应的标识
+// 参数：
+//   - c: Echo 上下文
+//   - kind: 计数维度
+//   - email: 登录邮箱
+//
+// 返回值：
+//   - string: 账户维度为规范化后的邮箱，IP 维度为客户端 IP
+func loginGuardIdentity(c echo.Context, kind, email string) string {
+	if kind == LOGIN_GUARD_IP {
+		return c.RealIP()
+	}
+	return normalizeLoginEmail(email)
+}
+
+// normalizeLoginEmail 规范化登录邮箱，避免通过大小写变化绕过账户计数
+// 参数：
+//   - email: 登录邮箱
+//
+// 返回值：
+//   - string: 规范化后的邮箱
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// loginGuardKey 生成登录防护缓存键
+// 参数：
+//   - prefix: 缓存前缀
+//   - kind: 计数维度
+//   - identity: 计数对象标识
+//
+// 返回值：
+//   - string: 缓存键
+func loginGuardKey(prefix, kind, identity string) string {
+	return fmt.Sprintf("%s%s:%s", prefix, kind, identity)
+}
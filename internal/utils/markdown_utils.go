@@ -5,13 +5,18 @@ package utils
 
 import (
 	"bytes"
+	"regexp"
 	"sync"
 
+	"github.com/microcosm-cc/bluemonday"
 	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer"
 	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
 )
 
 // 使用 sync.Pool 复用 buffer
@@ -73,6 +78,107 @@ func defaultMarkdownConfig() MarkdownConfig {
 	}
 }
 
+// commentLinkRel 评论中链接统一携带的 rel 属性
+const commentLinkRel = "nofollow ugc"
+
+// commentMarkdownConfig 返回评论使用的受限 Markdown 配置，不启用表格、脚注、标题 ID 等扩展
+// 返回值：
+//   - MarkdownConfig: 评论 Markdown 配置
+func commentMarkdownConfig() MarkdownConfig {
+	return MarkdownConfig{
+		Extensions: []goldmark.Extender{
+			extension.Linkify, // 自动链接支持
+			extension.CJK,     // CJK 支持
+		},
+		ParserOptions: []parser.Option{
+			parser.WithASTTransformers(util.Prioritized(&commentASTTransformer{}, 100)),
+		},
+		RendererOptions: []renderer.Option{
+			html.WithHardWraps(), // 硬换行
+			html.WithXHTML(),     // 生成 XHTML
+		},
+	}
+}
+
+// commentASTTransformer 为评论中的链接添加 rel 属性，并将图片替换为其替代文本
+type commentASTTransformer struct{}
+
+// Transform 实现 parser.ASTTransformer 接口
+// 参数：
+//   - doc: 文档根节点
+//   - reader: 源文本读取器
+//   - pc: 解析上下文
+func (t *commentASTTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	var images []*ast.Image
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.Link, *ast.AutoLink:
+			node.SetAttributeString("rel", []byte(commentLinkRel))
+		case *ast.Image:
+			images = append(images, node)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, img := range images {
+		parent := img.Parent()
+		for child := img.FirstChild(); child != nil; {
+			next := child.NextSibling()
+			parent.InsertBefore(parent, img, child)
+			child = next
+		}
+		parent.RemoveChild(parent, img)
+	}
+}
+
+var (
+	commentMarkdown     goldmark.Markdown
+	commentPolicy       *bluemonday.Policy
+	commentRendererOnce sync.Once
+)
+
+// newCommentPolicy 创建评论 HTML 的白名单策略
+// 返回值：
+//   - *bluemonday.Policy: HTML 清洗策略
+func newCommentPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("p", "br", "em", "strong", "blockquote", "pre", "code", "ul", "ol", "li")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowAttrs("rel").Matching(regexp.MustCompile(`^` + commentLinkRel + `$`)).OnElements("a")
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireParseableURLs(true)
+	return p
+}
+
+// RenderCommentMarkdown 按评论的受限 Markdown 子集渲染 HTML：支持行内代码、代码块、链接、强调、引用与列表，
+// 链接携带 rel="nofollow ugc"，图片仅保留替代文本，原始 HTML 被忽略，渲染结果再经白名单清洗
+// 参数：
+//   - content: Markdown内容
+//
+// 返回值：
+//   - string: 清洗后的 HTML
+//   - error: 渲染过程中的错误
+func RenderCommentMarkdown(content []byte) (string, error) {
+	commentRendererOnce.Do(func() {
+		commentMarkdown = NewMarkdownRenderer(commentMarkdownConfig())
+		commentPolicy = newCommentPolicy()
+	})
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer bufferPool.Put(buf)
+
+	if err := commentMarkdown.Convert(content, buf); err != nil {
+		return "", err
+	}
+
+	return commentPolicy.Sanitize(buf.String()), nil
+}
+
 // RenderMarkdown 将 Markdown 渲染为 HTML
 // 参数：
 //   - content: Markdown内容
@@ -92,3 +198,42 @@ func RenderMarkdown(content []byte) (string, error) {
 
 	return buf.String(), nil
 }
+
+// ExtractMarkdownLinks 提取 Markdown 中的所有链接地址（包括行内链接、引用链接与自动链接），结果已去重
+// 参数：
+//   - content: Markdown内容
+//
+// 返回值：
+//   - []string: 链接地址列表
+func ExtractMarkdownLinks(content []byte) []string {
+	md := NewMarkdownRenderer(defaultMarkdownConfig())
+	doc := md.Parser().Parse(text.NewReader(content))
+
+	seen := make(map[string]struct{})
+	var links []string
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		var dest string
+		switch node := n.(type) {
+		case *ast.Link:
+			dest = string(node.Destination)
+		case *ast.AutoLink:
+			if node.AutoLinkType == ast.AutoLinkURL {
+				dest = string(node.URL(content))
+			}
+		}
+
+		if dest != "" {
+			if _, ok := seen[dest]; !ok {
+				seen[dest] = struct{}{}
+				links = append(links, dest)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return links
+}
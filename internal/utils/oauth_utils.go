@@ -0,0 +1,499 @@
+// Package utils 提供 OAuth2 / OIDC 第三方登录工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+)
+
+const (
+	OAUTH_TYPE_GITHUB = "github" // GitHub OAuth2，不支持 OIDC，通过 API 获取账号信息
+	OAUTH_TYPE_GOOGLE = "google" // Google OIDC
+	OAUTH_TYPE_OIDC   = "oidc"   // 通用 OIDC，通过发现文档获取各端点
+
+	OAUTH_MODE_LOGIN  = "login"  // 第三方登录
+	OAUTH_MODE_LINK   = "link"   // 已登录账户绑定第三方账号
+	OAUTH_MODE_REAUTH = "reauth" // 已登录账户通过已绑定的第三方账号重新验证身份
+
+	OAUTH_STATE_CACHE_PREFIX = "OAUTH:STATE:"   // 授权 state 缓存前缀，键为 OAUTH:STATE:<state>
+	OAUTH_STATE_EXPIRE_TIME  = 10 * time.Minute // 授权 state 有效期，超时须重新发起授权
+	oauthRandomBytes         = 32               // state、PKCE code_verifier 与 nonce 的随机字节数
+
+	GOOGLE_OIDC_ISSUER = "https://accounts.google.com"                 // Google OIDC Issuer
+	GITHUB_AUTH_URL    = "https://github.com/login/oauth/authorize"    // GitHub 授权地址
+	GITHUB_TOKEN_URL   = "https://github.com/login/oauth/access_token" // GitHub 令牌地址
+	GITHUB_API_URL     = "https://api.github.com"                      // GitHub API 地址
+)
+
+var (
+	ErrOAuthDisabled         = errors.New("第三方登录未启用")
+	ErrOAuthProviderNotFound = errors.New("第三方登录提供方不存在")
+	ErrOAuthStateInvalid     = errors.New("授权已过期，请重新发起第三方登录")
+	ErrOAuthExchangeFailed   = errors.New("第三方授权失败") // 提供方拒绝授权码或返回的身份信息未通过校验
+)
+
+// defaultOAuthScopes 各提供方类型未配置 SCOPES 时使用的默认授权范围
+var defaultOAuthScopes = map[string][]string{
+	OAUTH_TYPE_GITHUB: {"read:user", "user:email"},
+	OAUTH_TYPE_GOOGLE: {"openid", "email", "profile"},
+	OAUTH_TYPE_OIDC:   {"openid", "email", "profile"},
+}
+
+// OAuthState 发起授权时保存的上下文，回调时凭 state 取出并校验
+type OAuthState struct {
+	Provider     string `json:"provider"`      // 提供方名称
+	Mode         string `json:"mode"`          // 授权用途：login、link、reauth
+	AccountID    int64  `json:"account_id"`    // 发起授权的账户ID，仅 link 与 reauth 用途有值
+	RedirectURI  string `json:"redirect_uri"`  // 发起授权时使用的回调地址，换取令牌时须保持一致
+	CodeVerifier string `json:"code_verifier"` // PKCE code_verifier
+	Nonce        string `json:"nonce"`         // OIDC nonce，防止 ID Token 重放
+}
+
+// OAuthIdentity 第三方账号信息
+type OAuthIdentity struct {
+	Subject       string // 第三方账号唯一标识
+	Email         string // 邮箱
+	EmailVerified bool   // 提供方是否已验证邮箱
+	Name          string // 名称
+	AvatarURL     string // 头像地址
+}
+
+// oauthEndpoints 提供方各端点地址
+type oauthEndpoints struct {
+	AuthURL     string // 授权地址
+	TokenURL    string // 令牌地址
+	APIURL      string // GitHub API 地址
+	Issuer      string // OIDC Issuer，校验 ID Token 的 iss
+	UserInfoURL string // OIDC UserInfo 地址
+	JWKSURL     string // OIDC JWKS 地址
+}
+
+// oauthTokenResponse 令牌端点响应
+type oauthTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// GetOAuthProviders 获取已启用的第三方登录提供方
+// 返回值：
+//   - []configs.OAuthProviderConfig: 提供方配置列表，未启用第三方登录时为空
+//   - error: 操作过程中的错误
+func GetOAuthProviders() ([]configs.OAuthProviderConfig, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("加载第三方登录配置失败: %w", err)
+	}
+	if !cfg.AppConfig.OAuth.OAuthEnabled {
+		return nil, nil
+	}
+	return cfg.AppConfig.OAuth.Providers, nil
+}
+
+// CreateOAuthAuthorization 生成第三方授权地址，state、PKCE code_verifier 与 nonce 保存在缓存中，供回调时校验
+// 参数：
+//   - c: Echo 上下文
+//   - providerName: 提供方名称
+//   - mode: 授权用途：login、link、reauth
+//   - accountID: 发起授权的账户ID，login 用途为 0
+//
+// 返回值：
+//   - string: 授权地址
+//   - error: 操作过程中的错误
+func CreateOAuthAuthorization(c echo.Context, providerName, mode string, accountID int64) (string, error) {
+	oauthCfg, provider, err := loadOAuthProvider(providerName)
+	if err != nil {
+		return "", err
+	}
+	if oauthCfg.RedirectURL == "" {
+		return "", fmt.Errorf("未配置第三方登录回调地址")
+	}
+
+	ctx := c.Request().Context()
+	endpoints, err := resolveOAuthEndpoints(ctx, NewSafeHTTPClient(oauthCfg.AllowPrivate), provider)
+	if err != nil {
+		return "", err
+	}
+
+	state, err := randomOAuthToken()
+	if err != nil {
+		return "", fmt.Errorf("生成授权 state 失败: %w", err)
+	}
+	codeVerifier, err := randomOAuthToken()
+	if err != nil {
+		return "", fmt.Errorf("生成 PKCE code_verifier 失败: %w", err)
+	}
+	nonce, err := randomOAuthToken()
+	if err != nil {
+		return "", fmt.Errorf("生成 nonce 失败: %w", err)
+	}
+
+	oauthState := &OAuthState{
+		Provider:     provider.Name,
+		Mode:         mode,
+		AccountID:    accountID,
+		RedirectURI:  strings.ReplaceAll(oauthCfg.RedirectURL, "{provider}", url.PathEscape(provider.Name)),
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+	}
+	payload, err := json.Marshal(oauthState)
+	if err != nil {
+		return "", fmt.Errorf("序列化授权 state 失败: %w", err)
+	}
+	if err := global.RedisClient.Set(ctx, OAUTH_STATE_CACHE_PREFIX+state, payload, OAUTH_STATE_EXPIRE_TIME).Err(); err != nil {
+		return "", fmt.Errorf("授权 state 写入缓存失败: %w", err)
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", provider.ClientID)
+	query.Set("redirect_uri", oauthState.RedirectURI)
+	query.Set("scope", strings.Join(oauthScopes(provider), " "))
+	query.Set("state", state)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	if provider.Type != OAUTH_TYPE_GITHUB {
+		query.Set("nonce", nonce)
+	}
+
+	authURL, err := url.Parse(endpoints.AuthURL)
+	if err != nil {
+		return "", fmt.Errorf("授权地址「%s」无效: %w", endpoints.AuthURL, err)
+	}
+	merged := authURL.Query()
+	for key, values := range query {
+		merged[key] = values
+	}
+	authURL.RawQuery = merged.Encode()
+
+	return authURL.String(), nil
+}
+
+// ConsumeOAuthState 取出并作废授权 state，每个 state 只能使用一次
+// 参数：
+//   - c: Echo 上下文
+//   - state: 授权 state
+//
+// 返回值：
+//   - *OAuthState: 发起授权时保存的上下文
+//   - error: state 不存在或已过期时返回 ErrOAuthStateInvalid
+func ConsumeOAuthState(c echo.Context, state string) (*OAuthState, error) {
+	payload, err := global.RedisClient.GetDel(c.Request().Context(), OAUTH_STATE_CACHE_PREFIX+state).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrOAuthStateInvalid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取授权 state 失败: %w", err)
+	}
+
+	var oauthState OAuthState
+	if err := json.Unmarshal([]byte(payload), &oauthState); err != nil {
+		return nil, fmt.Errorf("授权 state 内容无效: %w", err)
+	}
+	return &oauthState, nil
+}
+
+// ExchangeOAuthCode 用授权码换取第三方账号信息，OIDC 提供方校验 ID Token 的签名、签发方、受众、有效期与 nonce
+// 参数：
+//   - c: Echo 上下文
+//   - oauthState: 发起授权时保存的上下文
+//   - code: 授权码
+//
+// 返回值：
+//   - *OAuthIdentity: 第三方账号信息
+//   - error: 提供方拒绝授权或身份信息未通过校验时返回 ErrOAuthExchangeFailed
+func ExchangeOAuthCode(c echo.Context, oauthState *OAuthState, code string) (*OAuthIdentity, error) {
+	oauthCfg, provider, err := loadOAuthProvider(oauthState.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := c.Request().Context()
+	client := NewSafeHTTPClient(oauthCfg.AllowPrivate)
+	endpoints, err := resolveOAuthEndpoints(ctx, client, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := exchangeOAuthToken(ctx, client, endpoints.TokenURL, provider, oauthState, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if provider.Type == OAUTH_TYPE_GITHUB {
+		return fetchGitHubIdentity(ctx, client, endpoints.APIURL, token.AccessToken)
+	}
+	return verifyOIDCIdentity(ctx, client, endpoints, provider, oauthState, token)
+}
+
+// loadOAuthProvider 按名称获取已启用的提供方配置
+// 参数：
+//   - name: 提供方名称
+//
+// 返回值：
+//   - *configs.OAuthConfig: 第三方登录配置
+//   - *configs.OAuthProviderConfig: 提供方配置
+//   - error: 未启用第三方登录或提供方不存在时返回对应错误
+func loadOAuthProvider(name string) (*configs.OAuthConfig, *configs.OAuthProviderConfig, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("加载第三方登录配置失败: %w", err)
+	}
+
+	oauthCfg := cfg.AppConfig.OAuth
+	if !oauthCfg.OAuthEnabled {
+		return nil, nil, ErrOAuthDisabled
+	}
+
+	for _, provider := range oauthCfg.Providers {
+		if provider.Name != name {
+			continue
+		}
+		if _, ok := defaultOAuthScopes[provider.Type]; !ok {
+			return nil, nil, fmt.Errorf("第三方登录提供方「%s」的类型「%s」不受支持", provider.Name, provider.Type)
+		}
+		return &oauthCfg, &provider, nil
+	}
+
+	return nil, nil, ErrOAuthProviderNotFound
+}
+
+// resolveOAuthEndpoints 获取提供方各端点地址，OIDC 提供方通过发现文档获取
+// 参数：
+//   - ctx: 请求上下文
+//   - client: HTTP 客户端
+//   - provider: 提供方配置
+//
+// 返回值：
+//   - *oauthEndpoints: 端点地址
+//   - error: 操作过程中的错误
+func resolveOAuthEndpoints(ctx context.Context, client *http.Client, provider *configs.OAuthProviderConfig) (*oauthEndpoints, error) {
+	if provider.Type == OAUTH_TYPE_GITHUB {
+		endpoints := &oauthEndpoints{
+			AuthURL:  provider.AuthURL,
+			TokenURL: provider.TokenURL,
+			APIURL:   strings.TrimSuffix(provider.APIURL, "/"),
+		}
+		if endpoints.AuthURL == "" {
+			endpoints.AuthURL = GITHUB_AUTH_URL
+		}
+		if endpoints.TokenURL == "" {
+			endpoints.TokenURL = GITHUB_TOKEN_URL
+		}
+		if endpoints.APIURL == "" {
+			endpoints.APIURL = GITHUB_API_URL
+		}
+		return endpoints, nil
+	}
+
+	issuer := provider.Issuer
+	if issuer == "" && provider.Type == OAUTH_TYPE_GOOGLE {
+		issuer = GOOGLE_OIDC_ISSUER
+	}
+	if issuer == "" {
+		return nil, fmt.Errorf("第三方登录提供方「%s」未配置 ISSUER", provider.Name)
+	}
+
+	discovery, err := discoverOIDC(ctx, client, issuer)
+	if err != nil {
+		return nil, err
+	}
+	return &oauthEndpoints{
+		AuthURL:     discovery.AuthorizationEndpoint,
+		TokenURL:    discovery.TokenEndpoint,
+		Issuer:      discovery.Issuer,
+		UserInfoURL: discovery.UserInfoEndpoint,
+		JWKSURL:     discovery.JWKSURI,
+	}, nil
+}
+
+// exchangeOAuthToken 调用令牌端点，用授权码与 PKCE code_verifier 换取访问令牌
+// 参数：
+//   - ctx: 请求上下文
+//   - client: HTTP 客户端
+//   - tokenURL: 令牌端点地址
+//   - provider: 提供方配置
+//   - oauthState: 发起授权时保存的上下文
+//   - code: 授权码
+//
+// 返回值：
+//   - *oauthTokenResponse: 令牌端点响应
+//   - error: 操作过程中的错误
+func exchangeOAuthToken(ctx context.Context, client *http.Client, tokenURL string, provider *configs.OAuthProviderConfig, oauthState *OAuthState, code string) (*oauthTokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", oauthState.RedirectURI)
+	form.Set("client_id", provider.ClientID)
+	form.Set("client_secret", provider.ClientSecret)
+	form.Set("code_verifier", oauthState.CodeVerifier)
+
+	req, err := NewOutgoingRequest(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("创建令牌请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求令牌端点失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ReadLimitedBody(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取令牌端点响应失败: %w", err)
+	}
+
+	var token oauthTokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("%w: 令牌端点响应无法解析，状态码 %d", ErrOAuthExchangeFailed, resp.StatusCode)
+	}
+	// GitHub 授权码无效时同样返回 200，错误信息在响应体的 error 字段中
+	if resp.StatusCode != http.StatusOK || token.Error != "" {
+		return nil, fmt.Errorf("%w: 令牌端点返回 %d %s %s", ErrOAuthExchangeFailed, resp.StatusCode, token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("%w: 令牌端点未返回 access_token", ErrOAuthExchangeFailed)
+	}
+	return &token, nil
+}
+
+// fetchGitHubIdentity 通过 GitHub API 获取账号信息，邮箱取已验证的主邮箱
+// 参数：
+//   - ctx: 请求上下文
+//   - client: HTTP 客户端
+//   - apiURL: GitHub API 地址
+//   - accessToken: 访问令牌
+//
+// 返回值：
+//   - *OAuthIdentity: 第三方账号信息
+//   - error: 操作过程中的错误
+func fetchGitHubIdentity(ctx context.Context, client *http.Client, apiURL, accessToken string) (*OAuthIdentity, error) {
+	var user struct {
+		ID        json.Number `json:"id"`
+		Login     string      `json:"login"`
+		Name      string      `json:"name"`
+		AvatarURL string      `json:"avatar_url"`
+	}
+	if err := getOAuthJSON(ctx, client, apiURL+"/user", accessToken, &user); err != nil {
+		return nil, err
+	}
+	if user.ID == "" {
+		return nil, fmt.Errorf("%w: GitHub 未返回用户 ID", ErrOAuthExchangeFailed)
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getOAuthJSON(ctx, client, apiURL+"/user/emails", accessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	identity := &OAuthIdentity{
+		Subject:   user.ID.String(),
+		Name:      user.Name,
+		AvatarURL: user.AvatarURL,
+	}
+	if identity.Name == "" {
+		identity.Name = user.Login
+	}
+	for _, email := range emails {
+		if !email.Verified {
+			continue
+		}
+		if identity.Email == "" || email.Primary {
+			identity.Email = email.Email
+			identity.EmailVerified = true
+		}
+		if email.Primary {
+			break
+		}
+	}
+	return identity, nil
+}
+
+// getOAuthJSON 携带访问令牌请求提供方接口并解析 JSON 响应
+// 参数：
+//   - ctx: 请求上下文
+//   - client: HTTP 客户端
+//   - rawURL: 接口地址
+//   - accessToken: 访问令牌
+//   - out: 解析目标
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func getOAuthJSON(ctx context.Context, client *http.Client, rawURL, accessToken string, out interface{}) error {
+	req, err := NewOutgoingRequest(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("创建请求「%s」失败: %w", rawURL, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求「%s」失败: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ReadLimitedBody(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取「%s」响应失败: %w", rawURL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: 「%s」返回状态码 %d", ErrOAuthExchangeFailed, rawURL, resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("%w: 「%s」响应无法解析: %v", ErrOAuthExchangeFailed, rawURL, err)
+	}
+	return nil
+}
+
+// oauthScopes 获取提供方的授权范围，未配置时使用提供方类型的默认范围
+// 参数：
+//   - provider: 提供方配置
+//
+// 返回值：
+//   - []string: 授权范围
+func oauthScopes(provider *configs.OAuthProviderConfig) []string {
+	if len(provider.Scopes) > 0 {
+		return provider.Scopes
+	}
+	return defaultOAuthScopes[provider.Type]
+}
+
+// randomOAuthToken 生成 URL 安全的随机字符串，用于 state、PKCE code_verifier 与 nonce
+// 返回值：
+//   - string: 随机字符串
+//   - error: 操作过程中的错误
+func randomOAuthToken() (string, error) {
+	buf := make([]byte, oauthRandomBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
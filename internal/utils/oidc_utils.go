@@ -0,0 +1,365 @@
+// Package utils 提供 OIDC 发现文档与 ID Token 校验工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"jank.com/jank_blog/configs"
+)
+
+const (
+	OIDC_METADATA_CACHE_TTL    = time.Hour   // 发现文档与 JWKS 的缓存时间
+	OIDC_JWKS_REFRESH_INTERVAL = time.Minute // ID Token 使用未知 kid 时重新拉取 JWKS 的最小间隔，防止被用于放大请求
+)
+
+// oidcSigningMethods ID Token 允许的签名算法，不接受 none 与 HS 系列对称算法
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// oidcDiscovery OIDC 发现文档中用到的字段
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcJWK JWKS 中的单个公钥
+type oidcJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// oidcCachedDiscovery 缓存的发现文档
+type oidcCachedDiscovery struct {
+	discovery *oidcDiscovery
+	fetchedAt time.Time
+}
+
+// oidcCachedJWKS 缓存的 JWKS，键为 kid
+type oidcCachedJWKS struct {
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+var (
+	oidcDiscoveryCache sync.Map // 发现文档缓存，键为 Issuer
+	oidcJWKSCache      sync.Map // JWKS 缓存，键为 JWKS 地址
+)
+
+// discoverOIDC 获取 Issuer 的发现文档，发现文档中的 issuer 须与配置一致
+// 参数：
+//   - ctx: 请求上下文
+//   - client: HTTP 客户端
+//   - issuer: OIDC Issuer
+//
+// 返回值：
+//   - *oidcDiscovery: 发现文档
+//   - error: 操作过程中的错误
+func discoverOIDC(ctx context.Context, client *http.Client, issuer string) (*oidcDiscovery, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+	if cached, ok := oidcDiscoveryCache.Load(issuer); ok {
+		entry := cached.(*oidcCachedDiscovery)
+		if time.Since(entry.fetchedAt) < OIDC_METADATA_CACHE_TTL {
+			return entry.discovery, nil
+		}
+	}
+
+	var discovery oidcDiscovery
+	if err := getOIDCJSON(ctx, client, issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, fmt.Errorf("获取「%s」发现文档失败: %w", issuer, err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("发现文档中的 issuer「%s」与配置「%s」不一致", discovery.Issuer, issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("「%s」发现文档缺少授权、令牌或 JWKS 端点", issuer)
+	}
+
+	oidcDiscoveryCache.Store(issuer, &oidcCachedDiscovery{discovery: &discovery, fetchedAt: time.Now()})
+	return &discovery, nil
+}
+
+// verifyOIDCIdentity 校验 ID Token 并提取账号信息，ID Token 未携带邮箱时从 UserInfo 端点补充
+// 参数：
+//   - ctx: 请求上下文
+//   - client: HTTP 客户端
+//   - endpoints: 提供方端点地址
+//   - provider: 提供方配置
+//   - oauthState: 发起授权时保存的上下文
+//   - token: 令牌端点响应
+//
+// 返回值：
+//   - *OAuthIdentity: 第三方账号信息
+//   - error: ID Token 未通过校验时返回 ErrOAuthExchangeFailed
+func verifyOIDCIdentity(ctx context.Context, client *http.Client, endpoints *oauthEndpoints, provider *configs.OAuthProviderConfig, oauthState *OAuthState, token *oauthTokenResponse) (*OAuthIdentity, error) {
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: 令牌端点未返回 id_token", ErrOAuthExchangeFailed)
+	}
+
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(oidcSigningMethods))
+	if _, err := parser.ParseWithClaims(token.IDToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return getOIDCKey(ctx, client, endpoints.JWKSURL, kid)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: id_token 校验失败: %v", ErrOAuthExchangeFailed, err)
+	}
+
+	if !verifyOIDCIssuer(claims, endpoints.Issuer, provider.Type) {
+		return nil, fmt.Errorf("%w: id_token 签发方「%v」不匹配", ErrOAuthExchangeFailed, claims["iss"])
+	}
+	if !claims.VerifyAudience(provider.ClientID, true) {
+		return nil, fmt.Errorf("%w: id_token 受众不包含当前客户端", ErrOAuthExchangeFailed)
+	}
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf("%w: id_token 缺少有效期或已过期", ErrOAuthExchangeFailed)
+	}
+	nonce, _ := claims["nonce"].(string)
+	if subtle.ConstantTimeCompare([]byte(nonce), []byte(oauthState.Nonce)) != 1 {
+		return nil, fmt.Errorf("%w: id_token nonce 不匹配", ErrOAuthExchangeFailed)
+	}
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: id_token 缺少 sub", ErrOAuthExchangeFailed)
+	}
+
+	identity := &OAuthIdentity{Subject: subject}
+	fillOIDCIdentity(identity, claims)
+
+	if identity.Email == "" && endpoints.UserInfoURL != "" {
+		var userInfo map[string]interface{}
+		if err := getOAuthJSON(ctx, client, endpoints.UserInfoURL, token.AccessToken, &userInfo); err != nil {
+			return nil, err
+		}
+		// UserInfo 响应的 sub 须与 ID Token 一致，防止替换为其他账号的信息
+		if userInfoSubject, _ := userInfo["sub"].(string); userInfoSubject != subject {
+			return nil, fmt.Errorf("%w: UserInfo 的 sub 与 id_token 不一致", ErrOAuthExchangeFailed)
+		}
+		fillOIDCIdentity(identity, userInfo)
+	}
+
+	return identity, nil
+}
+
+// fillOIDCIdentity 从 ID Token 或 UserInfo 的声明中补充尚未获取的账号信息
+// 参数：
+//   - identity: 第三方账号信息
+//   - claims: 声明
+func fillOIDCIdentity(identity *OAuthIdentity, claims map[string]interface{}) {
+	if identity.Email == "" {
+		identity.Email, _ = claims["email"].(string)
+		// 部分提供方以字符串形式返回 email_verified
+		switch verified := claims["email_verified"].(type) {
+		case bool:
+			identity.EmailVerified = verified
+		case string:
+			identity.EmailVerified = verified == "true"
+		}
+	}
+	if identity.Name == "" {
+		identity.Name, _ = claims["name"].(string)
+	}
+	if identity.Name == "" {
+		identity.Name, _ = claims["preferred_username"].(string)
+	}
+	if identity.AvatarURL == "" {
+		identity.AvatarURL, _ = claims["picture"].(string)
+	}
+}
+
+// verifyOIDCIssuer 校验 ID Token 的签发方，Google 签发的 ID Token 可能不带 https:// 前缀
+// 参数：
+//   - claims: ID Token 声明
+//   - issuer: 发现文档中的 issuer
+//   - providerType: 提供方类型
+//
+// 返回值：
+//   - bool: 签发方是否匹配
+func verifyOIDCIssuer(claims jwt.MapClaims, issuer, providerType string) bool {
+	iss, _ := claims["iss"].(string)
+	if strings.TrimSuffix(iss, "/") == strings.TrimSuffix(issuer, "/") {
+		return true
+	}
+	return providerType == OAUTH_TYPE_GOOGLE && "https://"+iss == GOOGLE_OIDC_ISSUER
+}
+
+// getOIDCKey 按 kid 获取校验 ID Token 签名的公钥，缓存中不存在时重新拉取 JWKS，用于支持提供方轮换密钥
+// 参数：
+//   - ctx: 请求上下文
+//   - client: HTTP 客户端
+//   - jwksURL: JWKS 地址
+//   - kid: ID Token 头部的 kid，为空时仅在 JWKS 只有一个公钥时可用
+//
+// 返回值：
+//   - interface{}: 公钥
+//   - error: 操作过程中的错误
+func getOIDCKey(ctx context.Context, client *http.Client, jwksURL, kid string) (interface{}, error) {
+	cached, _ := oidcJWKSCache.Load(jwksURL)
+	entry, _ := cached.(*oidcCachedJWKS)
+	if entry != nil && time.Since(entry.fetchedAt) < OIDC_METADATA_CACHE_TTL {
+		if key, ok := lookupOIDCKey(entry.keys, kid); ok {
+			return key, nil
+		}
+	}
+	if entry != nil && time.Since(entry.fetchedAt) < OIDC_JWKS_REFRESH_INTERVAL {
+		return nil, fmt.Errorf("JWKS 中不存在 kid 为「%s」的公钥", kid)
+	}
+
+	var jwks struct {
+		Keys []oidcJWK `json:"keys"`
+	}
+	if err := getOIDCJSON(ctx, client, jwksURL, &jwks); err != nil {
+		return nil, fmt.Errorf("获取 JWKS 失败: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseOIDCJWK(jwk)
+		if err != nil {
+			// 跳过不支持的密钥类型，不影响同一 JWKS 中其他公钥的使用
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	oidcJWKSCache.Store(jwksURL, &oidcCachedJWKS{keys: keys, fetchedAt: time.Now()})
+
+	if key, ok := lookupOIDCKey(keys, kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("JWKS 中不存在 kid 为「%s」的公钥", kid)
+}
+
+// lookupOIDCKey 在 JWKS 中查找公钥
+// 参数：
+//   - keys: JWKS，键为 kid
+//   - kid: 公钥 kid
+//
+// 返回值：
+//   - interface{}: 公钥
+//   - bool: 是否找到
+func lookupOIDCKey(keys map[string]interface{}, kid string) (interface{}, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	key, ok := keys[kid]
+	return key, ok
+}
+
+// parseOIDCJWK 将 JWK 解析为公钥，支持 RSA、EC（P-256、P-384、P-521）与 Ed25519
+// 参数：
+//   - jwk: JWK
+//
+// 返回值：
+//   - interface{}: 公钥
+//   - error: 操作过程中的错误
+func parseOIDCJWK(jwk oidcJWK) (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("RSA 公钥 n 无效: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("RSA 公钥 e 无效")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("不支持的 EC 曲线「%s」", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("EC 公钥 x 无效: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, fmt.Errorf("EC 公钥 y 无效: %w", err)
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("EC 公钥不在曲线「%s」上", jwk.Crv)
+		}
+		return key, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("不支持的 OKP 曲线「%s」", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("Ed25519 公钥无效")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("不支持的密钥类型「%s」", jwk.Kty)
+	}
+}
+
+// getOIDCJSON 请求发现文档或 JWKS 等公开地址并解析 JSON 响应
+// 参数：
+//   - ctx: 请求上下文
+//   - client: HTTP 客户端
+//   - rawURL: 请求地址
+//   - out: 解析目标
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func getOIDCJSON(ctx context.Context, client *http.Client, rawURL string, out interface{}) error {
+	req, err := NewOutgoingRequest(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("返回状态码 %d", resp.StatusCode)
+	}
+	body, err := ReadLimitedBody(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
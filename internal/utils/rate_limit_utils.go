@@ -0,0 +1,65 @@
+// Package utils 提供基于 Redis 的限流工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/global"
+)
+
+const RATE_LIMIT_CACHE_PREFIX = "RATE:LIMIT:" // 限流计数缓存前缀
+
+// AllowRequest 按固定时间窗口计数，判断本次请求是否在限额内
+// 参数：
+//   - c: Echo 上下文
+//   - scope: 限流场景，如 comment
+//   - identity: 限流对象标识，如客户端 IP
+//   - limit: 时间窗口内允许的请求次数，小于等于 0 表示不限流
+//   - window: 时间窗口
+//
+// 返回值：
+//   - bool: 未超出限额返回 true
+//   - error: 操作过程中的错误
+func AllowRequest(c echo.Context, scope, identity string, limit int, window time.Duration) (bool, error) {
+	if limit <= 0 || window <= 0 {
+		return true, nil
+	}
+
+	key := fmt.Sprintf("%s%s:%s", RATE_LIMIT_CACHE_PREFIX, scope, identity)
+	ctx := c.Request().Context()
+
+	count, err := global.RedisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("限流计数失败: %w", err)
+	}
+
+	// 首次计数时设置窗口过期时间
+	if count == 1 {
+		if err := global.RedisClient.Expire(ctx, key, window).Err(); err != nil {
+			return false, fmt.Errorf("设置限流窗口失败: %w", err)
+		}
+	}
+
+	return count <= int64(limit), nil
+}
+
+// ResetRequestLimit 清除限流计数，用于校验成功后不再累计失败次数的场景
+// 参数：
+//   - c: Echo 上下文
+//   - scope: 限流场景
+//   - identity: 限流对象标识
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func ResetRequestLimit(c echo.Context, scope, identity string) error {
+	key := fmt.Sprintf("%s%s:%s", RATE_LIMIT_CACHE_PREFIX, scope, identity)
+	if err := global.RedisClient.Del(c.Request().Context(), key).Err(); err != nil {
+		return fmt.Errorf("清除限流计数失败: %w", err)
+	}
+	return nil
+}
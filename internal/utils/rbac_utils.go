@@ -0,0 +1,103 @@
+// Package utils 提供基于角色的权限控制工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import "github.com/labstack/echo/v4"
+
+// 账户角色
+const (
+	ROLE_ADMIN     = "admin"     // 管理员，拥有全部权限
+	ROLE_EDITOR    = "editor"    // 编辑，可管理全部文章、类目与评论
+	ROLE_AUTHOR    = "author"    // 作者，只能管理自己的文章
+	ROLE_COMMENTER = "commenter" // 评论者，只能以登录身份发表评论
+)
+
+// 权限
+const (
+	PERMISSION_POST_CREATE      = "post:create"      // 发布文章，并编辑、删除自己的文章
+	PERMISSION_POST_EDIT_ANY    = "post:edit_any"    // 编辑、删除任意文章
+	PERMISSION_CATEGORY_MANAGE  = "category:manage"  // 管理类目
+	PERMISSION_COMMENT_CREATE   = "comment:create"   // 以登录身份发表评论
+	PERMISSION_COMMENT_MODERATE = "comment:moderate" // 审核、删除任意评论，查看未公开的评论
+	PERMISSION_FILE_MANAGE      = "file:manage"      // 管理对象存储文件
+	PERMISSION_ACCOUNT_MANAGE   = "account:manage"   // 管理账户角色
+	PERMISSION_AUDIT_READ       = "audit:read"       // 查看审计日志
+)
+
+// AUTH_ACCOUNT_ROLE_CONTEXT_KEY 认证通过后存储在 Echo 上下文中的账户角色键名
+const AUTH_ACCOUNT_ROLE_CONTEXT_KEY = "auth_account_role"
+
+// rolePermissions 角色拥有的权限
+var rolePermissions = map[string][]string{
+	ROLE_ADMIN: {
+		PERMISSION_POST_CREATE, PERMISSION_POST_EDIT_ANY, PERMISSION_CATEGORY_MANAGE, PERMISSION_COMMENT_CREATE,
+		PERMISSION_COMMENT_MODERATE, PERMISSION_FILE_MANAGE, PERMISSION_ACCOUNT_MANAGE, PERMISSION_AUDIT_READ,
+	},
+	ROLE_EDITOR: {
+		PERMISSION_POST_CREATE, PERMISSION_POST_EDIT_ANY, PERMISSION_CATEGORY_MANAGE, PERMISSION_COMMENT_CREATE,
+		PERMISSION_COMMENT_MODERATE, PERMISSION_FILE_MANAGE,
+	},
+	ROLE_AUTHOR: {
+		PERMISSION_POST_CREATE, PERMISSION_COMMENT_CREATE, PERMISSION_FILE_MANAGE,
+	},
+	ROLE_COMMENTER: {
+		PERMISSION_COMMENT_CREATE,
+	},
+}
+
+// IsValidRole 判断角色是否存在
+// 参数：
+//   - role: 角色
+//
+// 返回值：
+//   - bool: 角色是否存在
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// RoleHasPermission 判断角色是否拥有权限
+// 参数：
+//   - role: 角色
+//   - permission: 权限
+//
+// 返回值：
+//   - bool: 是否拥有权限
+func RoleHasPermission(role, permission string) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAuthAccountRole 获取当前请求已认证账户的角色
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - string: 账户角色，未认证时为空
+func GetAuthAccountRole(c echo.Context) string {
+	role, _ := c.Get(AUTH_ACCOUNT_ROLE_CONTEXT_KEY).(string)
+	return role
+}
+
+// HasPermission 判断当前请求的已认证账户是否拥有权限，未认证请求没有任何权限；
+// 使用个人访问令牌认证时，权限还受令牌授权范围限制
+// 参数：
+//   - c: Echo 上下文
+//   - permission: 权限
+//
+// 返回值：
+//   - bool: 是否拥有权限
+func HasPermission(c echo.Context, permission string) bool {
+	if _, authenticated := GetAuthAccountID(c); !authenticated {
+		return false
+	}
+	if scopes, ok := GetAuthTokenScopes(c); ok && !ScopesGrantPermission(scopes, permission) {
+		return false
+	}
+	return RoleHasPermission(GetAuthAccountRole(c), permission)
+}
@@ -0,0 +1,408 @@
+// Package utils 提供基于 Redis 的多设备登录会话工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+
+	"jank.com/jank_blog/internal/global"
+)
+
+const (
+	SESSION_CACHE_PREFIX       = "USER:SESSION:"  // 会话缓存前缀，键为 USER:SESSION:<账户ID>:<会话ID>
+	SESSION_INDEX_CACHE_PREFIX = "USER:SESSIONS:" // 账户会话索引缓存前缀，键为 USER:SESSIONS:<账户ID>
+	SESSION_TOUCH_INTERVAL     = time.Minute      // 最近活跃时间的最小刷新间隔，避免每个请求都写缓存
+	sessionIDBytes             = 16               // 会话ID随机字节数
+)
+
+// REFRESH_TOKEN_USED_CACHE_PREFIX 已使用 Refresh Token 缓存前缀，键为 USER:REFRESH:USED:<jti>
+const REFRESH_TOKEN_USED_CACHE_PREFIX = "USER:REFRESH:USED:"
+
+// ErrRefreshTokenReused 已轮换作废的 Refresh Token 被再次使用，所属会话已被注销
+var ErrRefreshTokenReused = errors.New("refresh token 已被使用，会话已注销")
+
+// ErrSessionNotFound 会话不存在，已被注销或已过期
+var ErrSessionNotFound = errors.New("会话已失效，请重新登录")
+
+// updateSessionScript 仅当会话仍存在时写入字段并顺延过期时间，避免并发注销后的写入使会话复活；
+// KEYS[1] 为会话缓存键，ARGV[1] 为过期毫秒数，其余参数为交替的字段名与字段值
+var updateSessionScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("HSET", KEYS[1], unpack(ARGV, 2))
+redis.call("PEXPIRE", KEYS[1], ARGV[1])
+return 1
+`)
+
+// AUTH_SESSION_ID_CONTEXT_KEY 认证通过后存储在 Echo 上下文中的会话ID键名
+const AUTH_SESSION_ID_CONTEXT_KEY = "auth_session_id"
+
+// Session 登录会话，每次登录创建一个，令牌中携带会话ID
+type Session struct {
+	ID         string // 会话ID
+	AccountID  int64  // 账户ID
+	UserAgent  string // 登录设备的 User-Agent
+	IP         string // 最近一次访问的 IP
+	CreatedAt  int64  // 登录时间
+	LastSeenAt int64  // 最近活跃时间
+
+	RefreshTokenID string // 当前有效的 Refresh Token 唯一标识（jti）
+}
+
+// GetAuthSessionID 获取当前请求已认证的会话ID
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - string: 会话ID，未认证时为空
+func GetAuthSessionID(c echo.Context) string {
+	sessionID, _ := c.Get(AUTH_SESSION_ID_CONTEXT_KEY).(string)
+	return sessionID
+}
+
+// CreateSession 为账户创建新的登录会话
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - string: 会话ID
+//   - error: 操作过程中的错误
+func CreateSession(c echo.Context, accountID int64) (string, error) {
+	sessionID, err := randomSessionToken()
+	if err != nil {
+		return "", fmt.Errorf("生成会话ID失败: %w", err)
+	}
+
+	expireTime, err := sessionExpireTime()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().Unix()
+	ctx := c.Request().Context()
+	key := sessionKey(accountID, sessionID)
+	if err := global.RedisClient.HSet(ctx, key, map[string]interface{}{
+		"user_agent":   c.Request().UserAgent(),
+		"ip":           c.RealIP(),
+		"created_at":   now,
+		"last_seen_at": now,
+	}).Err(); err != nil {
+		return "", fmt.Errorf("保存会话失败: %w", err)
+	}
+	if err := global.RedisClient.Expire(ctx, key, expireTime).Err(); err != nil {
+		return "", fmt.Errorf("设置会话过期时间失败: %w", err)
+	}
+
+	indexKey := sessionIndexKey(accountID)
+	if err := global.RedisClient.SAdd(ctx, indexKey, sessionID).Err(); err != nil {
+		return "", fmt.Errorf("保存会话索引失败: %w", err)
+	}
+	if err := global.RedisClient.Expire(ctx, indexKey, expireTime).Err(); err != nil {
+		return "", fmt.Errorf("设置会话索引过期时间失败: %w", err)
+	}
+
+	return sessionID, nil
+}
+
+// GetSession 获取账户的登录会话
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//   - sessionID: 会话ID
+//
+// 返回值：
+//   - *Session: 会话，不存在或已过期时为 nil
+//   - error: 操作过程中的错误
+func GetSession(c echo.Context, accountID int64, sessionID string) (*Session, error) {
+	if sessionID == "" {
+		return nil, nil
+	}
+
+	fields, err := global.RedisClient.HGetAll(c.Request().Context(), sessionKey(accountID, sessionID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("读取会话失败: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	createdAt, _ := strconv.ParseInt(fields["created_at"], 10, 64)
+	lastSeenAt, _ := strconv.ParseInt(fields["last_seen_at"], 10, 64)
+	return &Session{
+		ID:         sessionID,
+		AccountID:  accountID,
+		UserAgent:  fields["user_agent"],
+		IP:         fields["ip"],
+		CreatedAt:  createdAt,
+		LastSeenAt: lastSeenAt,
+
+		RefreshTokenID: fields["refresh_jti"],
+	}, nil
+}
+
+// TouchSession 刷新会话的最近活跃时间与 IP，并顺延会话过期时间
+// 参数：
+//   - c: Echo 上下文
+//   - session: 会话
+//
+// 返回值：
+//   - error: 操作过程中的错误，会话已被注销时为 ErrSessionNotFound
+func TouchSession(c echo.Context, session *Session) error {
+	now := time.Now()
+	ip := c.RealIP()
+	if ip == session.IP && now.Sub(time.Unix(session.LastSeenAt, 0)) < SESSION_TOUCH_INTERVAL {
+		return nil
+	}
+
+	expireTime, err := sessionExpireTime()
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if err := updateSession(ctx, sessionKey(session.AccountID, session.ID), expireTime, "ip", ip, "last_seen_at", now.Unix()); err != nil {
+		return err
+	}
+	if err := global.RedisClient.Expire(ctx, sessionIndexKey(session.AccountID), expireTime).Err(); err != nil {
+		return fmt.Errorf("设置会话索引过期时间失败: %w", err)
+	}
+
+	session.IP = ip
+	session.LastSeenAt = now.Unix()
+	return nil
+}
+
+// ListSessions 获取账户全部有效的登录会话，按最近活跃时间倒序排列，并清理索引中已过期的会话
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - []*Session: 会话列表
+//   - error: 操作过程中的错误
+func ListSessions(c echo.Context, accountID int64) ([]*Session, error) {
+	ctx := c.Request().Context()
+	indexKey := sessionIndexKey(accountID)
+	sessionIDs, err := global.RedisClient.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("读取会话索引失败: %w", err)
+	}
+
+	sessions := make([]*Session, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		session, err := GetSession(c, accountID, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if session == nil {
+			global.RedisClient.SRem(ctx, indexKey, sessionID)
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt > sessions[j].LastSeenAt
+	})
+	return sessions, nil
+}
+
+// RevokeSession 注销账户的单个登录会话，该会话签发的令牌随即失效
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//   - sessionID: 会话ID
+//
+// 返回值：
+//   - bool: 会话是否存在
+//   - error: 操作过程中的错误
+func RevokeSession(c echo.Context, accountID int64, sessionID string) (bool, error) {
+	ctx := c.Request().Context()
+	deleted, err := global.RedisClient.Del(ctx, sessionKey(accountID, sessionID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("删除会话失败: %w", err)
+	}
+	if err := global.RedisClient.SRem(ctx, sessionIndexKey(accountID), sessionID).Err(); err != nil {
+		return false, fmt.Errorf("删除会话索引失败: %w", err)
+	}
+	return deleted > 0, nil
+}
+
+// RevokeAllSessions 注销账户的全部登录会话
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RevokeAllSessions(c echo.Context, accountID int64) error {
+	ctx := c.Request().Context()
+	indexKey := sessionIndexKey(accountID)
+	sessionIDs, err := global.RedisClient.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return fmt.Errorf("读取会话索引失败: %w", err)
+	}
+
+	keys := make([]string, 0, len(sessionIDs)+1)
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, sessionKey(accountID, sessionID))
+	}
+	keys = append(keys, indexKey)
+	if err := global.RedisClient.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("删除会话失败: %w", err)
+	}
+	return nil
+}
+
+// IssueSessionTokens 为登录会话签发访问令牌与刷新令牌，并将新的 Refresh Token 标识记为会话当前有效的标识
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//   - sessionID: 会话ID
+//
+// 返回值：
+//   - string: Access Token
+//   - string: Refresh Token
+//   - error: 操作过程中的错误，会话已被注销时为 ErrSessionNotFound
+func IssueSessionTokens(c echo.Context, accountID int64, sessionID string) (string, string, error) {
+	refreshTokenID, err := randomSessionToken()
+	if err != nil {
+		return "", "", fmt.Errorf("生成 refresh token 标识失败: %w", err)
+	}
+
+	expireTime, err := sessionExpireTime()
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := updateSession(c.Request().Context(), sessionKey(accountID, sessionID), expireTime, "refresh_jti", refreshTokenID); err != nil {
+		return "", "", err
+	}
+
+	return GenerateJWT(accountID, sessionID, refreshTokenID)
+}
+
+// consumeRefreshToken 将 Refresh Token 标记为已使用；令牌已被使用过或不是会话当前有效的令牌时，注销整个会话
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//   - sessionID: 会话ID
+//   - refreshTokenID: Refresh Token 唯一标识（jti）
+//
+// 返回值：
+//   - error: 会话已失效、检测到重用或操作失败时返回错误
+func consumeRefreshToken(c echo.Context, accountID int64, sessionID, refreshTokenID string) error {
+	session, err := GetSession(c, accountID, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return ErrSessionNotFound
+	}
+
+	expireTime, err := sessionExpireTime()
+	if err != nil {
+		return err
+	}
+
+	// SETNX 保证并发请求中同一令牌只能被使用一次
+	ctx := c.Request().Context()
+	firstUse, err := global.RedisClient.SetNX(ctx, REFRESH_TOKEN_USED_CACHE_PREFIX+refreshTokenID, sessionID, expireTime).Result()
+	if err != nil {
+		return fmt.Errorf("记录 refresh token 使用状态失败: %w", err)
+	}
+
+	if !firstUse || session.RefreshTokenID != refreshTokenID {
+		if _, err := RevokeSession(c, accountID, sessionID); err != nil {
+			return fmt.Errorf("注销会话失败: %w", err)
+		}
+		return ErrRefreshTokenReused
+	}
+
+	if err := TouchSession(c, session); err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			return err
+		}
+		global.SysLog.Warnf("刷新会话「%s」活跃时间失败: %v", sessionID, err)
+	}
+	return nil
+}
+
+// updateSession 仅当会话仍存在时写入会话字段并顺延过期时间
+// 参数：
+//   - ctx: 上下文
+//   - key: 会话缓存键
+//   - expireTime: 过期时间
+//   - fields: 交替的字段名与字段值
+//
+// 返回值：
+//   - error: 操作过程中的错误，会话不存在时为 ErrSessionNotFound
+func updateSession(ctx context.Context, key string, expireTime time.Duration, fields ...interface{}) error {
+	args := append([]interface{}{expireTime.Milliseconds()}, fields...)
+	updated, err := updateSessionScript.Run(ctx, global.RedisClient, []string{key}, args...).Int()
+	if err != nil {
+		return fmt.Errorf("更新会话失败: %w", err)
+	}
+	if updated == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// sessionExpireTime 获取会话闲置过期时间，与配置的 Refresh Token 有效期一致，每次活跃后顺延
+// 返回值：
+//   - time.Duration: 过期时间
+//   - error: 加载配置过程中的错误
+func sessionExpireTime() (time.Duration, error) {
+	keyring, err := loadJWTKeyring()
+	if err != nil {
+		return 0, err
+	}
+	return keyring.refreshExpire, nil
+}
+
+// randomSessionToken 生成随机的十六进制标识，用于会话ID与 Refresh Token 标识
+// 返回值：
+//   - string: 随机标识
+//   - error: 操作过程中的错误
+func randomSessionToken() (string, error) {
+	buf := make([]byte, sessionIDBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// sessionKey 生成会话缓存键
+// 参数：
+//   - accountID: 账户ID
+//   - sessionID: 会话ID
+//
+// 返回值：
+//   - string: 缓存键
+func sessionKey(accountID int64, sessionID string) string {
+	return fmt.Sprintf("%s%d:%s", SESSION_CACHE_PREFIX, accountID, sessionID)
+}
+
+// sessionIndexKey 生成账户会话索引缓存键
+// 参数：
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - string: 缓存键
+func sessionIndexKey(accountID int64) string {
+	return fmt.Sprintf("%s%d", SESSION_INDEX_CACHE_PREFIX, accountID)
+}
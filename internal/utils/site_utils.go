@@ -0,0 +1,103 @@
+// Package utils 提供站点地址相关工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"jank.com/jank_blog/configs"
+)
+
+const (
+	POST_URL_ID_PLACEHOLDER = "{id}" // 文章访问地址模板中的 ID 占位符
+)
+
+// BuildPostURL 根据文章 ID 生成文章对外访问地址
+// 参数：
+//   - postID: 文章 ID
+//
+// 返回值：
+//   - string: 文章访问地址
+//   - error: 生成过程中的错误
+func BuildPostURL(postID int64) (string, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return "", fmt.Errorf("加载站点配置失败: %w", err)
+	}
+
+	template := cfg.AppConfig.Site.PostURLTemplate
+	if !strings.Contains(template, POST_URL_ID_PLACEHOLDER) {
+		return "", fmt.Errorf("文章访问地址模板缺少 %s 占位符", POST_URL_ID_PLACEHOLDER)
+	}
+
+	return strings.Replace(template, POST_URL_ID_PLACEHOLDER, strconv.FormatInt(postID, 10), 1), nil
+}
+
+// ParsePostIDFromURL 按文章访问地址模板从 URL 中解析文章 ID
+// 参数：
+//   - rawURL: 文章访问地址
+//
+// 返回值：
+//   - int64: 文章 ID
+//   - error: 解析过程中的错误
+func ParsePostIDFromURL(rawURL string) (int64, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return 0, fmt.Errorf("加载站点配置失败: %w", err)
+	}
+
+	template := cfg.AppConfig.Site.PostURLTemplate
+	idx := strings.Index(template, POST_URL_ID_PLACEHOLDER)
+	if idx < 0 {
+		return 0, fmt.Errorf("文章访问地址模板缺少 %s 占位符", POST_URL_ID_PLACEHOLDER)
+	}
+
+	// 忽略 query 与 fragment，只比较协议、主机与路径
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, fmt.Errorf("URL 格式错误: %w", err)
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	normalized := strings.TrimSuffix(u.String(), "/")
+
+	prefix, suffix := template[:idx], strings.TrimSuffix(template[idx+len(POST_URL_ID_PLACEHOLDER):], "/")
+	if !strings.HasPrefix(normalized, prefix) || !strings.HasSuffix(normalized, suffix) || len(normalized) < len(prefix)+len(suffix) {
+		return 0, fmt.Errorf("「%s」不是本站文章地址", rawURL)
+	}
+
+	postID, err := strconv.ParseInt(normalized[len(prefix):len(normalized)-len(suffix)], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("「%s」不是本站文章地址", rawURL)
+	}
+	return postID, nil
+}
+
+// IsSiteURL 判断 URL 是否属于本站（与前端或 API 地址同主机）
+// 参数：
+//   - rawURL: 待判断的 URL
+//
+// 返回值：
+//   - bool: 属于本站返回 true
+func IsSiteURL(rawURL string) bool {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return false
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	for _, base := range []string{cfg.AppConfig.Site.SiteURL, cfg.AppConfig.Site.ApiURL} {
+		if b, err := url.Parse(base); err == nil && b.Host != "" && strings.EqualFold(b.Host, u.Host) {
+			return true
+		}
+	}
+	return false
+}
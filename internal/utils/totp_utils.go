@@ -0,0 +1,135 @@
+// Package utils 提供基于 RFC 6238 的 TOTP 双因素认证工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	TOTP_PERIOD              = 30  // TOTP 时间步长，单位秒
+	TOTP_SKEW                = 1   // 校验时前后各容忍的时间步数
+	TOTP_QR_CODE_SIZE        = 256 // 二维码图片边长，单位像素
+	TOTP_RECOVERY_CODE_COUNT = 10  // 每次生成的恢复码数量
+	totpRecoveryCodeBytes    = 10  // 恢复码随机字节数，编码后为 16 个字符
+)
+
+// GenerateTOTPKey 生成新的 TOTP 密钥
+// 参数：
+//   - issuer: 签发方，显示在验证器应用中
+//   - accountName: 账户名，通常为邮箱
+//
+// 返回值：
+//   - *otp.Key: TOTP 密钥，包含密钥与 otpauth URI
+//   - error: 操作过程中的错误
+func GenerateTOTPKey(issuer, accountName string) (*otp.Key, error) {
+	return totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: accountName,
+		Period:      TOTP_PERIOD,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+}
+
+// TOTPQRCodeBase64 将 TOTP 密钥的 otpauth URI 编码为 PNG 二维码
+// 参数：
+//   - key: TOTP 密钥
+//
+// 返回值：
+//   - string: data URI 格式的 Base64 PNG 图片
+//   - error: 操作过程中的错误
+func TOTPQRCodeBase64(key *otp.Key) (string, error) {
+	img, err := key.Image(TOTP_QR_CODE_SIZE, TOTP_QR_CODE_SIZE)
+	if err != nil {
+		return "", fmt.Errorf("生成二维码失败: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", fmt.Errorf("编码二维码图片失败: %w", err)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// ValidateTOTPCode 校验 TOTP 验证码，同一时间步的验证码只能使用一次
+// 参数：
+//   - secret: Base32 编码的 TOTP 密钥
+//   - code: 用户输入的验证码
+//   - lastStep: 上次校验通过的时间步，不大于该值的时间步视为重放
+//   - now: 当前时间
+//
+// 返回值：
+//   - int64: 本次校验通过的时间步
+//   - bool: 是否校验通过
+func ValidateTOTPCode(secret, code string, lastStep int64, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if secret == "" || len(code) != int(otp.DigitsSix) {
+		return 0, false
+	}
+
+	opts := totp.ValidateOpts{Period: TOTP_PERIOD, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1}
+	current := now.Unix() / TOTP_PERIOD
+	for step := current - TOTP_SKEW; step <= current+TOTP_SKEW; step++ {
+		if step <= lastStep {
+			continue
+		}
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*TOTP_PERIOD, 0), opts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// GenerateRecoveryCodes 生成一组一次性恢复码，格式为 xxxx-xxxx-xxxx-xxxx
+// 返回值：
+//   - []string: 恢复码明文，仅在生成时展示一次
+//   - error: 操作过程中的错误
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, TOTP_RECOVERY_CODE_COUNT)
+	buf := make([]byte, totpRecoveryCodeBytes)
+	for i := 0; i < TOTP_RECOVERY_CODE_COUNT; i++ {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("生成恢复码失败: %w", err)
+		}
+		raw := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf))
+		codes = append(codes, strings.Join([]string{raw[0:4], raw[4:8], raw[8:12], raw[12:16]}, "-"))
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode 计算恢复码的 SHA-256 摘要，忽略大小写、空白与分隔符
+// 参数：
+//   - code: 恢复码
+//
+// 返回值：
+//   - string: 十六进制摘要
+func HashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
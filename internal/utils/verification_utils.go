@@ -4,10 +4,13 @@
 package utils
 
 import (
-	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
 
 	"jank.com/jank_blog/internal/global"
 )
@@ -15,6 +18,8 @@ import (
 const (
 	EMAIL_VERIFICATION_CODE_CACHE_KEY_PREFIX = "EMAIL:VERIFICATION:CODE:"     // 邮箱验证码缓存前缀
 	IMG_VERIFICATION_CODE_CACHE_PREFIX       = "IMG:VERIFICATION:CODE:CACHE:" // 图形验证码缓存前缀
+	PASSWORD_RESET_CODE_CACHE_KEY_PREFIX     = "PASSWORD:RESET:CODE:"         // 找回密码验证码缓存前缀
+	EMAIL_CHANGE_CODE_CACHE_KEY_PREFIX       = "EMAIL:CHANGE:CODE:"           // 修改邮箱验证码缓存前缀
 )
 
 // VerifyEmailCode 校验邮箱验证码
@@ -41,7 +46,43 @@ func VerifyImgCode(c echo.Context, code, email string) bool {
 	return VerifyCode(c, code, email, IMG_VERIFICATION_CODE_CACHE_PREFIX)
 }
 
-// VerifyCode 通用验证码校验
+// VerifyPasswordResetCode 校验找回密码验证码，校验后验证码即失效
+// 参数：
+//   - c: Echo 上下文
+//   - code: 验证码
+//   - email: 邮箱地址
+//
+// 返回值：
+//   - bool: 验证成功返回 true，失败返回 false
+func VerifyPasswordResetCode(c echo.Context, code, email string) bool {
+	return VerifyCode(c, code, email, PASSWORD_RESET_CODE_CACHE_KEY_PREFIX)
+}
+
+// EmailChangeCodeIdentity 生成修改邮箱验证码的缓存标识，验证码同时绑定账户与接收邮箱
+// 参数：
+//   - accountID: 账户ID
+//   - email: 接收验证码的邮箱地址
+//
+// 返回值：
+//   - string: 缓存标识
+func EmailChangeCodeIdentity(accountID int64, email string) string {
+	return fmt.Sprintf("%d:%s", accountID, strings.ToLower(email))
+}
+
+// VerifyEmailChangeCode 校验修改邮箱验证码，校验后验证码即失效
+// 参数：
+//   - c: Echo 上下文
+//   - code: 验证码
+//   - identity: 由 EmailChangeCodeIdentity 生成的缓存标识
+//
+// 返回值：
+//   - bool: 验证成功返回 true，失败返回 false
+func VerifyEmailChangeCode(c echo.Context, code, identity string) bool {
+	return VerifyCode(c, code, identity, EMAIL_CHANGE_CODE_CACHE_KEY_PREFIX)
+}
+
+// VerifyCode 通用验证码校验，读取与删除在一条 GETDEL 命令中完成，验证码无论校验是否通过都会失效，
+// 并发请求中只有一个能取到验证码，保证验证码只能使用一次
 // 参数：
 //   - c: Echo 上下文
 //   - code: 验证码
@@ -53,9 +94,9 @@ func VerifyImgCode(c echo.Context, code, email string) bool {
 func VerifyCode(c echo.Context, code, email, prefix string) bool {
 	key := prefix + email
 
-	storedCode, err := global.RedisClient.Get(c.Request().Context(), key).Result()
+	storedCode, err := global.RedisClient.GetDel(c.Request().Context(), key).Result()
 	if err != nil {
-		if err.Error() == "redis: nil" {
+		if errors.Is(err, redis.Nil) {
 			BizLogger(c).Error("验证码不存在或已过期")
 		} else {
 			BizLogger(c).Errorf("验证码校验失败: %v", err)
@@ -66,14 +107,10 @@ func VerifyCode(c echo.Context, code, email, prefix string) bool {
 	storedCode = strings.ToUpper(strings.TrimSpace(storedCode))
 	code = strings.ToUpper(strings.TrimSpace(code))
 
-	if storedCode != code {
+	if subtle.ConstantTimeCompare([]byte(storedCode), []byte(code)) != 1 {
 		BizLogger(c).Error("用户验证码错误")
 		return false
 	}
 
-	if err := global.RedisClient.Del(context.Background(), key).Err(); err != nil {
-		BizLogger(c).Errorf("删除验证码缓存失败: %v", err)
-	}
-
 	return true
 }
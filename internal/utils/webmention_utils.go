@@ -0,0 +1,428 @@
+// Package utils 提供 Webmention 协议相关工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"jank.com/jank_blog/configs"
+)
+
+const (
+	WEBMENTION_ENDPOINT_PATH = "/api/v1/webmention/receiveWebmention" // Webmention 接收端点路径
+)
+
+// Webmention 互动类型常量，依据来源页面中指向目标地址的链接的 microformats2 class 判断
+const (
+	WEBMENTION_TYPE_MENTION  = "mention"  // 普通提及
+	WEBMENTION_TYPE_REPLY    = "reply"    // 回复（u-in-reply-to）
+	WEBMENTION_TYPE_LIKE     = "like"     // 点赞（u-like-of）
+	WEBMENTION_TYPE_REPOST   = "repost"   // 转发（u-repost-of）
+	WEBMENTION_TYPE_BOOKMARK = "bookmark" // 收藏（u-bookmark-of）
+)
+
+// mentionClassTypes microformats2 class 与互动类型的映射
+var mentionClassTypes = map[string]string{
+	"u-in-reply-to":  WEBMENTION_TYPE_REPLY,
+	"u-like-of":      WEBMENTION_TYPE_LIKE,
+	"u-repost-of":    WEBMENTION_TYPE_REPOST,
+	"u-bookmark-of":  WEBMENTION_TYPE_BOOKMARK,
+	"u-mention-of":   WEBMENTION_TYPE_MENTION,
+	"u-quotation-of": WEBMENTION_TYPE_MENTION,
+}
+
+// WebmentionSource 来源页面校验结果
+type WebmentionSource struct {
+	Gone   bool   // 来源页面已删除（410），对应的 Webmention 应移除
+	Linked bool   // 来源页面是否包含指向目标地址的链接
+	Type   string // 互动类型
+	Title  string // 来源页面标题
+	Author string // 来源页面作者
+}
+
+// WebmentionEndpointURL 获取本站对外声明的 Webmention 接收端点地址，未启用时返回错误
+// 返回值：
+//   - string: 接收端点地址
+//   - error: 获取过程中的错误
+func WebmentionEndpointURL() (string, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return "", fmt.Errorf("加载站点配置失败: %w", err)
+	}
+	if !cfg.AppConfig.Webmention.WebmentionEnabled {
+		return "", fmt.Errorf("Webmention 未启用")
+	}
+	if cfg.AppConfig.Site.ApiURL == "" {
+		return "", fmt.Errorf("未配置后端 API 对外访问地址")
+	}
+	return strings.TrimSuffix(cfg.AppConfig.Site.ApiURL, "/") + WEBMENTION_ENDPOINT_PATH, nil
+}
+
+// DiscoverWebmentionEndpoint 发现目标地址声明的 Webmention 接收端点
+// 参数：
+//   - ctx: 请求上下文
+//   - client: HTTP 客户端
+//   - target: 目标地址
+//
+// 返回值：
+//   - string: 接收端点地址，未声明时返回空字符串
+//   - error: 发现过程中的错误
+func DiscoverWebmentionEndpoint(ctx context.Context, client *http.Client, target string) (string, error) {
+	req, err := NewOutgoingRequest(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return "", fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Accept", "text/html, */*;q=0.8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("请求「%s」失败: %w", target, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("请求「%s」返回状态码 %d", target, resp.StatusCode)
+	}
+
+	// 重定向后以最终地址作为相对地址的解析基准
+	base := resp.Request.URL
+
+	// HTTP Link 头优先
+	for _, header := range resp.Header.Values("Link") {
+		if endpoint, ok := parseWebmentionLinkHeader(header); ok {
+			return resolveURL(base, endpoint)
+		}
+	}
+
+	if !strings.Contains(resp.Header.Get("Content-Type"), "html") {
+		return "", nil
+	}
+
+	body, err := ReadLimitedBody(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("读取「%s」内容失败: %w", target, err)
+	}
+
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("解析「%s」HTML 失败: %w", target, err)
+	}
+
+	// 按文档顺序查找第一个 rel 包含 webmention 的 <link> 或 <a>
+	var endpoint string
+	var found bool
+	walkHTML(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode || (n.Data != "link" && n.Data != "a") {
+			return true
+		}
+		if !hasToken(htmlAttr(n, "rel"), "webmention") {
+			return true
+		}
+		href, ok := htmlAttrOK(n, "href")
+		if !ok {
+			return true
+		}
+		endpoint, found = href, true
+		return false
+	})
+
+	if !found {
+		return "", nil
+	}
+	return resolveURL(base, endpoint)
+}
+
+// SendWebmention 向接收端点发送 Webmention 通知
+// 参数：
+//   - ctx: 请求上下文
+//   - client: HTTP 客户端
+//   - endpoint: 接收端点地址
+//   - source: 来源地址
+//   - target: 目标地址
+//
+// 返回值：
+//   - error: 发送过程中的错误
+func SendWebmention(ctx context.Context, client *http.Client, endpoint, source, target string) error {
+	form := url.Values{}
+	form.Set("source", source)
+	form.Set("target", target)
+
+	req, err := NewOutgoingRequest(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("发送 Webmention 至「%s」失败: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("发送 Webmention 至「%s」返回状态码 %d", endpoint, resp.StatusCode)
+	}
+	return nil
+}
+
+// VerifyWebmentionSource 拉取来源页面并校验其是否包含指向目标地址的链接
+// 参数：
+//   - ctx: 请求上下文
+//   - client: HTTP 客户端
+//   - source: 来源地址
+//   - target: 目标地址
+//
+// 返回值：
+//   - *WebmentionSource: 校验结果
+//   - error: 校验过程中的错误
+func VerifyWebmentionSource(ctx context.Context, client *http.Client, source, target string) (*WebmentionSource, error) {
+	req, err := NewOutgoingRequest(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Accept", "text/html, */*;q=0.8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求来源页面「%s」失败: %w", source, err)
+	}
+	defer resp.Body.Close()
+
+	result := &WebmentionSource{Type: WEBMENTION_TYPE_MENTION}
+	if resp.StatusCode == http.StatusGone {
+		result.Gone = true
+		return result, nil
+	}
+	// 来源页面明确不存在（4xx）时视为未包含链接，服务端错误与限流等暂时性失败返回错误以便重试
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return result, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("请求来源页面「%s」返回状态码 %d", source, resp.StatusCode)
+	}
+
+	body, err := ReadLimitedBody(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取来源页面「%s」失败: %w", source, err)
+	}
+
+	// 非 HTML 内容只做纯文本匹配
+	if !strings.Contains(resp.Header.Get("Content-Type"), "html") {
+		result.Linked = bytes.Contains(body, []byte(target))
+		return result, nil
+	}
+
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("解析来源页面「%s」失败: %w", source, err)
+	}
+
+	base := resp.Request.URL
+	normalizedTarget := normalizeMentionURL(target)
+
+	walkHTML(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		switch n.Data {
+		case "title":
+			if result.Title == "" && n.FirstChild != nil {
+				result.Title = strings.TrimSpace(n.FirstChild.Data)
+			}
+		case "meta":
+			if result.Author == "" && strings.EqualFold(htmlAttr(n, "name"), "author") {
+				result.Author = strings.TrimSpace(htmlAttr(n, "content"))
+			}
+		}
+
+		// 作者信息优先取 h-card 中的 p-author / p-name
+		class := htmlAttr(n, "class")
+		if result.Author == "" && hasToken(class, "p-author") {
+			result.Author = strings.TrimSpace(htmlText(n))
+		}
+
+		attr := "href"
+		switch n.Data {
+		case "a", "link":
+		case "img", "video", "audio", "source":
+			attr = "src"
+		default:
+			return true
+		}
+
+		link, ok := htmlAttrOK(n, attr)
+		if !ok {
+			return true
+		}
+		resolved, err := resolveURL(base, link)
+		if err != nil || normalizeMentionURL(resolved) != normalizedTarget {
+			return true
+		}
+
+		result.Linked = true
+		for _, token := range strings.Fields(class) {
+			if t, ok := mentionClassTypes[token]; ok {
+				result.Type = t
+				break
+			}
+		}
+		return true
+	})
+
+	return result, nil
+}
+
+// parseWebmentionLinkHeader 从 HTTP Link 头中解析 rel="webmention" 的地址
+// 参数：
+//   - header: Link 头的值
+//
+// 返回值：
+//   - string: 端点地址
+//   - bool: 是否找到
+func parseWebmentionLinkHeader(header string) (string, bool) {
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+
+		target := strings.TrimSpace(parts[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+
+		for _, param := range parts[1:] {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+				continue
+			}
+			if hasToken(strings.Trim(strings.TrimSpace(value), `"`), "webmention") {
+				return target[1 : len(target)-1], true
+			}
+		}
+	}
+	return "", false
+}
+
+// resolveURL 以 base 为基准解析相对地址
+// 参数：
+//   - base: 基准地址
+//   - ref: 相对或绝对地址
+//
+// 返回值：
+//   - string: 绝对地址
+//   - error: 解析过程中的错误
+func resolveURL(base *url.URL, ref string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return "", fmt.Errorf("地址「%s」格式错误: %w", ref, err)
+	}
+	return base.ResolveReference(u).String(), nil
+}
+
+// normalizeMentionURL 去除地址中的 fragment 与末尾斜杠，用于链接比对
+// 参数：
+//   - rawURL: 原始地址
+//
+// 返回值：
+//   - string: 规范化后的地址
+func normalizeMentionURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	u.Fragment = ""
+	u.Host = strings.ToLower(u.Host)
+	return strings.TrimSuffix(u.String(), "/")
+}
+
+// walkHTML 深度优先遍历 HTML 节点，visit 返回 false 时终止遍历
+// 参数：
+//   - n: 起始节点
+//   - visit: 访问函数
+//
+// 返回值：
+//   - bool: 是否继续遍历
+func walkHTML(n *html.Node, visit func(*html.Node) bool) bool {
+	if !visit(n) {
+		return false
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if !walkHTML(child, visit) {
+			return false
+		}
+	}
+	return true
+}
+
+// htmlAttr 获取节点属性值
+// 参数：
+//   - n: HTML 节点
+//   - key: 属性名
+//
+// 返回值：
+//   - string: 属性值，不存在时返回空字符串
+func htmlAttr(n *html.Node, key string) string {
+	val, _ := htmlAttrOK(n, key)
+	return val
+}
+
+// htmlAttrOK 获取节点属性值及其是否存在
+// 参数：
+//   - n: HTML 节点
+//   - key: 属性名
+//
+// 返回值：
+//   - string: 属性值
+//   - bool: 属性是否存在
+func htmlAttrOK(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if strings.EqualFold(attr.Key, key) {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// htmlText 获取节点下的全部文本内容
+// 参数：
+//   - n: HTML 节点
+//
+// 返回值：
+//   - string: 文本内容
+func htmlText(n *html.Node) string {
+	var sb strings.Builder
+	walkHTML(n, func(node *html.Node) bool {
+		if node.Type == html.TextNode {
+			sb.WriteString(node.Data)
+		}
+		return true
+	})
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// hasToken 判断以空白分隔的属性值中是否包含指定标记（忽略大小写）
+// 参数：
+//   - value: 属性值
+//   - token: 标记
+//
+// 返回值：
+//   - bool: 包含返回 true
+func hasToken(value, token string) bool {
+	for _, field := range strings.Fields(value) {
+		if strings.EqualFold(field, token) {
+			return true
+		}
+	}
+	return false
+}
@@ -4,10 +4,17 @@
 package main
 
 import (
+	"os"
+
 	"jank.com/jank_blog/cmd"
 )
 
-// main 程序主入口函数
+// main 程序主入口函数，携带子命令时执行命令行工具，否则启动服务
 func main() {
+	if len(os.Args) > 1 {
+		cmd.Run(os.Args[1:])
+		return
+	}
+
 	cmd.Start()
 }
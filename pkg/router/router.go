@@ -40,4 +40,12 @@ func New(app *echo.Echo) {
 	routes.RegisterCommentRoutes(api1)
 	// 注册对象存储路由
 	routes.RegisterOssRoutes(api1)
+	// 注册 Webmention 相关的路由
+	routes.RegisterWebmentionRoutes(api1)
+	// 注册邮件通知相关的路由
+	routes.RegisterNotificationRoutes(api1)
+	// 注册审计日志相关的路由
+	routes.RegisterAuditRoutes(api1)
+	// 注册 /.well-known 标准发现路由
+	routes.RegisterWellKnownRoutes(app.Group("/.well-known"))
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	auth_middleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/account"
 )
 
@@ -19,8 +20,35 @@ func RegisterAccountRoutes(r ...*echo.Group) {
 	accountGroupV1 := apiV1.Group("/account")
 	accountGroupV1.POST("/registerAccount", account.RegisterAcc)
 	accountGroupV1.POST("/loginAccount", account.LoginAccount)
+	accountGroupV1.POST("/verifyTotpLogin", account.VerifyTotpLogin)
+	accountGroupV1.POST("/refreshToken", account.RefreshToken)
+	accountGroupV1.GET("/listOAuthProviders", account.ListOAuthProviders)
+	accountGroupV1.GET("/getOAuthAuthorizationURL", account.GetOAuthAuthorizationURL)
+	accountGroupV1.POST("/oauthLogin", account.OAuthLogin)
 	accountGroupV1.GET("/getAccount", account.GetAccount, auth_middleware.AuthMiddleware())
 	accountGroupV1.POST("/updateAccount", account.UpdateAccount, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/changeEmail", account.ChangeEmail, auth_middleware.AuthMiddleware())
+	accountGroupV1.GET("/exportAccountData", account.ExportAccountData, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/deleteAccount", account.DeleteAccount, auth_middleware.AuthMiddleware())
 	accountGroupV1.POST("/logoutAccount", account.LogoutAccount, auth_middleware.AuthMiddleware())
+	accountGroupV1.GET("/listSessions", account.ListSessions, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/revokeSession", account.RevokeSession, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/revokeAllSessions", account.RevokeAllSessions, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/createAccessToken", account.CreateAccessToken, auth_middleware.AuthMiddleware())
+	accountGroupV1.GET("/listAccessTokens", account.ListAccessTokens, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/revokeAccessToken", account.RevokeAccessToken, auth_middleware.AuthMiddleware())
 	accountGroupV1.POST("/resetPassword", account.ResetPassword, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/forgotPassword", account.ForgotPassword)
+	accountGroupV1.POST("/enrollTotp", account.EnrollTotp, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/enableTotp", account.EnableTotp, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/disableTotp", account.DisableTotp, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/regenerateRecoveryCodes", account.RegenerateRecoveryCodes, auth_middleware.AuthMiddleware())
+	accountGroupV1.GET("/getOAuthLinkURL", account.GetOAuthLinkURL, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/linkOAuthIdentity", account.LinkOAuthIdentity, auth_middleware.AuthMiddleware())
+	accountGroupV1.GET("/getOAuthReauthURL", account.GetOAuthReauthURL, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/verifyOAuthReauth", account.VerifyOAuthReauth, auth_middleware.AuthMiddleware())
+	accountGroupV1.GET("/listOAuthIdentities", account.ListOAuthIdentities, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/unlinkOAuthIdentity", account.UnlinkOAuthIdentity, auth_middleware.AuthMiddleware())
+	accountGroupV1.POST("/updateAccountRole", account.UpdateAccountRole, auth_middleware.AuthMiddleware(), auth_middleware.PermissionMiddleware(utils.PERMISSION_ACCOUNT_MANAGE))
+	accountGroupV1.POST("/unlockAccount", account.UnlockAccount, auth_middleware.AuthMiddleware(), auth_middleware.PermissionMiddleware(utils.PERMISSION_ACCOUNT_MANAGE))
 }
@@ -0,0 +1,22 @@
+// Package routes 提供路由注册功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	auth_middleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/audit"
+)
+
+// RegisterAuditRoutes 注册审计日志相关路由
+// 参数：
+//   - r: Echo 路由组数组，r[0] 为 API v1 版本组
+func RegisterAuditRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	auditGroupV1 := apiV1.Group("/audit")
+	auditGroupV1.GET("/listAuditLogs", audit.ListAuditLogs, auth_middleware.AuthMiddleware(), auth_middleware.PermissionMiddleware(utils.PERMISSION_AUDIT_READ))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	auth_middleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/category"
 )
 
@@ -17,10 +18,15 @@ func RegisterCategoryRoutes(r ...*echo.Group) {
 	// api v1 group
 	apiV1 := r[0]
 	categoryGroupV1 := apiV1.Group("/category")
-	categoryGroupV1.GET("/getOneCategory", category.GetOneCategory)
-	categoryGroupV1.GET("/getCategoryTree", category.GetCategoryTree)
-	categoryGroupV1.GET("/getCategoryChildrenTree", category.GetCategoryChildrenTree)
-	categoryGroupV1.POST("/createOneCategory", category.CreateOneCategory, auth_middleware.AuthMiddleware())
-	categoryGroupV1.POST("/updateOneCategory", category.UpdateOneCategory, auth_middleware.AuthMiddleware())
-	categoryGroupV1.POST("/deleteOneCategory", category.DeleteOneCategory, auth_middleware.AuthMiddleware())
+	categoryGroupV1.GET("/getOneCategory", category.GetOneCategory, auth_middleware.OptionalAuthMiddleware(utils.SCOPE_POSTS_WRITE))
+	categoryGroupV1.GET("/getCategoryTree", category.GetCategoryTree, auth_middleware.OptionalAuthMiddleware(utils.SCOPE_POSTS_WRITE))
+	categoryGroupV1.GET("/getCategoryChildrenTree", category.GetCategoryChildrenTree, auth_middleware.OptionalAuthMiddleware(utils.SCOPE_POSTS_WRITE))
+	categoryGroupV1.GET("/getCategoryBreadcrumb", category.GetCategoryBreadcrumb)
+	categoryGroupV1.POST("/createOneCategory", category.CreateOneCategory, auth_middleware.AuthMiddleware(utils.SCOPE_CATEGORIES_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_CATEGORY_MANAGE))
+	categoryGroupV1.POST("/updateOneCategory", category.UpdateOneCategory, auth_middleware.AuthMiddleware(utils.SCOPE_CATEGORIES_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_CATEGORY_MANAGE))
+	categoryGroupV1.POST("/moveCategory", category.MoveCategory, auth_middleware.AuthMiddleware(utils.SCOPE_CATEGORIES_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_CATEGORY_MANAGE))
+	categoryGroupV1.POST("/mergeCategories", category.MergeCategories, auth_middleware.AuthMiddleware(utils.SCOPE_CATEGORIES_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_CATEGORY_MANAGE))
+	categoryGroupV1.POST("/deleteOneCategory", category.DeleteOneCategory, auth_middleware.AuthMiddleware(utils.SCOPE_CATEGORIES_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_CATEGORY_MANAGE))
+	categoryGroupV1.GET("/checkCategoryTree", category.CheckCategoryTree, auth_middleware.AuthMiddleware(utils.SCOPE_CATEGORIES_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_CATEGORY_MANAGE))
+	categoryGroupV1.POST("/repairCategoryTree", category.RepairCategoryTree, auth_middleware.AuthMiddleware(utils.SCOPE_CATEGORIES_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_CATEGORY_MANAGE))
 }
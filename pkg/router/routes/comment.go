@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	auth_middleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/comment"
 )
 
@@ -17,8 +18,14 @@ func RegisterCommentRoutes(r ...*echo.Group) {
 	// api v1 group
 	apiV1 := r[0]
 	commentGroupV1 := apiV1.Group("/comment")
-	commentGroupV1.GET("/getOneComment", comment.GetOneComment)
-	commentGroupV1.GET("/getCommentGraph", comment.GetCommentGraph)
-	commentGroupV1.POST("/createOneComment", comment.CreateOneComment, auth_middleware.AuthMiddleware())
-	commentGroupV1.POST("/deleteOneComment", comment.DeleteOneComment, auth_middleware.AuthMiddleware())
+	commentGroupV1.GET("/getOneComment", comment.GetOneComment, auth_middleware.OptionalAuthMiddleware(utils.SCOPE_COMMENTS_MODERATE))
+	commentGroupV1.GET("/getCommentGraph", comment.GetCommentGraph, auth_middleware.OptionalAuthMiddleware(utils.SCOPE_COMMENTS_MODERATE))
+	commentGroupV1.GET("/getCommentReplies", comment.GetCommentReplies, auth_middleware.OptionalAuthMiddleware(utils.SCOPE_COMMENTS_MODERATE))
+	commentGroupV1.GET("/getModerationQueue", comment.GetModerationQueue, auth_middleware.AuthMiddleware(utils.SCOPE_COMMENTS_MODERATE), auth_middleware.PermissionMiddleware(utils.PERMISSION_COMMENT_MODERATE))
+	commentGroupV1.POST("/moderateComments", comment.ModerateComments, auth_middleware.AuthMiddleware(utils.SCOPE_COMMENTS_MODERATE), auth_middleware.PermissionMiddleware(utils.PERMISSION_COMMENT_MODERATE))
+	commentGroupV1.POST("/reactComment", comment.ReactComment, auth_middleware.OptionalAuthMiddleware())
+	commentGroupV1.POST("/createOneComment", comment.CreateOneComment, auth_middleware.OptionalAuthMiddleware())
+	commentGroupV1.POST("/updateOneComment", comment.UpdateOneComment, auth_middleware.OptionalAuthMiddleware())
+	commentGroupV1.POST("/deleteOneComment", comment.DeleteOneComment, auth_middleware.OptionalAuthMiddleware(utils.SCOPE_COMMENTS_MODERATE))
+	commentGroupV1.GET("/getCommentRevisions", comment.GetCommentRevisions, auth_middleware.AuthMiddleware(utils.SCOPE_COMMENTS_MODERATE), auth_middleware.PermissionMiddleware(utils.PERMISSION_COMMENT_MODERATE))
 }
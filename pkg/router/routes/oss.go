@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	auth_middleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/oss"
 )
 
@@ -17,8 +18,8 @@ func RegisterOssRoutes(r ...*echo.Group) {
 	// api v1 group
 	apiV1 := r[0]
 	ossGroupV1 := apiV1.Group("/oss")
-	ossGroupV1.POST("/uploadOneFile", oss.UploadOneFile, auth_middleware.AuthMiddleware())
-	ossGroupV1.GET("/downloadOneFile", oss.DownloadOneFile, auth_middleware.AuthMiddleware())
-	ossGroupV1.POST("/deleteOneFile", oss.DeleteOneFile, auth_middleware.AuthMiddleware())
-	ossGroupV1.GET("/listAllObjects", oss.ListAllObjects, auth_middleware.AuthMiddleware())
+	ossGroupV1.POST("/uploadOneFile", oss.UploadOneFile, auth_middleware.AuthMiddleware(utils.SCOPE_OSS_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_FILE_MANAGE))
+	ossGroupV1.GET("/downloadOneFile", oss.DownloadOneFile, auth_middleware.AuthMiddleware(utils.SCOPE_OSS_READ, utils.SCOPE_OSS_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_FILE_MANAGE))
+	ossGroupV1.POST("/deleteOneFile", oss.DeleteOneFile, auth_middleware.AuthMiddleware(utils.SCOPE_OSS_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_FILE_MANAGE))
+	ossGroupV1.GET("/listAllObjects", oss.ListAllObjects, auth_middleware.AuthMiddleware(utils.SCOPE_OSS_READ, utils.SCOPE_OSS_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_FILE_MANAGE))
 }
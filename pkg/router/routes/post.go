@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	auth_middleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/post"
 )
 
@@ -19,7 +20,7 @@ func RegisterPostRoutes(r ...*echo.Group) {
 	postGroupV1 := apiV1.Group("/post")
 	postGroupV1.GET("/getOnePost", post.GetOnePost)
 	postGroupV1.GET("/getAllPosts", post.GetAllPosts)
-	postGroupV1.POST("/createOnePost", post.CreateOnePost, auth_middleware.AuthMiddleware())
-	postGroupV1.POST("/updateOnePost", post.UpdateOnePost, auth_middleware.AuthMiddleware())
-	postGroupV1.POST("/deleteOnePost", post.DeleteOnePost, auth_middleware.AuthMiddleware())
+	postGroupV1.POST("/createOnePost", post.CreateOnePost, auth_middleware.AuthMiddleware(utils.SCOPE_POSTS_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_POST_CREATE))
+	postGroupV1.POST("/updateOnePost", post.UpdateOnePost, auth_middleware.AuthMiddleware(utils.SCOPE_POSTS_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_POST_CREATE))
+	postGroupV1.POST("/deleteOnePost", post.DeleteOnePost, auth_middleware.AuthMiddleware(utils.SCOPE_POSTS_WRITE), auth_middleware.PermissionMiddleware(utils.PERMISSION_POST_CREATE))
 }
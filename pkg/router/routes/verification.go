@@ -6,6 +6,7 @@ package routes
 import (
 	"github.com/labstack/echo/v4"
 
+	auth_middleware "jank.com/jank_blog/internal/middleware/auth"
 	"jank.com/jank_blog/pkg/serve/controller/verification"
 )
 
@@ -18,4 +19,6 @@ func RegisterVerificationRoutes(r ...*echo.Group) {
 	accountGroupV1 := apiV1.Group("/verification")
 	accountGroupV1.GET("/sendImgVerificationCode", verification.SendImgVerificationCode)
 	accountGroupV1.GET("/sendEmailVerificationCode", verification.SendEmailVerificationCode)
+	accountGroupV1.GET("/sendPasswordResetCode", verification.SendPasswordResetCode)
+	accountGroupV1.GET("/sendEmailChangeCode", verification.SendEmailChangeCode, auth_middleware.AuthMiddleware())
 }
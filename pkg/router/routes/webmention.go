@@ -0,0 +1,21 @@
+// Package routes 提供路由注册功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/pkg/serve/controller/webmention"
+)
+
+// RegisterWebmentionRoutes 注册 Webmention 相关路由
+// 参数：
+//   - r: Echo 路由组数组，r[0] 为 API v1 版本组
+func RegisterWebmentionRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	webmentionGroupV1 := apiV1.Group("/webmention")
+	webmentionGroupV1.POST("/receiveWebmention", webmention.ReceiveWebmention)
+	webmentionGroupV1.GET("/getWebmentionList", webmention.GetWebmentionList)
+}
@@ -0,0 +1,17 @@
+// Package routes 提供路由注册功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/pkg/serve/controller/account"
+)
+
+// RegisterWellKnownRoutes 注册 /.well-known 下的标准发现路由
+// 参数：
+//   - r: Echo 路由组，为 /.well-known 路由组
+func RegisterWellKnownRoutes(r *echo.Group) {
+	r.GET("/jwks.json", account.GetJWKS)
+}
@@ -4,7 +4,9 @@
 package account
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -62,15 +64,16 @@ func RegisterAcc(c echo.Context) error {
 
 // LoginAccount godoc
 // @Summary      用户登录
-// @Description  用户登录并获取访问令牌，支持图形验证码校验
+// @Description  用户登录并获取访问令牌，支持图形验证码校验；启用双因素认证的账户返回登录挑战令牌，需调用 /account/verifyTotpLogin 换取访问令牌
 // @Tags         账户
 // @Accept       json
 // @Produce      json
 // @Param        request  body      dto.LoginRequest  true  "登录信息"
 // @Param        ImgVerificationCode  query   string  true  "图形验证码"
-// @Success      200     {object}   vo.Result{data=account.LoginVO}  "登录成功，返回访问令牌"
-// @Failure      400     {object}   vo.Result         "参数错误，验证码校验失败"
-// @Failure      401     {object}   vo.Result         "登录失败，凭证无效"
+// @Success      200     {object}   vo.Result{data=account.LoginVO}  "登录成功，返回访问令牌或登录挑战令牌"
+// @Failure      400     {object}   vo.Result         "参数错误，验证码校验失败，邮箱或密码错误"
+// @Failure      429     {object}   vo.Result         "登录失败次数过多，账户或 IP 已被临时锁定"
+// @Failure      500     {object}   vo.Result         "服务器错误"
 // @Router       /account/loginAccount [post]
 // 参数：
 //   - c: Echo 上下文
@@ -94,7 +97,42 @@ func LoginAccount(c echo.Context) error {
 
 	response, err := service.LoginAcc(c, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// RefreshToken godoc
+// @Summary      刷新访问令牌
+// @Description  使用 Refresh Token 换取新的访问令牌与刷新令牌，每个 Refresh Token 仅能使用一次；已使用过的 Refresh Token 再次提交时注销其所属会话
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RefreshTokenRequest  true  "刷新令牌"
+// @Success      200     {object}   vo.Result{data=account.LoginVO}  "刷新成功"
+// @Failure      400     {object}   vo.Result         "参数错误"
+// @Failure      401     {object}   vo.Result         "刷新令牌无效、已过期或已被使用"
+// @Router       /account/refreshToken [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RefreshToken(c echo.Context) error {
+	req := new(dto.RefreshTokenRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	response, err := service.RefreshToken(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
 	}
 
 	return c.JSON(http.StatusOK, vo.Success(c, response))
@@ -102,14 +140,17 @@ func LoginAccount(c echo.Context) error {
 
 // GetAccount godoc
 // @Summary      获取账户信息
-// @Description  根据提供的邮箱获取对应用户的详细信息
+// @Description  获取当前登录用户的详细信息，具备账户管理权限时可按邮箱查询其他用户
 // @Tags         账户
 // @Accept       json
 // @Produce      json
-// @Param        request  query      dto.GetAccountRequest  true  "获取账户请求参数"
+// @Param        request  query      dto.GetAccountRequest  false  "获取账户请求参数"
 // @Success      200     {object}   vo.Result{data=account.GetAccountVO}  "获取成功"
 // @Failure      400     {object}   vo.Result              "请求参数错误"
-// @Failure      404     {object}   vo.Result              "用户不存在"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      403     {object}   vo.Result              "无权查询其他用户"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
 // @Router       /account/getAccount [get]
 // 参数：
 //   - c: Echo 上下文
@@ -129,7 +170,7 @@ func GetAccount(c echo.Context) error {
 
 	response, err := service.GetAccount(c, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return vo.FailWithBizErr(c, err)
 	}
 
 	return c.JSON(http.StatusOK, vo.Success(c, response))
@@ -172,9 +213,114 @@ func UpdateAccount(c echo.Context) error {
 	return c.JSON(http.StatusOK, vo.Success(c, response))
 }
 
+// ChangeEmail godoc
+// @Summary      修改邮箱
+// @Description  凭 /verification/sendEmailChangeCode 分别发送至当前邮箱与新邮箱的验证码修改账户邮箱，修改后以新邮箱登录；任一验证码错误时两个验证码均作废
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ChangeEmailRequest  true  "修改邮箱信息"
+// @Success      200     {object}   vo.Result{data=account.GetAccountVO}  "修改成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误，验证码错误或新邮箱已被注册"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/changeEmail [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func ChangeEmail(c echo.Context) error {
+	req := new(dto.ChangeEmailRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	response, err := service.ChangeEmail(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ExportAccountData godoc
+// @Summary      导出账户数据
+// @Description  将当前账户的个人资料、撰写的文章、发表的评论与上传的文件打包为 zip 下载，每小时最多导出 3 次
+// @Tags         账户
+// @Produce      application/zip
+// @Success      200     {file}     file       "账户数据压缩包"
+// @Failure      401     {object}   vo.Result  "未授权"
+// @Failure      429     {object}   vo.Result  "导出过于频繁"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/exportAccountData [get]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func ExportAccountData(c echo.Context) error {
+	response, err := service.ExportAccountData(c)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	fileName := fmt.Sprintf("jank_blog_account_%s_%s.zip", response.Profile.ID, time.Now().Format("20060102"))
+	c.Response().Header().Set(echo.HeaderContentType, "application/zip")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Response().WriteHeader(http.StatusOK)
+
+	// 响应头已发出，打包失败时只能中断下载，错误已在业务层记录
+	_ = service.WriteAccountExportArchive(c, response, c.Response())
+	return nil
+}
+
+// DeleteAccount godoc
+// @Summary      注销账户
+// @Description  凭当前密码（未设置密码的账户凭 /account/verifyOAuthReauth 获得的身份验证令牌）注销账户，已启用双因素认证时还需提供 TOTP 验证码；账户发表的评论保留内容并匿名化，撰写的文章与上传的文件保留，全部会话与个人访问令牌随即失效；系统中的最后一名管理员不能注销
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DeleteAccountRequest  true  "注销账户信息"
+// @Success      200     {object}   vo.Result{data=string}  "注销成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误，密码或验证码错误"
+// @Failure      401     {object}   vo.Result              "未授权"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/deleteAccount [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteAccount(c echo.Context) error {
+	req := new(dto.DeleteAccountRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	if err := service.DeleteAccount(c, req); err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, "账户已注销"))
+}
+
 // LogoutAccount godoc
 // @Summary      用户登出
-// @Description  退出当前用户登录状态
+// @Description  退出当前设备的登录状态，其他设备的会话不受影响
 // @Tags         账户
 // @Produce      json
 // @Success      200  {object}  vo.Result{data=string}  "登出成功"
@@ -197,7 +343,7 @@ func LogoutAccount(c echo.Context) error {
 
 // ResetPassword godoc
 // @Summary      重置密码
-// @Description  重置用户账户密码，支持邮箱验证码校验
+// @Description  已登录用户凭发送至本账户邮箱的验证码重置密码
 // @Tags         账户
 // @Accept       json
 // @Produce      json
@@ -230,8 +376,798 @@ func ResetPassword(c echo.Context) error {
 
 	err := service.ResetPassword(c, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return vo.FailWithBizErr(c, err)
 	}
 
 	return c.JSON(http.StatusOK, vo.Success(c, "密码重置成功"))
 }
+
+// ForgotPassword godoc
+// @Summary      找回密码
+// @Description  未登录时凭发送至邮箱的找回密码验证码设置新密码，验证码仅可使用一次，成功后注销该账户的全部会话并撤销全部个人访问令牌
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ForgotPasswordRequest  true  "找回密码信息"
+// @Success      200     {object}   vo.Result{data=string}  "密码重置成功"
+// @Failure      400     {object}   vo.Result         "参数错误，验证码错误或已过期"
+// @Failure      429     {object}   vo.Result         "验证码校验次数过多，或登录失败次数过多已被临时锁定"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Router       /account/forgotPassword [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func ForgotPassword(c echo.Context) error {
+	req := new(dto.ForgotPasswordRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	if err := service.ForgotPassword(c, req); err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, "密码重置成功，请使用新密码重新登录"))
+}
+
+// UpdateAccountRole godoc
+// @Summary      修改账户角色
+// @Description  管理员为账户分配 admin、editor、author 或 commenter 角色
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdateAccountRoleRequest  true  "修改账户角色信息"
+// @Success      200     {object}   vo.Result{data=account.GetAccountVO}  "修改成功"
+// @Failure      400     {object}   vo.Result         "参数错误，账户不存在或为最后一名管理员"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      403     {object}   vo.Result         "无权修改账户角色"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/updateAccountRole [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateAccountRole(c echo.Context) error {
+	req := new(dto.UpdateAccountRoleRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	acc, err := service.UpdateAccountRole(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, acc))
+}
+
+// UnlockAccount godoc
+// @Summary      解除登录锁定
+// @Description  管理员解除账户因登录失败次数过多产生的临时锁定并清除失败计数，可同时解除指定客户端 IP 的锁定
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UnlockAccountRequest  true  "解除登录锁定信息"
+// @Success      200     {object}   vo.Result         "解除成功"
+// @Failure      400     {object}   vo.Result         "参数错误"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      403     {object}   vo.Result         "无权解除登录锁定"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/unlockAccount [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UnlockAccount(c echo.Context) error {
+	req := new(dto.UnlockAccountRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	if err := service.UnlockAccount(c, req); err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, "解除登录锁定成功"))
+}
+
+// VerifyTotpLogin godoc
+// @Summary      双因素认证登录
+// @Description  登录第二步，凭登录挑战令牌与 TOTP 验证码或恢复码换取访问令牌，每个恢复码只能使用一次
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.VerifyTotpLoginRequest  true  "双因素认证登录信息"
+// @Success      200     {object}   vo.Result{data=account.LoginVO}  "登录成功，返回访问令牌"
+// @Failure      400     {object}   vo.Result         "参数错误，验证码错误或登录已过期"
+// @Failure      429     {object}   vo.Result         "验证码校验次数过多，或登录失败次数过多已被临时锁定"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Router       /account/verifyTotpLogin [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func VerifyTotpLogin(c echo.Context) error {
+	req := new(dto.VerifyTotpLoginRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	response, err := service.VerifyTotpLogin(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// EnrollTotp godoc
+// @Summary      绑定双因素认证
+// @Description  生成待确认的 TOTP 密钥，返回 otpauth URI 与二维码，调用 /account/enableTotp 确认后生效
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=account.TotpEnrollmentVO}  "返回密钥与二维码"
+// @Failure      400     {object}   vo.Result         "已启用双因素认证"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/enrollTotp [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func EnrollTotp(c echo.Context) error {
+	response, err := service.EnrollTotp(c)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// EnableTotp godoc
+// @Summary      启用双因素认证
+// @Description  凭验证器应用生成的 TOTP 验证码确认启用双因素认证，返回仅展示一次的恢复码
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.EnableTotpRequest  true  "启用双因素认证信息"
+// @Success      200     {object}   vo.Result{data=account.TotpRecoveryCodesVO}  "启用成功，返回恢复码"
+// @Failure      400     {object}   vo.Result         "参数错误，验证码错误"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      429     {object}   vo.Result         "验证码校验次数过多，或登录失败次数过多已被临时锁定"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/enableTotp [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func EnableTotp(c echo.Context) error {
+	req := new(dto.EnableTotpRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	response, err := service.EnableTotp(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// DisableTotp godoc
+// @Summary      关闭双因素认证
+// @Description  凭当前密码、身份验证令牌（通过 /account/verifyOAuthReauth 获得）或 TOTP 验证码关闭双因素认证，密钥与恢复码随即作废
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DisableTotpRequest  true  "关闭双因素认证信息"
+// @Success      200     {object}   vo.Result{data=string}  "关闭成功"
+// @Failure      400     {object}   vo.Result         "参数错误，密码或验证码错误"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      429     {object}   vo.Result         "验证码校验次数过多，或登录失败次数过多已被临时锁定"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/disableTotp [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DisableTotp(c echo.Context) error {
+	req := new(dto.DisableTotpRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	if err := service.DisableTotp(c, req); err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, "双因素认证已关闭"))
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary      重新生成恢复码
+// @Description  凭 TOTP 验证码作废旧恢复码并生成新的恢复码，新恢复码仅展示一次
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RegenerateRecoveryCodesRequest  true  "重新生成恢复码信息"
+// @Success      200     {object}   vo.Result{data=account.TotpRecoveryCodesVO}  "生成成功，返回恢复码"
+// @Failure      400     {object}   vo.Result         "参数错误，验证码错误"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      429     {object}   vo.Result         "验证码校验次数过多，或登录失败次数过多已被临时锁定"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/regenerateRecoveryCodes [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RegenerateRecoveryCodes(c echo.Context) error {
+	req := new(dto.RegenerateRecoveryCodesRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	response, err := service.RegenerateRecoveryCodes(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ListSessions godoc
+// @Summary      获取登录会话列表
+// @Description  获取当前账户在各设备上有效的登录会话，包括设备 User-Agent、IP 与最近活跃时间
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=[]account.SessionVO}  "获取成功"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/listSessions [get]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func ListSessions(c echo.Context) error {
+	response, err := service.ListSessions(c)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// RevokeSession godoc
+// @Summary      注销登录会话
+// @Description  注销当前账户的指定登录会话，该会话签发的令牌随即失效
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RevokeSessionRequest  true  "注销登录会话信息"
+// @Success      200     {object}   vo.Result{data=string}  "注销成功"
+// @Failure      400     {object}   vo.Result         "参数错误，会话不存在"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/revokeSession [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RevokeSession(c echo.Context) error {
+	req := new(dto.RevokeSessionRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	if err := service.RevokeSession(c, req); err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, "会话已注销"))
+}
+
+// RevokeAllSessions godoc
+// @Summary      注销全部登录会话
+// @Description  注销当前账户在全部设备上的登录会话，包括当前会话
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=string}  "注销成功"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/revokeAllSessions [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RevokeAllSessions(c echo.Context) error {
+	if err := service.RevokeAllSessions(c); err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, "全部会话已注销"))
+}
+
+// GetJWKS godoc
+// @Summary      获取 JWKS 公钥集合
+// @Description  以 RFC 7517 格式返回用于校验令牌签名的公钥集合，供其他服务校验本站签发的令牌；HS256 对称密钥不会公开
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   account.JWKSVO  "获取成功"
+// @Failure      500     {object}   vo.Result       "服务器错误"
+// @Router       /.well-known/jwks.json [get]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func GetJWKS(c echo.Context) error {
+	response, err := service.GetJWKS(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	// JWKS 需按标准格式直接返回，不包装统一响应结构
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(http.StatusOK, response)
+}
+
+// CreateAccessToken godoc
+// @Summary      创建个人访问令牌
+// @Description  创建供自动化脚本使用的个人访问令牌，可指定授权范围与有效天数；令牌明文仅在创建时返回一次，调用接口时以 Bearer 方式放入 Authorization 请求头
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateAccessTokenRequest  true  "个人访问令牌信息"
+// @Success      200     {object}   vo.Result{data=account.CreateAccessTokenVO}  "创建成功"
+// @Failure      400     {object}   vo.Result         "参数错误，授权范围无效或令牌数量已达上限"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      403     {object}   vo.Result         "当前账户角色无法授予指定授权范围"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/createAccessToken [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateAccessToken(c echo.Context) error {
+	req := new(dto.CreateAccessTokenRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	response, err := service.CreateAccessToken(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ListAccessTokens godoc
+// @Summary      获取个人访问令牌列表
+// @Description  获取当前账户未撤销的个人访问令牌，不包含令牌明文
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=[]account.AccessTokenVO}  "获取成功"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/listAccessTokens [get]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func ListAccessTokens(c echo.Context) error {
+	response, err := service.ListAccessTokens(c)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// RevokeAccessToken godoc
+// @Summary      撤销个人访问令牌
+// @Description  撤销当前账户的个人访问令牌，撤销后立即失效
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RevokeAccessTokenRequest  true  "个人访问令牌ID"
+// @Success      200     {object}   vo.Result{data=string}  "撤销成功"
+// @Failure      400     {object}   vo.Result         "参数错误，令牌不存在"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/revokeAccessToken [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RevokeAccessToken(c echo.Context) error {
+	req := new(dto.RevokeAccessTokenRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	if err := service.RevokeAccessToken(c, req); err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, "个人访问令牌已撤销"))
+}
+
+// ListOAuthProviders godoc
+// @Summary      获取第三方登录提供方列表
+// @Description  获取已启用的第三方登录提供方，供前端展示登录按钮，未启用第三方登录时返回空列表
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=[]account.OAuthProviderVO}  "获取成功"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Router       /account/listOAuthProviders [get]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func ListOAuthProviders(c echo.Context) error {
+	response, err := service.ListOAuthProviders(c)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// GetOAuthAuthorizationURL godoc
+// @Summary      获取第三方登录授权地址
+// @Description  获取第三方登录的授权地址，前端跳转至该地址完成授权，提供方携带 code 与 state 重定向回前端回调页面后提交至 /account/oauthLogin
+// @Tags         账户
+// @Produce      json
+// @Param        provider  query     string  true  "提供方名称"
+// @Success      200     {object}   vo.Result{data=account.OAuthAuthorizationVO}  "获取成功"
+// @Failure      400     {object}   vo.Result         "参数错误，提供方不存在或未启用第三方登录"
+// @Failure      429     {object}   vo.Result         "发起授权过于频繁"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Router       /account/getOAuthAuthorizationURL [get]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func GetOAuthAuthorizationURL(c echo.Context) error {
+	req := new(dto.GetOAuthAuthorizationRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	response, err := service.GetOAuthAuthorizationURL(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// OAuthLogin godoc
+// @Summary      第三方登录
+// @Description  提交第三方授权回调携带的 code 与 state 完成登录，第三方账号未绑定时以提供方已验证的邮箱自动创建评论者账户，邮箱已注册时须登录后绑定；已启用双因素认证的账户返回登录挑战令牌
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.OAuthCallbackRequest  true  "第三方授权回调信息"
+// @Success      200     {object}   vo.Result{data=account.LoginVO}  "登录成功，返回访问令牌"
+// @Failure      400     {object}   vo.Result         "参数错误，授权已过期、授权失败或邮箱已被注册"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Router       /account/oauthLogin [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func OAuthLogin(c echo.Context) error {
+	req := new(dto.OAuthCallbackRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	response, err := service.OAuthLogin(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// GetOAuthLinkURL godoc
+// @Summary      获取绑定第三方账号授权地址
+// @Description  获取为当前账户绑定第三方账号的授权地址，授权完成后将回调携带的 code 与 state 提交至 /account/linkOAuthIdentity
+// @Tags         账户
+// @Produce      json
+// @Param        provider  query     string  true  "提供方名称"
+// @Success      200     {object}   vo.Result{data=account.OAuthAuthorizationVO}  "获取成功"
+// @Failure      400     {object}   vo.Result         "参数错误，提供方不存在或未启用第三方登录"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      429     {object}   vo.Result         "发起授权过于频繁"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/getOAuthLinkURL [get]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func GetOAuthLinkURL(c echo.Context) error {
+	req := new(dto.GetOAuthAuthorizationRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	response, err := service.GetOAuthLinkURL(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// LinkOAuthIdentity godoc
+// @Summary      绑定第三方账号
+// @Description  提交绑定授权回调携带的 code 与 state，为当前账户绑定第三方账号，绑定后可通过该第三方账号登录；每个提供方只能绑定一个第三方账号
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.OAuthCallbackRequest  true  "第三方授权回调信息"
+// @Success      200     {object}   vo.Result{data=account.OAuthIdentityVO}  "绑定成功"
+// @Failure      400     {object}   vo.Result         "参数错误，授权已过期、授权失败或第三方账号已被绑定"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/linkOAuthIdentity [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func LinkOAuthIdentity(c echo.Context) error {
+	req := new(dto.OAuthCallbackRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	response, err := service.LinkOAuthIdentity(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// GetOAuthReauthURL godoc
+// @Summary      获取重新验证身份授权地址
+// @Description  获取通过已绑定的第三方账号重新验证身份的授权地址，授权完成后将回调携带的 code 与 state 提交至 /account/verifyOAuthReauth；供未设置密码的账户注销账户或关闭双因素认证前使用
+// @Tags         账户
+// @Produce      json
+// @Param        provider  query     string  true  "提供方名称"
+// @Success      200     {object}   vo.Result{data=account.OAuthAuthorizationVO}  "获取成功"
+// @Failure      400     {object}   vo.Result         "参数错误，提供方不存在或未启用第三方登录"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      429     {object}   vo.Result         "发起授权过于频繁"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/getOAuthReauthURL [get]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func GetOAuthReauthURL(c echo.Context) error {
+	req := new(dto.GetOAuthAuthorizationRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	response, err := service.GetOAuthReauthURL(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// VerifyOAuthReauth godoc
+// @Summary      通过第三方账号重新验证身份
+// @Description  提交重新验证身份授权回调携带的 code 与 state，授权的第三方账号须已绑定当前账户；返回 5 分钟内有效、仅限当前会话使用一次的身份验证令牌，注销账户或关闭双因素认证时代替密码提交
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.OAuthCallbackRequest  true  "第三方授权回调信息"
+// @Success      200     {object}   vo.Result{data=account.OAuthReauthVO}  "验证成功"
+// @Failure      400     {object}   vo.Result         "参数错误，授权已过期、授权失败或第三方账号未绑定当前账户"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/verifyOAuthReauth [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func VerifyOAuthReauth(c echo.Context) error {
+	req := new(dto.OAuthCallbackRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	response, err := service.VerifyOAuthReauth(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// ListOAuthIdentities godoc
+// @Summary      获取已绑定的第三方账号
+// @Description  获取当前账户绑定的第三方账号
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=[]account.OAuthIdentityVO}  "获取成功"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/listOAuthIdentities [get]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func ListOAuthIdentities(c echo.Context) error {
+	response, err := service.ListOAuthIdentities(c)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, response))
+}
+
+// UnlinkOAuthIdentity godoc
+// @Summary      解除第三方账号绑定
+// @Description  解除当前账户绑定的第三方账号，未设置密码的账户须先通过找回密码设置密码才能解除最后一个绑定
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UnlinkOAuthIdentityRequest  true  "绑定记录ID"
+// @Success      200     {object}   vo.Result{data=string}  "解除成功"
+// @Failure      400     {object}   vo.Result         "参数错误，绑定记录不存在或不能解除最后一个绑定"
+// @Failure      401     {object}   vo.Result         "未授权，用户未登录"
+// @Failure      500     {object}   vo.Result         "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/unlinkOAuthIdentity [post]
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UnlinkOAuthIdentity(c echo.Context) error {
+	req := new(dto.UnlinkOAuthIdentityRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	if err := service.UnlinkOAuthIdentity(c, req); err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, "第三方账号已解除绑定"))
+}
@@ -33,9 +33,9 @@ type LoginRequest struct {
 
 // GetAccountRequest            获取账户信息请求体
 // @Description	请求获取账户信息时所需参数
-// @Param			email	    body	string	true	"用户邮箱"
+// @Param			email	    body	string	false	"用户邮箱，为空时获取当前登录用户，查询其他用户须具备账户管理权限"
 type GetAccountRequest struct {
-	Email string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	Email string `json:"email" xml:"email" form:"email" query:"email" validate:"omitempty,email"`
 }
 
 // UpdateAccountRequest  更新账户信息请求体
@@ -61,3 +61,149 @@ type ResetPwdRequest struct {
 	AgainNewPassword      string `json:"again_new_password" xml:"again_new_password" form:"again_new_password" query:"again_new_password" validate:"required,min=6,max=20"`
 	EmailVerificationCode string `json:"email_verification_code" xml:"email_verification_code" form:"email_verification_code" query:"email_verification_code" validate:"required"`
 }
+
+// ForgotPasswordRequest  找回密码请求体
+// @Description	用户未登录时通过找回密码验证码设置新密码所需参数
+// @Param			email				body	string	true	"用户邮箱"
+// @Param			reset_code			body	string	true	"找回密码验证码"
+// @Param			new_password		body	string	true	"新密码"
+// @Param			again_new_password	body	string	true	"再次输入新密码"
+type ForgotPasswordRequest struct {
+	Email            string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	ResetCode        string `json:"reset_code" xml:"reset_code" form:"reset_code" query:"reset_code" validate:"required"`
+	NewPassword      string `json:"new_password" xml:"new_password" form:"new_password" query:"new_password" validate:"required,min=6,max=20"`
+	AgainNewPassword string `json:"again_new_password" xml:"again_new_password" form:"again_new_password" query:"again_new_password" validate:"required,min=6,max=20"`
+}
+
+// UpdateAccountRoleRequest  修改账户角色请求体
+// @Description	管理员修改账户角色所需参数
+// @Param			email	body	string	true	"用户邮箱"
+// @Param			role	body	string	true	"角色：admin、editor、author、commenter"
+type UpdateAccountRoleRequest struct {
+	Email string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	Role  string `json:"role" xml:"role" form:"role" query:"role" validate:"required,oneof=admin editor author commenter"`
+}
+
+// UnlockAccountRequest  解除登录锁定请求体
+// @Description	管理员解除账户因登录失败过多产生的临时锁定
+// @Param			email	body	string	true	"被锁定的用户邮箱"
+// @Param			ip		body	string	false	"同时解除锁定的客户端 IP"
+type UnlockAccountRequest struct {
+	Email string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	IP    string `json:"ip" xml:"ip" form:"ip" query:"ip" validate:"omitempty,ip"`
+}
+
+// VerifyTotpLoginRequest  双因素认证登录请求体
+// @Description	登录第二步，凭登录挑战令牌与 TOTP 验证码或恢复码换取访问令牌
+// @Param			challenge_token	body	string	true	"登录挑战令牌"
+// @Param			code			body	string	false	"TOTP 验证码，与恢复码二选一"
+// @Param			recovery_code	body	string	false	"恢复码，与 TOTP 验证码二选一"
+type VerifyTotpLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" xml:"challenge_token" form:"challenge_token" query:"challenge_token" validate:"required"`
+	Code           string `json:"code" xml:"code" form:"code" query:"code" validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode   string `json:"recovery_code" xml:"recovery_code" form:"recovery_code" query:"recovery_code" validate:"required_without=Code,omitempty,max=32"`
+}
+
+// EnableTotpRequest  启用双因素认证请求体
+// @Description	凭验证器应用生成的 TOTP 验证码确认启用双因素认证
+// @Param			code	body	string	true	"TOTP 验证码"
+type EnableTotpRequest struct {
+	Code string `json:"code" xml:"code" form:"code" query:"code" validate:"required,len=6,numeric"`
+}
+
+// DisableTotpRequest  关闭双因素认证请求体
+// @Description	凭当前密码、身份验证令牌或 TOTP 验证码关闭双因素认证
+// @Param			password		body	string	false	"当前密码，与身份验证令牌、TOTP 验证码三选一"
+// @Param			reauth_token	body	string	false	"通过已绑定的第三方账号重新验证身份后获得的令牌，未设置密码的账户使用"
+// @Param			code			body	string	false	"TOTP 验证码，与当前密码、身份验证令牌三选一"
+type DisableTotpRequest struct {
+	Password    string `json:"password" xml:"password" form:"password" query:"password" validate:"required_without_all=Code ReauthToken,omitempty,max=20"`
+	ReauthToken string `json:"reauth_token" xml:"reauth_token" form:"reauth_token" query:"reauth_token" validate:"omitempty,max=64"`
+	Code        string `json:"code" xml:"code" form:"code" query:"code" validate:"omitempty,len=6,numeric"`
+}
+
+// RegenerateRecoveryCodesRequest  重新生成恢复码请求体
+// @Description	凭 TOTP 验证码作废旧恢复码并生成新的恢复码
+// @Param			code	body	string	true	"TOTP 验证码"
+type RegenerateRecoveryCodesRequest struct {
+	Code string `json:"code" xml:"code" form:"code" query:"code" validate:"required,len=6,numeric"`
+}
+
+// RevokeSessionRequest  注销登录会话请求体
+// @Description	注销当前账户的指定登录会话
+// @Param			session_id	body	string	true	"会话ID"
+type RevokeSessionRequest struct {
+	SessionID string `json:"session_id" xml:"session_id" form:"session_id" query:"session_id" validate:"required,hexadecimal,len=32"`
+}
+
+// RefreshTokenRequest   刷新令牌请求体
+// @Description	使用 Refresh Token 换取新的访问令牌与刷新令牌
+// @Param			refresh_token	body	string	true	"刷新令牌"
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" xml:"refresh_token" form:"refresh_token" query:"refresh_token" validate:"required"`
+}
+
+// CreateAccessTokenRequest  创建个人访问令牌请求体
+// @Description	创建供自动化脚本使用的个人访问令牌
+// @Param			name				body	string		true	"令牌名称"
+// @Param			scopes				body	[]string	true	"授权范围：posts:write、categories:write、comments:moderate、oss:read、oss:write"
+// @Param			expires_in_days		body	int			false	"有效天数，0 表示永不过期"
+type CreateAccessTokenRequest struct {
+	Name          string   `json:"name" xml:"name" form:"name" query:"name" validate:"required,max=64"`
+	Scopes        []string `json:"scopes" xml:"scopes" form:"scopes" query:"scopes" validate:"required,min=1,max=10,dive,required"`
+	ExpiresInDays int      `json:"expires_in_days" xml:"expires_in_days" form:"expires_in_days" query:"expires_in_days" validate:"min=0,max=3650"`
+}
+
+// RevokeAccessTokenRequest  撤销个人访问令牌请求体
+// @Description	撤销当前账户的个人访问令牌
+// @Param			id	body	string	true	"令牌ID"
+type RevokeAccessTokenRequest struct {
+	ID int64 `json:"id,string" xml:"id" form:"id" query:"id" validate:"required"`
+}
+
+// ChangeEmailRequest  修改邮箱请求体
+// @Description	凭分别发送至当前邮箱与新邮箱的验证码修改账户邮箱
+// @Param			new_email		body	string	true	"新邮箱"
+// @Param			old_email_code	body	string	true	"当前邮箱收到的验证码"
+// @Param			new_email_code	body	string	true	"新邮箱收到的验证码"
+type ChangeEmailRequest struct {
+	NewEmail     string `json:"new_email" xml:"new_email" form:"new_email" query:"new_email" validate:"required,email,max=64"`
+	OldEmailCode string `json:"old_email_code" xml:"old_email_code" form:"old_email_code" query:"old_email_code" validate:"required,len=6,numeric"`
+	NewEmailCode string `json:"new_email_code" xml:"new_email_code" form:"new_email_code" query:"new_email_code" validate:"required,len=6,numeric"`
+}
+
+// DeleteAccountRequest  注销账户请求体
+// @Description	凭当前密码或身份验证令牌注销账户，已启用双因素认证时还需提供 TOTP 验证码
+// @Param			password		body	string	false	"当前密码，与身份验证令牌二选一"
+// @Param			reauth_token	body	string	false	"通过已绑定的第三方账号重新验证身份后获得的令牌，未设置密码的账户使用"
+// @Param			code			body	string	false	"TOTP 验证码，已启用双因素认证时必填"
+type DeleteAccountRequest struct {
+	Password    string `json:"password" xml:"password" form:"password" query:"password" validate:"required_without=ReauthToken,omitempty,max=20"`
+	ReauthToken string `json:"reauth_token" xml:"reauth_token" form:"reauth_token" query:"reauth_token" validate:"omitempty,max=64"`
+	Code        string `json:"code" xml:"code" form:"code" query:"code" validate:"omitempty,len=6,numeric"`
+}
+
+// GetOAuthAuthorizationRequest  获取第三方授权地址请求体
+// @Description	获取跳转至第三方登录提供方的授权地址
+// @Param			provider	query	string	true	"提供方名称"
+type GetOAuthAuthorizationRequest struct {
+	Provider string `json:"provider" xml:"provider" form:"provider" query:"provider" validate:"required,max=32"`
+}
+
+// OAuthCallbackRequest  第三方授权回调请求体
+// @Description	前端回调页面将提供方重定向携带的 code 与 state 原样提交
+// @Param			provider	body	string	true	"提供方名称"
+// @Param			code		body	string	true	"授权码"
+// @Param			state		body	string	true	"授权 state"
+type OAuthCallbackRequest struct {
+	Provider string `json:"provider" xml:"provider" form:"provider" query:"provider" validate:"required,max=32"`
+	Code     string `json:"code" xml:"code" form:"code" query:"code" validate:"required,max=2048"`
+	State    string `json:"state" xml:"state" form:"state" query:"state" validate:"required,max=128"`
+}
+
+// UnlinkOAuthIdentityRequest  解除第三方账号绑定请求体
+// @Description	解除当前账户绑定的第三方账号
+// @Param			id	body	string	true	"绑定记录ID"
+type UnlinkOAuthIdentityRequest struct {
+	ID int64 `json:"id,string" xml:"id" form:"id" query:"id" validate:"required"`
+}
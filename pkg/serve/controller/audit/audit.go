@@ -0,0 +1,58 @@
+// Package audit 提供审计日志相关的HTTP接口处理
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package audit
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/audit/dto"
+	service "jank.com/jank_blog/pkg/serve/service/audit"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// ListAuditLogs godoc
+// @Summary      查询审计日志
+// @Description  按事件、操作者、IP、请求ID、审计对象与时间范围分页查询审计日志，最新的在前
+// @Tags         审计
+// @Accept       json
+// @Produce      json
+// @Param        action       query    string  false  "审计事件，如 account.login_failed"
+// @Param        actor_id     query    string  false  "操作者账户ID"
+// @Param        ip           query    string  false  "操作者 IP"
+// @Param        request_id   query    string  false  "请求ID"
+// @Param        target_type  query    string  false  "审计对象类型：account、ip、post、category、comment、object、config"
+// @Param        target_id    query    string  false  "审计对象标识"
+// @Param        start_time   query    int     false  "起始时间（含），Unix 秒"
+// @Param        end_time     query    int     false  "截止时间（不含），Unix 秒"
+// @Param        page         query    int     false  "页码(默认为1)"
+// @Param        page_size    query    int     false  "每页条数(默认为20,最大100)"
+// @Success      200   {object} vo.Result{data=audit.AuditLogPageVO}  "查询成功"
+// @Failure      400   {object} vo.Result  "请求参数错误"
+// @Failure      401   {object} vo.Result  "未授权，用户未登录"
+// @Failure      403   {object} vo.Result  "无权查看审计日志"
+// @Failure      500   {object} vo.Result  "服务器错误"
+// @Security     BearerAuth
+// @Router       /audit/listAuditLogs [get]
+func ListAuditLogs(c echo.Context) error {
+	req := new(dto.ListAuditLogsRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	logs, err := service.ListAuditLogs(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, logs))
+}
@@ -0,0 +1,28 @@
+// Package dto 提供审计日志相关的数据传输对象定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package dto
+
+// ListAuditLogsRequest 查询审计日志请求，未传入的条件不参与过滤
+// @Param action      query string false "审计事件，如 account.login_failed"
+// @Param actor_id    query int64  false "操作者账户ID"
+// @Param ip          query string false "操作者 IP"
+// @Param request_id  query string false "请求ID"
+// @Param target_type query string false "审计对象类型"
+// @Param target_id   query string false "审计对象标识"
+// @Param start_time  query int64  false "起始时间（含），Unix 秒"
+// @Param end_time    query int64  false "截止时间（不含），Unix 秒"
+// @Param page        query int    false "页码"
+// @Param page_size   query int    false "每页条数"
+type ListAuditLogsRequest struct {
+	Action     string `json:"action" xml:"action" form:"action" query:"action" validate:"omitempty,max=64"`
+	ActorID    int64  `json:"actor_id,string" xml:"actor_id,string" form:"actor_id,string" query:"actor_id" validate:"omitempty,min=0"`
+	IP         string `json:"ip" xml:"ip" form:"ip" query:"ip" validate:"omitempty,ip"`
+	RequestID  string `json:"request_id" xml:"request_id" form:"request_id" query:"request_id" validate:"omitempty,max=64"`
+	TargetType string `json:"target_type" xml:"target_type" form:"target_type" query:"target_type" validate:"omitempty,oneof=account ip post category comment object config"`
+	TargetID   string `json:"target_id" xml:"target_id" form:"target_id" query:"target_id" validate:"omitempty,max=255"`
+	StartTime  int64  `json:"start_time" xml:"start_time" form:"start_time" query:"start_time" validate:"omitempty,min=0"`
+	EndTime    int64  `json:"end_time" xml:"end_time" form:"end_time" query:"end_time" validate:"omitempty,min=0,gtfield=StartTime"`
+	Page       int    `json:"page" xml:"page" form:"page" query:"page" validate:"omitempty,min=1"`
+	PageSize   int    `json:"page_size" xml:"page_size" form:"page_size" query:"page_size" validate:"omitempty,min=1,max=100"`
+}
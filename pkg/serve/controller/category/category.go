@@ -94,6 +94,36 @@ func GetCategoryChildrenTree(c echo.Context) error {
 	return c.JSON(http.StatusOK, vo.Success(c, childrenCategories))
 }
 
+// GetCategoryBreadcrumb godoc
+// @Summary      获取类目面包屑
+// @Description  根据类目路径获取从根类目到当前类目的祖先链
+// @Tags         类目
+// @Accept       json
+// @Produce      json
+// @Param        id    query     string  true  "类目ID"
+// @Success      200   {object} vo.Result{data=[]category.CategoriesVO}  "获取成功"
+// @Failure      400   {object} vo.Result  "请求参数错误"
+// @Failure      500   {object} vo.Result  "服务器错误"
+// @Router       /category/getCategoryBreadcrumb [get]
+func GetCategoryBreadcrumb(c echo.Context) error {
+	req := new(dto.GetOneCategoryRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
+	}
+
+	breadcrumb, err := service.GetCategoryBreadcrumb(c, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, breadcrumb))
+}
+
 // CreateOneCategory     godoc
 // @Summary      创建类目
 // @Description  创建新的类目
@@ -157,14 +187,76 @@ func UpdateOneCategory(c echo.Context) error {
 	return c.JSON(http.StatusOK, vo.Success(c, updatedCategory))
 }
 
+// MoveCategory     godoc
+// @Summary      移动类目
+// @Description  将类目及其子树移动到新父类目下的指定位置
+// @Tags         类目
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.MoveCategoryRequest  true  "移动类目请求参数"
+// @Success      200     {object}   vo.Result{data=category.CategoriesVO}  "移动成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Security     BearerAuth
+// @Router       /category/moveCategory [post]
+func MoveCategory(c echo.Context) error {
+	req := new(dto.MoveCategoryRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
+	}
+
+	movedCategory, err := service.MoveCategory(c, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, movedCategory))
+}
+
+// MergeCategories     godoc
+// @Summary      合并类目
+// @Description  将源类目的文章与子类目全部转移到目标类目，并删除源类目
+// @Tags         类目
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.MergeCategoriesRequest  true  "合并类目请求参数"
+// @Success      200     {object}   vo.Result{data=category.MergeCategoriesVO}  "合并成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Security     BearerAuth
+// @Router       /category/mergeCategories [post]
+func MergeCategories(c echo.Context) error {
+	req := new(dto.MergeCategoriesRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
+	}
+
+	result, err := service.MergeCategories(c, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, result))
+}
+
 // DeleteOneCategory   godoc
 // @Summary      删除类目
-// @Description  根据类目 ID 删除类目
+// @Description  根据类目 ID 删除类目及其子类目，并按策略处理其下的文章
 // @Tags         类目
 // @Accept       json
 // @Produce      json
-// @Param        id    path     string  true  "类目ID"
-// @Success      200   {object} vo.Result{data=category.CategoriesVO}  "删除成功"
+// @Param        request  body  dto.DeleteOneCategoryRequest  true  "删除类目请求参数"
+// @Success      200   {object} vo.Result{data=category.DeleteCategoryVO}  "删除成功"
 // @Failure      400   {object} vo.Result  "请求参数错误"
 // @Failure      404   {object} vo.Result  "类目不存在"
 // @Failure      500   {object} vo.Result  "服务器错误"
@@ -188,3 +280,41 @@ func DeleteOneCategory(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, vo.Success(c, category))
 }
+
+// CheckCategoryTree   godoc
+// @Summary      检查类目树完整性
+// @Description  检查类目路径与父类目链是否一致、孤儿类目、父子环以及指向已删除类目的文章关联
+// @Tags         类目
+// @Accept       json
+// @Produce      json
+// @Success      200   {object} vo.Result{data=category.CategoryTreeReportVO}  "检查完成"
+// @Failure      500   {object} vo.Result  "服务器错误"
+// @Security     BearerAuth
+// @Router       /category/checkCategoryTree [get]
+func CheckCategoryTree(c echo.Context) error {
+	report, err := service.CheckCategoryTree(c, false)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, report))
+}
+
+// RepairCategoryTree   godoc
+// @Summary      修复类目树
+// @Description  在事务中修复类目树完整性问题，并返回修复前检测到的问题
+// @Tags         类目
+// @Accept       json
+// @Produce      json
+// @Success      200   {object} vo.Result{data=category.CategoryTreeReportVO}  "修复成功"
+// @Failure      500   {object} vo.Result  "服务器错误"
+// @Security     BearerAuth
+// @Router       /category/repairCategoryTree [post]
+func RepairCategoryTree(c echo.Context) error {
+	report, err := service.CheckCategoryTree(c, true)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, report))
+}
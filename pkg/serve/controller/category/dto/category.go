@@ -13,10 +13,21 @@ type CreateOneCategoryRequest struct {
 	ParentID    int64  `json:"parent_id,string" xml:"parent_id" form:"parent_id" query:"parent_id" validate:"omitempty"`
 }
 
+// 删除类目时文章的处理策略
+const (
+	DELETE_STRATEGY_NONE     = "none"     // 文章变为未分类
+	DELETE_STRATEGY_PARENT   = "parent"   // 文章转移到父类目
+	DELETE_STRATEGY_CATEGORY = "category" // 文章转移到指定类目
+)
+
 // DeleteOneCategoryRequest  删除类目请求
-// @Param id path int64 true "类目ID"
+// @Param id        path int64  true  "类目ID"
+// @Param strategy  body string false "文章处理策略：none（默认，变为未分类）、parent（转移到父类目）、category（转移到指定类目）"
+// @Param target_id body int64  false "strategy 为 category 时的目标类目ID"
 type DeleteOneCategoryRequest struct {
-	ID int64 `json:"id,string" xml:"id" form:"id" query:"id" validate:"required"`
+	ID       int64  `json:"id,string" xml:"id" form:"id" query:"id" validate:"required"`
+	Strategy string `json:"strategy" xml:"strategy" form:"strategy" query:"strategy" validate:"omitempty,oneof=none parent category"`
+	TargetID int64  `json:"target_id,string" xml:"target_id" form:"target_id" query:"target_id" validate:"omitempty"`
 }
 
 // GetOneCategoryRequest 更新类目请求
@@ -36,3 +47,21 @@ type UpdateOneCategoryRequest struct {
 	Description string `json:"description" xml:"description" form:"description" query:"description" default:""`
 	ParentID    int64  `json:"parent_id,string" xml:"parent_id" form:"parent_id" query:"parent_id" validate:"omitempty"`
 }
+
+// MoveCategoryRequest     移动类目请求
+// @Param id        body int64 true  "类目ID"
+// @Param parent_id body int64 false "新父类目ID，0 表示根类目"
+// @Param position  body int   false "同级中的目标位置，从 0 开始"
+type MoveCategoryRequest struct {
+	ID       int64 `json:"id,string" xml:"id" form:"id" query:"id" validate:"required"`
+	ParentID int64 `json:"parent_id,string" xml:"parent_id" form:"parent_id" query:"parent_id" validate:"omitempty"`
+	Position int   `json:"position" xml:"position" form:"position" query:"position" validate:"min=0"`
+}
+
+// MergeCategoriesRequest  合并类目请求
+// @Param source_id body int64 true "源类目ID，合并后删除"
+// @Param target_id body int64 true "目标类目ID"
+type MergeCategoriesRequest struct {
+	SourceID int64 `json:"source_id,string" xml:"source_id" form:"source_id" query:"source_id" validate:"required"`
+	TargetID int64 `json:"target_id,string" xml:"target_id" form:"target_id" query:"target_id" validate:"required"`
+}
@@ -46,15 +46,20 @@ func GetOneComment(c echo.Context) error {
 }
 
 // GetCommentGraph godoc
-// @Summary      获取评论图
-// @Description  根据文章 ID 获取评论图结构
+// @Summary      分页获取文章评论
+// @Description  根据文章 ID 分页获取顶层评论，并按层级内联回复，附带校验通过的 Webmention，未登录时仅包含审核通过的评论
 // @Tags         评论
 // @Accept       json
 // @Produce      json
-// @Param        post_id    query     string  true  "文章ID"
-// @Success      200        {object} vo.Result{data=[]comment.CommentsVO}  "获取成功"
+// @Param        post_id    query     string  true   "文章ID"
+// @Param        sort       query     string  false  "排序方式：newest、oldest、reactions"
+// @Param        page       query     int     false  "页码(默认为1)"
+// @Param        page_size  query     int     false  "每页顶层评论条数(默认为10,最大100)"
+// @Param        depth      query     int     false  "内联展示的回复层级"
+// @Success      200        {object} vo.Result{data=comment.CommentPageVO}  "获取成功"
+// @Failure      400        {object} vo.Result  "请求参数错误"
 // @Failure      500        {object} vo.Result  "服务器错误"
-// @Router       /comment/getOneComment [get]
+// @Router       /comment/getCommentGraph [get]
 func GetCommentGraph(c echo.Context) error {
 	req := new(dto.GetCommentGraphRequest)
 	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
@@ -74,15 +79,79 @@ func GetCommentGraph(c echo.Context) error {
 	return c.JSON(http.StatusOK, vo.Success(c, comments))
 }
 
+// GetCommentReplies godoc
+// @Summary      按游标加载评论回复
+// @Description  按创建时间顺序加载评论的直接回复，用于展开超出内联层级或条数的回复
+// @Tags         评论
+// @Accept       json
+// @Produce      json
+// @Param        comment_id  query    string  true   "父评论ID"
+// @Param        cursor      query    string  false  "游标"
+// @Param        limit       query    int     false  "返回条数(最大100)"
+// @Success      200   {object} vo.Result{data=comment.CommentRepliesVO}  "获取成功"
+// @Failure      400   {object} vo.Result  "请求参数错误"
+// @Failure      500   {object} vo.Result  "服务器错误"
+// @Router       /comment/getCommentReplies [get]
+func GetCommentReplies(c echo.Context) error {
+	req := new(dto.GetCommentRepliesRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
+	}
+
+	replies, err := service.GetCommentReplies(c, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, replies))
+}
+
+// ReactComment godoc
+// @Summary      评论点赞
+// @Description  为审核通过的评论点赞，同一访客对同一评论在 30 天内仅计一次
+// @Tags         评论
+// @Accept       json
+// @Produce      json
+// @Param        request  body     dto.ReactCommentRequest  true  "评论互动请求参数"
+// @Success      200   {object} vo.Result{data=comment.CommentReactionVO}  "操作成功"
+// @Failure      400   {object} vo.Result  "请求参数错误"
+// @Failure      500   {object} vo.Result  "服务器错误"
+// @Router       /comment/reactComment [post]
+func ReactComment(c echo.Context) error {
+	req := new(dto.ReactCommentRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
+	}
+
+	reaction, err := service.ReactToComment(c, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, reaction))
+}
+
 // CreateOneComment godoc
 // @Summary      创建评论
-// @Description  创建一条新的评论
+// @Description  创建一条新的评论，未登录时需提供昵称、邮箱与图形验证码以游客身份评论，并按 IP 限流
 // @Tags         评论
 // @Accept       json
 // @Produce      json
 // @Param        request  body      dto.CreateCommentRequest  true  "创建评论请求参数"
 // @Success      200     {object}   vo.Result{data=comment.CommentsVO}  "创建成功"
 // @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      403     {object}   vo.Result          "文章已关闭评论或仅允许登录用户评论"
+// @Failure      429     {object}   vo.Result          "请求过于频繁"
 // @Router       /comment/createOneComment [post]
 func CreateOneComment(c echo.Context) error {
 	req := new(dto.CreateCommentRequest)
@@ -95,23 +164,160 @@ func CreateOneComment(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
 	}
 
+	if _, authenticated := utils.GetAuthAccountID(c); !authenticated {
+		if err := service.CheckGuestComment(c, req); err != nil {
+			return vo.FailWithBizErr(c, err)
+		}
+
+		if !utils.VerifyImgCode(c, req.ImgVerificationCode, req.Email) {
+			return c.JSON(http.StatusBadRequest, vo.Fail(c, nil, bizErr.New(bizErr.BAD_REQUEST, "图形验证码校验失败")))
+		}
+	}
+
 	comment, err := service.CreateComment(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, comment))
+}
+
+// GetModerationQueue godoc
+// @Summary      获取评论审核队列
+// @Description  按审核状态分页获取评论，默认返回待审核评论
+// @Tags         评论
+// @Accept       json
+// @Produce      json
+// @Param        status     query    string  false  "审核状态：pending、approved、spam、rejected"
+// @Param        page       query    int     false  "页码(默认为1)"
+// @Param        page_size  query    int     false  "每页条数(默认为20,最大100)"
+// @Success      200   {object} vo.Result{data=comment.ModerationQueueVO}  "获取成功"
+// @Failure      400   {object} vo.Result  "请求参数错误"
+// @Failure      500   {object} vo.Result  "服务器错误"
+// @Security     BearerAuth
+// @Router       /comment/getModerationQueue [get]
+func GetModerationQueue(c echo.Context) error {
+	req := new(dto.GetModerationQueueRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
+	}
+
+	queue, err := service.GetModerationQueue(c, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, queue))
+}
+
+// ModerateComments godoc
+// @Summary      批量审核评论
+// @Description  批量通过、拒绝评论或将其标记为垃圾评论
+// @Tags         评论
+// @Accept       json
+// @Produce      json
+// @Param        request  body     dto.ModerateCommentsRequest  true  "批量审核请求参数"
+// @Success      200   {object} vo.Result{data=comment.ModerateCommentsVO}  "审核成功"
+// @Failure      400   {object} vo.Result  "请求参数错误"
+// @Failure      500   {object} vo.Result  "服务器错误"
+// @Security     BearerAuth
+// @Router       /comment/moderateComments [post]
+func ModerateComments(c echo.Context) error {
+	req := new(dto.ModerateCommentsRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
+	}
+
+	result, err := service.ModerateComments(c, req)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
 	}
 
+	return c.JSON(http.StatusOK, vo.Success(c, result))
+}
+
+// UpdateOneComment godoc
+// @Summary      编辑评论
+// @Description  评论作者在编辑时间窗口内编辑评论，游客需提供创建评论时返回的编辑凭证，编辑前的内容保存为历史版本
+// @Tags         评论
+// @Accept       json
+// @Produce      json
+// @Param        request  body     dto.UpdateCommentRequest  true  "编辑评论请求参数"
+// @Success      200   {object} vo.Result{data=comment.CommentsVO}  "编辑成功"
+// @Failure      400   {object} vo.Result  "请求参数错误"
+// @Failure      403   {object} vo.Result  "无权编辑或已超过可编辑时间"
+// @Failure      500   {object} vo.Result  "服务器错误"
+// @Router       /comment/updateOneComment [post]
+func UpdateOneComment(c echo.Context) error {
+	req := new(dto.UpdateCommentRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
+	}
+
+	comment, err := service.UpdateComment(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
 	return c.JSON(http.StatusOK, vo.Success(c, comment))
 }
 
+// GetCommentRevisions godoc
+// @Summary      获取评论编辑历史
+// @Description  获取评论编辑前的历史版本，最新的在前
+// @Tags         评论
+// @Accept       json
+// @Produce      json
+// @Param        comment_id  query    string  true  "评论ID"
+// @Success      200   {object} vo.Result{data=[]comment.CommentRevisionVO}  "获取成功"
+// @Failure      400   {object} vo.Result  "请求参数错误"
+// @Failure      500   {object} vo.Result  "服务器错误"
+// @Security     BearerAuth
+// @Router       /comment/getCommentRevisions [get]
+func GetCommentRevisions(c echo.Context) error {
+	req := new(dto.GetCommentRevisionsRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
+	}
+
+	revisions, err := service.GetCommentRevisions(c, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, revisions))
+}
+
 // DeleteOneComment godoc
 // @Summary      软删除评论
-// @Description  通过评论 ID 进行软删除
+// @Description  通过评论 ID 进行软删除，仅评论作者或博主可删除，游客需提供创建评论时返回的编辑凭证
 // @Tags         评论
 // @Accept       json
 // @Produce      json
-// @Param        id    body     string  true  "评论ID"
+// @Param        request  body     dto.DeleteCommentRequest  true  "删除评论请求参数"
 // @Success      200   {object} vo.Result{data=comment.CommentsVO}  "软删除成功"
 // @Failure      400   {object} vo.Result  "请求参数错误"
+// @Failure      403   {object} vo.Result  "无权删除"
 // @Failure      404   {object} vo.Result  "评论不存在"
 // @Router       /comment/deleteOneComment [post]
 func DeleteOneComment(c echo.Context) error {
@@ -127,7 +333,7 @@ func DeleteOneComment(c echo.Context) error {
 
 	comment, err := service.DeleteComment(c, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return vo.FailWithBizErr(c, err)
 	}
 
 	return c.JSON(http.StatusOK, vo.Success(c, comment))
@@ -3,26 +3,76 @@
 // 创建时间：2025-05-10
 package dto
 
-// CreateCommentRequest 创建评论请求
-// @Param content     body string  true  "评论内容"
-// @Param post_id     body int64   true  "文章ID"
-// @Param reply_to_comment_id body int64 false "回复的评论ID"
+// CreateCommentRequest 创建评论请求，未登录时以游客身份评论
+// @Param content               body string  true  "评论内容"
+// @Param post_id               body int64   true  "文章ID"
+// @Param reply_to_comment_id   body int64   false "回复的评论ID"
+// @Param nickname              body string  false "游客昵称，游客评论必填"
+// @Param email                 body string  false "游客邮箱，游客评论必填"
+// @Param website               body string  false "游客个人网站"
+// @Param img_verification_code body string  false "图形验证码，游客评论必填"
 type CreateCommentRequest struct {
-	Content          string `json:"content" xml:"content" form:"content" query:"content" validate:"required,min=1,max=1024"`
-	PostId           int64  `json:"post_id,string" xml:"post_id,string" form:"post_id,string" query:"post_id" validate:"required"`
-	ReplyToCommentId int64  `json:"reply_to_comment_id,string" xml:"reply_to_comment_id,string" form:"reply_to_comment_id,string" query:"reply_to_comment_id" validate:"omitempty"`
+	Content             string `json:"content" xml:"content" form:"content" query:"content" validate:"required,min=1,max=10000"`
+	PostId              int64  `json:"post_id,string" xml:"post_id,string" form:"post_id,string" query:"post_id" validate:"required"`
+	ReplyToCommentId    int64  `json:"reply_to_comment_id,string" xml:"reply_to_comment_id,string" form:"reply_to_comment_id,string" query:"reply_to_comment_id" validate:"omitempty"`
+	Nickname            string `json:"nickname" xml:"nickname" form:"nickname" query:"nickname" validate:"omitempty,min=1,max=64"`
+	Email               string `json:"email" xml:"email" form:"email" query:"email" validate:"omitempty,email,max=255"`
+	Website             string `json:"website" xml:"website" form:"website" query:"website" validate:"omitempty,url,max=255"`
+	ImgVerificationCode string `json:"img_verification_code" xml:"img_verification_code" form:"img_verification_code" query:"img_verification_code" validate:"omitempty"`
 }
 
-// DeleteCommentRequest 删除评论请求
-// @Param id path int64 true "评论ID"
+// DeleteCommentRequest 删除评论请求，游客删除自己的评论时需提供创建时返回的编辑凭证
+// @Param id         body int64  true  "评论ID"
+// @Param edit_token body string false "游客编辑凭证"
 type DeleteCommentRequest struct {
-	ID int64 `json:"id,string" xml:"id,string" form:"id,string" query:"id" validate:"required"`
+	ID        int64  `json:"id,string" xml:"id,string" form:"id,string" query:"id" validate:"required"`
+	EditToken string `json:"edit_token" xml:"edit_token" form:"edit_token" query:"edit_token" validate:"omitempty,max=128"`
+}
+
+// UpdateCommentRequest 编辑评论请求，游客编辑时需提供创建时返回的编辑凭证
+// @Param id         body int64  true  "评论ID"
+// @Param content    body string true  "评论内容"
+// @Param edit_token body string false "游客编辑凭证"
+type UpdateCommentRequest struct {
+	ID        int64  `json:"id,string" xml:"id,string" form:"id,string" query:"id" validate:"required"`
+	Content   string `json:"content" xml:"content" form:"content" query:"content" validate:"required,min=1,max=10000"`
+	EditToken string `json:"edit_token" xml:"edit_token" form:"edit_token" query:"edit_token" validate:"omitempty,max=128"`
 }
 
-// GetCommentGraphRequest 获取评论请求
-// @Param post_id path int64 true "帖子ID"
+// GetCommentRevisionsRequest 获取评论编辑历史请求
+// @Param comment_id query int64 true "评论ID"
+type GetCommentRevisionsRequest struct {
+	CommentID int64 `json:"comment_id,string" xml:"comment_id,string" form:"comment_id,string" query:"comment_id" validate:"required"`
+}
+
+// GetCommentGraphRequest 分页获取评论请求
+// @Param post_id   query int64  true  "帖子ID"
+// @Param sort      query string false "排序方式：newest、oldest、reactions，默认 newest"
+// @Param page      query int    false "页码"
+// @Param page_size query int    false "每页顶层评论条数"
+// @Param depth     query int    false "内联展示的回复层级，不超过配置的最大层级"
 type GetCommentGraphRequest struct {
-	PostID int64 `json:"post_id,string" xml:"post_id,string" form:"post_id,string" query:"post_id" validate:"required"`
+	PostID   int64  `json:"post_id,string" xml:"post_id,string" form:"post_id,string" query:"post_id" validate:"required"`
+	Sort     string `json:"sort" xml:"sort" form:"sort" query:"sort" validate:"omitempty,oneof=newest oldest reactions"`
+	Page     int    `json:"page" xml:"page" form:"page" query:"page" validate:"omitempty,min=1"`
+	PageSize int    `json:"page_size" xml:"page_size" form:"page_size" query:"page_size" validate:"omitempty,min=1,max=100"`
+	Depth    int    `json:"depth" xml:"depth" form:"depth" query:"depth" validate:"omitempty,min=1"`
+}
+
+// GetCommentRepliesRequest 按游标获取评论回复请求
+// @Param comment_id query int64  true  "父评论ID"
+// @Param cursor     query string false "上一页返回的游标，为空时从第一条回复开始"
+// @Param limit      query int    false "返回条数"
+type GetCommentRepliesRequest struct {
+	CommentID int64  `json:"comment_id,string" xml:"comment_id,string" form:"comment_id,string" query:"comment_id" validate:"required"`
+	Cursor    string `json:"cursor" xml:"cursor" form:"cursor" query:"cursor" validate:"omitempty,max=128"`
+	Limit     int    `json:"limit" xml:"limit" form:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ReactCommentRequest 评论互动（点赞）请求
+// @Param id body int64 true "评论ID"
+type ReactCommentRequest struct {
+	ID int64 `json:"id,string" xml:"id,string" form:"id,string" query:"id" validate:"required"`
 }
 
 // GetOneCommentRequest 获取评论请求
@@ -30,3 +80,28 @@ type GetCommentGraphRequest struct {
 type GetOneCommentRequest struct {
 	ID int64 `json:"id,string" xml:"id,string" form:"id,string" query:"id" validate:"required"`
 }
+
+// GetModerationQueueRequest 获取评论审核队列请求
+// @Param status    query string false "审核状态，默认 pending"
+// @Param page      query int    false "页码"
+// @Param page_size query int    false "每页条数"
+type GetModerationQueueRequest struct {
+	Status   string `json:"status" xml:"status" form:"status" query:"status" validate:"omitempty,oneof=pending approved spam rejected"`
+	Page     int    `json:"page" xml:"page" form:"page" query:"page" validate:"omitempty,min=1"`
+	PageSize int    `json:"page_size" xml:"page_size" form:"page_size" query:"page_size" validate:"omitempty,min=1,max=100"`
+}
+
+// 批量审核操作
+const (
+	MODERATE_ACTION_APPROVE = "approve" // 通过
+	MODERATE_ACTION_REJECT  = "reject"  // 拒绝
+	MODERATE_ACTION_SPAM    = "spam"    // 标记为垃圾评论
+)
+
+// ModerateCommentsRequest 批量审核评论请求
+// @Param ids    body []string true "评论ID列表"
+// @Param action body string   true "审核操作：approve、reject、spam"
+type ModerateCommentsRequest struct {
+	IDs    []string `json:"ids" xml:"ids" form:"ids" query:"ids" validate:"required,min=1,max=100,dive,numeric"`
+	Action string   `json:"action" xml:"action" form:"action" query:"action" validate:"required,oneof=approve reject spam"`
+}
@@ -0,0 +1,21 @@
+// Package dto 提供邮件通知相关的数据传输对象定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package dto
+
+// 退订类型
+const (
+	UNSUBSCRIBE_KIND_REPLIES  = "replies"  // 评论回复通知
+	UNSUBSCRIBE_KIND_COMMENTS = "comments" // 新评论通知
+	UNSUBSCRIBE_KIND_ALL      = "all"      // 全部通知
+)
+
+// UnsubscribeRequest 一键退订请求，参数来自邮件中的签名链接
+// @Param email query string true "邮箱地址"
+// @Param kind  query string true "退订类型：replies、comments、all"
+// @Param sig   query string true "签名"
+type UnsubscribeRequest struct {
+	Email string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email,max=255"`
+	Kind  string `json:"kind" xml:"kind" form:"kind" query:"kind" validate:"required,oneof=replies comments all"`
+	Sig   string `json:"sig" xml:"sig" form:"sig" query:"sig" validate:"required,max=128"`
+}
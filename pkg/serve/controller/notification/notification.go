@@ -0,0 +1,90 @@
+// Package notification 提供邮件通知相关的HTTP接口处理
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package notification
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/notification/dto"
+	service "jank.com/jank_blog/pkg/serve/service/notification"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/notification"
+)
+
+// GetUnsubscribePreference godoc
+// @Summary      查看退订确认页
+// @Description  通过邮件中的签名链接查看当前邮件通知偏好，仅展示不修改，浏览器访问时返回带确认按钮的页面
+// @Tags         邮件通知
+// @Accept       json
+// @Produce      json,html
+// @Param        email  query     string  true  "邮箱地址"
+// @Param        kind   query     string  true  "退订类型：replies、comments、all"
+// @Param        sig    query     string  true  "签名"
+// @Success      200    {object}  vo.Result{data=notification.EmailPreferenceVO}  "获取成功"
+// @Failure      400    {object}  vo.Result  "退订链接无效"
+// @Failure      500    {object}  vo.Result  "服务器错误"
+// @Router       /notification/unsubscribe [get]
+func GetUnsubscribePreference(c echo.Context) error {
+	return handleUnsubscribe(c, service.GetUnsubscribePreference, false)
+}
+
+// Unsubscribe godoc
+// @Summary      确认退订邮件通知
+// @Description  通过邮件中的签名链接退订评论回复或新评论通知，支持 RFC 8058 一键退订 POST
+// @Tags         邮件通知
+// @Accept       x-www-form-urlencoded
+// @Produce      json,html
+// @Param        email  query     string  true  "邮箱地址"
+// @Param        kind   query     string  true  "退订类型：replies、comments、all"
+// @Param        sig    query     string  true  "签名"
+// @Success      200    {object}  vo.Result{data=notification.EmailPreferenceVO}  "退订成功"
+// @Failure      400    {object}  vo.Result  "退订链接无效"
+// @Failure      500    {object}  vo.Result  "服务器错误"
+// @Router       /notification/unsubscribe [post]
+func Unsubscribe(c echo.Context) error {
+	return handleUnsubscribe(c, service.Unsubscribe, true)
+}
+
+// handleUnsubscribe 绑定退订参数并调用业务处理，浏览器访问时返回页面，其余返回 JSON
+// 参数：
+//   - c: Echo 上下文
+//   - handle: 退订业务处理函数
+//   - done: 业务处理后是否已完成退订
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func handleUnsubscribe(c echo.Context, handle func(echo.Context, *dto.UnsubscribeRequest) (*notification.EmailPreferenceVO, error), done bool) error {
+	req := new(dto.UnsubscribeRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
+	}
+
+	pref, err := handle(c, req)
+	if err != nil {
+		if e, ok := err.(*bizErr.Err); ok && e.Code == bizErr.BAD_REQUEST {
+			return c.JSON(http.StatusBadRequest, vo.Fail(c, err, e))
+		}
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	if strings.Contains(c.Request().Header.Get(echo.HeaderAccept), echo.MIMETextHTML) {
+		page, err := service.RenderUnsubscribePage(req, pref, done)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		}
+		return c.HTML(http.StatusOK, page)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, pref))
+}
@@ -5,16 +5,25 @@ package dto
 
 // CreateOnePostRequest         发布文章的请求结构体
 // @Param	title				body	string	true	"文章标题"
+// @Param	slug				body	string	false	"文章别名(可选,用于匹配迁移前的文章地址)"
 // @Param	image				body	string	true	"文章图片(可选)"
 // @Param	visibility			body	string	true	"文章可见性(可选,默认 private)"
 // @Param	content_html	    body	string	true	"文章内容(markdown格式)"
 // @Param	category_id			body	int64	true	"文章分类ID"
+// @Param	comments_disabled			body	bool	false	"是否关闭评论(可选,默认开放)"
+// @Param	comments_close_after_days	body	int		false	"发布多少天后关闭评论(可选,0 表示不限)"
+// @Param	comments_login_required		body	bool	false	"是否仅允许登录用户评论(可选)"
 type CreateOnePostRequest struct {
 	Title           string `json:"title" xml:"title" form:"title" query:"title" validate:"required,min=1,max=225"`
+	Slug            string `json:"slug" xml:"slug" form:"slug" query:"slug" validate:"omitempty,max=255"`
 	Image           string `json:"image" xml:"image" form:"image" query:"image"`
 	Visibility      bool   `json:"visibility" xml:"visibility" form:"visibility" query:"visibility" validate:"omitempty,boolean"`
 	ContentMarkdown string `json:"content_markdown" xml:"content_markdown" form:"content_markdown" query:"content_markdown"`
 	CategoryID      int64  `json:"category_id,string" xml:"category_id,string" form:"category_id,string" query:"category_id" validate:"omitempty"`
+
+	CommentsDisabled       bool `json:"comments_disabled" xml:"comments_disabled" form:"comments_disabled" query:"comments_disabled"`
+	CommentsCloseAfterDays int  `json:"comments_close_after_days" xml:"comments_close_after_days" form:"comments_close_after_days" query:"comments_close_after_days" validate:"omitempty,min=0,max=36500"`
+	CommentsLoginRequired  bool `json:"comments_login_required" xml:"comments_login_required" form:"comments_login_required" query:"comments_login_required"`
 }
 
 // DeleteOnePostRequest    文章删除请求
@@ -32,17 +41,26 @@ type GetOnePostRequest struct {
 // UpdateOnePostRequest       更新文章请求参数结构体
 // @Param   id   			  body    int	    	true      "文章 ID"
 // @Param   title		      body    string        false	  "文章标题"
+// @Param   slug		      body    string        false	  "文章别名(可选)"
 // @Param   image 		      body 	  string        false     "文章图片(可选)"
 // @Param   visibility 	      body 	  string        false     "文章可见性(可选)"
 // @Param   content_markdown  body    string 		false     "文章内容(markdown格式)"
 // @Param   category_id 	  body    int64         false     "文章分类ID列表(可选)"
+// @Param   comments_disabled          body  bool  false  "是否关闭评论(可选,不传则不修改)"
+// @Param   comments_close_after_days  body  int   false  "发布多少天后关闭评论(可选,0 表示不限,不传则不修改)"
+// @Param   comments_login_required    body  bool  false  "是否仅允许登录用户评论(可选,不传则不修改)"
 type UpdateOnePostRequest struct {
 	ID              int64  `json:"id,string" xml:"id,string" form:"id" query:"id" validate:"required"`
 	Title           string `json:"title" xml:"title" form:"title" query:"title" validate:"min=0,max=255"`
+	Slug            string `json:"slug" xml:"slug" form:"slug" query:"slug" validate:"omitempty,max=255"`
 	Image           string `json:"image" xml:"image" form:"image" query:"image"`
 	Visibility      bool   `json:"visibility" xml:"visibility" form:"visibility" query:"visibility" validate:"omitempty,boolean"`
 	ContentMarkdown string `json:"content_markdown" xml:"content_markdown" form:"content_markdown" query:"content_markdown"`
 	CategoryID      int64  `json:"category_id,string" xml:"category_id,string" form:"category_id,string" query:"category_id" validate:"omitempty"`
+
+	CommentsDisabled       *bool `json:"comments_disabled" xml:"comments_disabled" form:"comments_disabled" query:"comments_disabled"`
+	CommentsCloseAfterDays *int  `json:"comments_close_after_days" xml:"comments_close_after_days" form:"comments_close_after_days" query:"comments_close_after_days" validate:"omitempty,min=0,max=36500"`
+	CommentsLoginRequired  *bool `json:"comments_login_required" xml:"comments_login_required" form:"comments_login_required" query:"comments_login_required"`
 }
 
 // GetAllPostsRequest        获取文章列表的请求结构体
@@ -117,6 +117,7 @@ func CreateOnePost(c echo.Context) error {
 // @Param        request  body      dto.UpdateOnePostRequest  true  "更新文章请求参数"
 // @Success      200     {object}   vo.Result{data=post.PostsVO}  "更新成功"
 // @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      403     {object}   vo.Result          "无权编辑该文章"
 // @Failure      404     {object}   vo.Result          "文章不存在"
 // @Failure      500     {object}   vo.Result          "服务器错误"
 // @Security     BearerAuth
@@ -134,7 +135,7 @@ func UpdateOnePost(c echo.Context) error {
 
 	updatedPost, err := service.UpdateOnePost(c, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return vo.FailWithBizErr(c, err)
 	}
 
 	return c.JSON(http.StatusOK, vo.Success(c, updatedPost))
@@ -149,6 +150,7 @@ func UpdateOnePost(c echo.Context) error {
 // @Param        request  body      dto.DeleteOnePostRequest  true  "删除文章请求参数"
 // @Success      200     {object}   vo.Result          "删除成功"
 // @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      403     {object}   vo.Result          "无权删除该文章"
 // @Failure      404     {object}   vo.Result          "文章不存在"
 // @Failure      500     {object}   vo.Result          "服务器错误"
 // @Security     BearerAuth
@@ -166,7 +168,7 @@ func DeleteOnePost(c echo.Context) error {
 
 	err := service.DeleteOnePost(c, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+		return vo.FailWithBizErr(c, err)
 	}
 
 	return c.JSON(http.StatusOK, vo.Success(c, "文章删除成功"))
@@ -8,3 +8,17 @@ package dto
 type GetOneVerificationCode struct {
 	Email string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
 }
+
+// SendPasswordResetCodeRequest      发送找回密码验证码
+// @Param			email	                body	string	true	"用户邮箱"
+// @Param			img_verification_code	body	string	true	"图形验证码"
+type SendPasswordResetCodeRequest struct {
+	Email               string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	ImgVerificationCode string `json:"img_verification_code" xml:"img_verification_code" form:"img_verification_code" query:"img_verification_code" validate:"required"`
+}
+
+// SendEmailChangeCodeRequest        发送修改邮箱验证码
+// @Param			new_email	body	string	true	"新邮箱"
+type SendEmailChangeCodeRequest struct {
+	NewEmail string `json:"new_email" xml:"new_email" form:"new_email" query:"new_email" validate:"required,email,max=64"`
+}
@@ -74,3 +74,70 @@ func SendEmailVerificationCode(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, vo.Success(c, "邮箱验证码发送成功, 请注意查收！"))
 }
+
+// SendPasswordResetCode godoc
+// @Summary 发送找回密码验证码
+// @Description 向已注册邮箱发送一次性的找回密码验证码，验证码有效期为15分钟；邮箱未注册时同样返回成功
+// @Tags 账户
+// @Accept json
+// @Produce json
+// @Param email query string true "邮箱地址"
+// @Param img_verification_code query string true "图形验证码"
+// @Success 200 {object} vo.Result "找回密码验证码已发送, 请注意查收邮件"
+// @Failure 400 {object} vo.Result "请求参数错误，图形验证码校验失败"
+// @Failure 500 {object} vo.Result "服务器错误，验证码发送失败"
+// @Router /verification/sendPasswordResetCode [get]
+func SendPasswordResetCode(c echo.Context) error {
+	req := new(dto.SendPasswordResetCodeRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	if !utils.VerifyImgCode(c, req.ImgVerificationCode, req.Email) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, nil, bizErr.New(bizErr.BAD_REQUEST, "图形验证码校验失败")))
+	}
+
+	err := service.SendPasswordResetCode(c, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR)))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, "若邮箱已注册，找回密码验证码将发送至该邮箱, 请注意查收！"))
+}
+
+// SendEmailChangeCode godoc
+// @Summary 发送修改邮箱验证码
+// @Description 已登录用户修改邮箱前，分别向当前邮箱与新邮箱发送验证码，验证码有效期为15分钟
+// @Tags 账户
+// @Accept json
+// @Produce json
+// @Param new_email query string true "新邮箱"
+// @Success 200 {object} vo.Result "修改邮箱验证码已发送, 请注意查收邮件"
+// @Failure 400 {object} vo.Result "请求参数错误，新邮箱已被注册或与当前邮箱相同"
+// @Failure 401 {object} vo.Result "未授权，用户未登录"
+// @Failure 429 {object} vo.Result "验证码未过期，请勿重复获取"
+// @Failure 500 {object} vo.Result "服务器错误，验证码发送失败"
+// @Security BearerAuth
+// @Router /verification/sendEmailChangeCode [get]
+func SendEmailChangeCode(c echo.Context) error {
+	req := new(dto.SendEmailChangeCodeRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST, "请求参数校验失败")))
+	}
+
+	if err := service.SendEmailChangeCode(c, req); err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, "修改邮箱验证码已分别发送至当前邮箱与新邮箱, 请注意查收！"))
+}
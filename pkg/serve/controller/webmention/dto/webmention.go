@@ -0,0 +1,18 @@
+// Package dto 提供 Webmention 相关的数据传输对象定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package dto
+
+// ReceiveWebmentionRequest 接收 Webmention 请求（W3C Webmention 规范，x-www-form-urlencoded）
+// @Param source body string true "来源页面地址"
+// @Param target body string true "目标文章地址"
+type ReceiveWebmentionRequest struct {
+	Source string `json:"source" xml:"source" form:"source" query:"source" validate:"required,url,max=1024"`
+	Target string `json:"target" xml:"target" form:"target" query:"target" validate:"required,url,max=1024"`
+}
+
+// GetWebmentionsRequest 获取文章 Webmention 列表请求
+// @Param post_id query int64 true "文章ID"
+type GetWebmentionsRequest struct {
+	PostID int64 `json:"post_id,string" xml:"post_id,string" form:"post_id,string" query:"post_id" validate:"required"`
+}
@@ -0,0 +1,78 @@
+// Package webmention 提供 Webmention 相关的HTTP接口处理
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package webmention
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/webmention/dto"
+	service "jank.com/jank_blog/pkg/serve/service/webmention"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// ReceiveWebmention godoc
+// @Summary      接收 Webmention
+// @Description  W3C Webmention 接收端点，记录后异步拉取来源页面校验是否包含指向目标文章的链接
+// @Tags         Webmention
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        source  formData  string  true  "来源页面地址"
+// @Param        target  formData  string  true  "目标文章地址"
+// @Success      202     {object}  vo.Result{data=webmention.WebmentionsVO}  "已接收，等待异步校验"
+// @Failure      400     {object}  vo.Result  "请求参数错误"
+// @Failure      429     {object}  vo.Result  "请求过于频繁"
+// @Failure      500     {object}  vo.Result  "服务器错误"
+// @Router       /webmention/receiveWebmention [post]
+func ReceiveWebmention(c echo.Context) error {
+	req := new(dto.ReceiveWebmentionRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
+	}
+
+	mention, err := service.ReceiveWebmention(c, req)
+	if err != nil {
+		return vo.FailWithBizErr(c, err)
+	}
+
+	return c.JSON(http.StatusAccepted, vo.Success(c, mention))
+}
+
+// GetWebmentionList godoc
+// @Summary      获取文章 Webmention 列表
+// @Description  根据文章 ID 获取校验通过的 Webmention，与评论一同展示
+// @Tags         Webmention
+// @Accept       json
+// @Produce      json
+// @Param        post_id  query     string  true  "文章ID"
+// @Success      200      {object}  vo.Result{data=[]webmention.WebmentionsVO}  "获取成功"
+// @Failure      400      {object}  vo.Result  "请求参数错误"
+// @Failure      500      {object}  vo.Result  "服务器错误"
+// @Router       /webmention/getWebmentionList [get]
+func GetWebmentionList(c echo.Context) error {
+	req := new(dto.GetWebmentionsRequest)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, err, bizErr.New(bizErr.BAD_REQUEST, err.Error())))
+	}
+
+	errors := utils.Validator(req)
+	if errors != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(c, errors, bizErr.New(bizErr.BAD_REQUEST)))
+	}
+
+	mentions, err := service.GetWebmentionsByPostID(c, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(c, mentions))
+}
@@ -28,6 +28,23 @@ func GetTotalAccounts(c echo.Context) (int64, error) {
 	return count, nil
 }
 
+// CountAccountsByRole 统计指定角色的账户数
+// 参数：
+//   - c: Echo 上下文
+//   - role: 角色
+//
+// 返回值：
+//   - int64: 账户数
+//   - error: 操作过程中的错误
+func CountAccountsByRole(c echo.Context, role string) (int64, error) {
+	var count int64
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&account.Account{}).Where("role = ? AND deleted = ?", role, false).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计角色账户数失败: %w", err)
+	}
+	return count, nil
+}
+
 // GetAccountByEmail 根据邮箱获取用户账户信息
 // 参数：
 //   - c: Echo 上下文
@@ -91,3 +108,309 @@ func UpdateAccount(c echo.Context, acc *account.Account) error {
 	}
 	return nil
 }
+
+// CreateRecoveryCodes 批量保存双因素认证恢复码
+// 参数：
+//   - c: Echo 上下文
+//   - codes: 恢复码列表
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateRecoveryCodes(c echo.Context, codes []*account.RecoveryCode) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Create(&codes).Error; err != nil {
+		return fmt.Errorf("保存恢复码失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteRecoveryCodesByAccountID 作废账户的全部恢复码
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteRecoveryCodesByAccountID(c echo.Context, accountID int64) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&account.RecoveryCode{}).
+		Where("account_id = ? AND deleted = ?", accountID, false).
+		Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("作废恢复码失败: %w", err)
+	}
+	return nil
+}
+
+// ConsumeRecoveryCode 使用一枚恢复码，恢复码存在且未使用时将其作废
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//   - codeHash: 恢复码摘要
+//
+// 返回值：
+//   - bool: 恢复码是否有效
+//   - error: 操作过程中的错误
+func ConsumeRecoveryCode(c echo.Context, accountID int64, codeHash string) (bool, error) {
+	db := utils.GetDBFromContext(c)
+	result := db.Model(&account.RecoveryCode{}).
+		Where("account_id = ? AND code_hash = ? AND deleted = ?", accountID, codeHash, false).
+		Update("deleted", true)
+	if result.Error != nil {
+		return false, fmt.Errorf("使用恢复码失败: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountRecoveryCodes 统计账户剩余可用的恢复码数量
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - int64: 剩余恢复码数量
+//   - error: 操作过程中的错误
+func CountRecoveryCodes(c echo.Context, accountID int64) (int64, error) {
+	var count int64
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&account.RecoveryCode{}).
+		Where("account_id = ? AND deleted = ?", accountID, false).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计恢复码数量失败: %w", err)
+	}
+	return count, nil
+}
+
+// CreateAccessToken 保存个人访问令牌
+// 参数：
+//   - c: Echo 上下文
+//   - token: 个人访问令牌
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateAccessToken(c echo.Context, token *account.AccessToken) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Create(token).Error; err != nil {
+		return fmt.Errorf("保存个人访问令牌失败: %w", err)
+	}
+	return nil
+}
+
+// ListAccessTokensByAccountID 获取账户未撤销的个人访问令牌，按创建时间倒序排列
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - []*account.AccessToken: 个人访问令牌列表
+//   - error: 操作过程中的错误
+func ListAccessTokensByAccountID(c echo.Context, accountID int64) ([]*account.AccessToken, error) {
+	var tokens []*account.AccessToken
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("account_id = ? AND deleted = ?", accountID, false).
+		Order("gmt_create DESC").
+		Find(&tokens).Error; err != nil {
+		return nil, fmt.Errorf("获取个人访问令牌列表失败: %w", err)
+	}
+	return tokens, nil
+}
+
+// CountAccessTokensByAccountID 统计账户未撤销的个人访问令牌数量
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - int64: 令牌数量
+//   - error: 操作过程中的错误
+func CountAccessTokensByAccountID(c echo.Context, accountID int64) (int64, error) {
+	var count int64
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&account.AccessToken{}).
+		Where("account_id = ? AND deleted = ?", accountID, false).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计个人访问令牌数量失败: %w", err)
+	}
+	return count, nil
+}
+
+// RevokeAccessToken 撤销账户的个人访问令牌
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//   - tokenID: 令牌ID
+//
+// 返回值：
+//   - bool: 令牌是否存在且此前未被撤销
+//   - error: 操作过程中的错误
+func RevokeAccessToken(c echo.Context, accountID, tokenID int64) (bool, error) {
+	db := utils.GetDBFromContext(c)
+	result := db.Model(&account.AccessToken{}).
+		Where("id = ? AND account_id = ? AND deleted = ?", tokenID, accountID, false).
+		Update("deleted", true)
+	if result.Error != nil {
+		return false, fmt.Errorf("撤销个人访问令牌失败: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RevokeAccessTokensByAccountID 撤销账户的全部个人访问令牌
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RevokeAccessTokensByAccountID(c echo.Context, accountID int64) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&account.AccessToken{}).
+		Where("account_id = ? AND deleted = ?", accountID, false).
+		Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("撤销个人访问令牌失败: %w", err)
+	}
+	return nil
+}
+
+// AnonymizeAccount 注销账户：清除邮箱、手机号、密码等个人信息并逻辑删除，
+// 邮箱替换为占位地址以释放唯一约束，手机号置空
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//   - placeholderEmail: 占位邮箱
+//   - nickname: 注销后展示的昵称
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func AnonymizeAccount(c echo.Context, accountID int64, placeholderEmail, nickname string) error {
+	db := utils.GetDBFromContext(c)
+	result := db.Model(&account.Account{}).
+		Where("id = ? AND deleted = ?", accountID, false).
+		Updates(map[string]interface{}{
+			"email":          placeholderEmail,
+			"phone":          nil,
+			"password":       "",
+			"nickname":       nickname,
+			"avatar":         nil,
+			"totp_secret":    "",
+			"totp_enabled":   false,
+			"totp_last_step": 0,
+			"deleted":        true,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("注销账户失败: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("注销账户失败: 账户不存在或已注销")
+	}
+	return nil
+}
+
+// GetExternalIdentity 根据提供方与第三方账号标识获取未解除的绑定记录
+// 参数：
+//   - c: Echo 上下文
+//   - provider: 提供方名称
+//   - subject: 第三方账号唯一标识
+//
+// 返回值：
+//   - *account.ExternalIdentity: 绑定记录
+//   - error: 操作过程中的错误
+func GetExternalIdentity(c echo.Context, provider, subject string) (*account.ExternalIdentity, error) {
+	var identity account.ExternalIdentity
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("provider = ? AND subject = ? AND deleted = ?", provider, subject, false).
+		First(&identity).Error; err != nil {
+		return nil, fmt.Errorf("获取第三方账号绑定记录失败: %w", err)
+	}
+	return &identity, nil
+}
+
+// ListExternalIdentitiesByAccountID 获取账户绑定的第三方账号，按绑定时间顺序排列
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - []*account.ExternalIdentity: 绑定记录列表
+//   - error: 操作过程中的错误
+func ListExternalIdentitiesByAccountID(c echo.Context, accountID int64) ([]*account.ExternalIdentity, error) {
+	var identities []*account.ExternalIdentity
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("account_id = ? AND deleted = ?", accountID, false).
+		Order("gmt_create ASC").
+		Find(&identities).Error; err != nil {
+		return nil, fmt.Errorf("获取第三方账号绑定列表失败: %w", err)
+	}
+	return identities, nil
+}
+
+// CreateExternalIdentity 保存第三方账号绑定记录
+// 参数：
+//   - c: Echo 上下文
+//   - identity: 绑定记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateExternalIdentity(c echo.Context, identity *account.ExternalIdentity) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Create(identity).Error; err != nil {
+		return fmt.Errorf("保存第三方账号绑定记录失败: %w", err)
+	}
+	return nil
+}
+
+// UpdateExternalIdentityLogin 更新第三方账号最近一次登录时间及提供方返回的邮箱与名称
+// 参数：
+//   - c: Echo 上下文
+//   - identity: 绑定记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateExternalIdentityLogin(c echo.Context, identity *account.ExternalIdentity) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&account.ExternalIdentity{}).
+		Where("id = ? AND deleted = ?", identity.ID, false).
+		Updates(map[string]interface{}{
+			"email":         identity.Email,
+			"display_name":  identity.DisplayName,
+			"last_login_at": identity.LastLoginAt,
+		}).Error; err != nil {
+		return fmt.Errorf("更新第三方账号登录时间失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteExternalIdentity 解除账户与第三方账号的绑定
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//   - identityID: 绑定记录ID
+//
+// 返回值：
+//   - bool: 绑定记录是否存在且此前未被解除
+//   - error: 操作过程中的错误
+func DeleteExternalIdentity(c echo.Context, accountID, identityID int64) (bool, error) {
+	db := utils.GetDBFromContext(c)
+	result := db.Model(&account.ExternalIdentity{}).
+		Where("id = ? AND account_id = ? AND deleted = ?", identityID, accountID, false).
+		Update("deleted", true)
+	if result.Error != nil {
+		return false, fmt.Errorf("解除第三方账号绑定失败: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteExternalIdentitiesByAccountID 解除账户绑定的全部第三方账号
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteExternalIdentitiesByAccountID(c echo.Context, accountID int64) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&account.ExternalIdentity{}).
+		Where("account_id = ? AND deleted = ?", accountID, false).
+		Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("解除第三方账号绑定失败: %w", err)
+	}
+	return nil
+}
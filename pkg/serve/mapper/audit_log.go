@@ -0,0 +1,123 @@
+// Package mapper 提供数据模型与数据库交互的映射层，处理审计日志的数据操作
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	model "jank.com/jank_blog/internal/model/audit"
+	"jank.com/jank_blog/internal/utils"
+)
+
+// AuditLogFilter 审计日志查询条件，零值字段不参与过滤
+type AuditLogFilter struct {
+	Action     string // 审计事件
+	ActorID    int64  // 操作者账户 ID
+	IP         string // 操作者 IP
+	RequestID  string // 请求 ID
+	TargetType string // 审计对象类型
+	TargetID   string // 审计对象标识
+	StartTime  int64  // 起始时间（含），Unix 秒
+	EndTime    int64  // 截止时间（不含），Unix 秒
+}
+
+// CreateAuditLog 追加审计日志
+// 参数：
+//   - c: Echo 上下文
+//   - log: 审计日志
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateAuditLog(c echo.Context, log *model.AuditLog) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Create(log).Error; err != nil {
+		return fmt.Errorf("创建审计日志失败: %w", err)
+	}
+	return nil
+}
+
+// GetAuditLogsWithPaging 按条件分页获取审计日志，最新的在前
+// 参数：
+//   - c: Echo 上下文
+//   - filter: 查询条件
+//   - page: 页码
+//   - pageSize: 每页大小
+//
+// 返回值：
+//   - []*model.AuditLog: 审计日志列表
+//   - int64: 符合条件的日志总数
+//   - error: 操作过程中的错误
+func GetAuditLogsWithPaging(c echo.Context, filter *AuditLogFilter, page, pageSize int) ([]*model.AuditLog, int64, error) {
+	var logs []*model.AuditLog
+	var total int64
+	db := utils.GetDBFromContext(c)
+
+	if err := db.Model(&model.AuditLog{}).Scopes(auditLogFilter(filter)).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取审计日志总数失败: %w", err)
+	}
+
+	if err := db.Scopes(auditLogFilter(filter)).
+		Order("gmt_create DESC, id DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Find(&logs).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取分页审计日志失败: %w", err)
+	}
+	return logs, total, nil
+}
+
+// DeleteAuditLogsBefore 物理删除指定时间之前的审计日志，仅用于执行保留策略
+// 参数：
+//   - c: Echo 上下文
+//   - before: 截止时间（不含），Unix 秒
+//
+// 返回值：
+//   - int64: 删除的日志数量
+//   - error: 操作过程中的错误
+func DeleteAuditLogsBefore(c echo.Context, before int64) (int64, error) {
+	db := utils.GetDBFromContext(c)
+	result := db.Where("gmt_create < ?", before).Delete(&model.AuditLog{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("清理过期审计日志失败: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// auditLogFilter 生成审计日志查询条件
+// 参数：
+//   - filter: 查询条件
+//
+// 返回值：
+//   - func(*gorm.DB) *gorm.DB: GORM 查询作用域
+func auditLogFilter(filter *AuditLogFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Action != "" {
+			db = db.Where("action = ?", filter.Action)
+		}
+		if filter.ActorID != 0 {
+			db = db.Where("actor_id = ?", filter.ActorID)
+		}
+		if filter.IP != "" {
+			db = db.Where("ip = ?", filter.IP)
+		}
+		if filter.RequestID != "" {
+			db = db.Where("request_id = ?", filter.RequestID)
+		}
+		if filter.TargetType != "" {
+			db = db.Where("target_type = ?", filter.TargetType)
+		}
+		if filter.TargetID != "" {
+			db = db.Where("target_id = ?", filter.TargetID)
+		}
+		if filter.StartTime > 0 {
+			db = db.Where("gmt_create >= ?", filter.StartTime)
+		}
+		if filter.EndTime > 0 {
+			db = db.Where("gmt_create < ?", filter.EndTime)
+		}
+		return db
+	}
+}
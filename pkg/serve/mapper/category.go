@@ -5,8 +5,10 @@ package mapper
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 
 	category "jank.com/jank_blog/internal/model/category"
 	"jank.com/jank_blog/internal/utils"
@@ -29,6 +31,27 @@ func GetCategoryByID(c echo.Context, id int64) (*category.Category, error) {
 	return &cat, nil
 }
 
+// GetCategoriesByIDs 根据 ID 列表批量获取类目
+// 参数：
+//   - c: Echo 上下文
+//   - ids: 类目 ID 列表
+//
+// 返回值：
+//   - []*category.Category: 类目列表
+//   - error: 操作过程中的错误
+func GetCategoriesByIDs(c echo.Context, ids []int64) ([]*category.Category, error) {
+	var categories []*category.Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("id IN ? AND deleted = ?", ids, false).Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("批量获取类目失败: %v", err)
+	}
+	return categories, nil
+}
+
 // GetCategoriesByParentID 根据父类目 ID 查找直接子类目
 // 参数：
 //   - c: Echo 上下文
@@ -40,38 +63,55 @@ func GetCategoryByID(c echo.Context, id int64) (*category.Category, error) {
 func GetCategoriesByParentID(c echo.Context, parentID int64) ([]*category.Category, error) {
 	var categories []*category.Category
 	db := utils.GetDBFromContext(c)
-	if err := db.Where("parent_id = ? AND deleted = ?", parentID, false).Find(&categories).Error; err != nil {
+	if err := db.Scopes(parentIs(parentID)).
+		Where("deleted = ?", false).
+		Order("position ASC, id ASC").
+		Find(&categories).Error; err != nil {
 		return nil, fmt.Errorf("获取子类目失败: %v", err)
 	}
 	return categories, nil
 }
 
-// GetCategoriesByPath 根据路径获取所有子类目
+// GetNextCategoryPosition 获取父类目下新增子类目的排序位置（追加到末尾）
 // 参数：
 //   - c: Echo 上下文
-//   - path: 类目路径
+//   - parentID: 父类目 ID，0 表示根类目
 //
 // 返回值：
-//   - []*category.Category: 子类目列表
+//   - int: 排序位置
 //   - error: 操作过程中的错误
-func GetCategoriesByPath(c echo.Context, path string) ([]*category.Category, error) {
-	var categories []*category.Category
+func GetNextCategoryPosition(c echo.Context, parentID int64) (int, error) {
+	var next int
 	db := utils.GetDBFromContext(c)
+	if err := db.Model(&category.Category{}).
+		Scopes(parentIs(parentID)).
+		Where("deleted = ?", false).
+		Select("COALESCE(MAX(position), -1) + 1").
+		Scan(&next).Error; err != nil {
+		return 0, fmt.Errorf("获取类目排序位置失败: %v", err)
+	}
+	return next, nil
+}
 
-	// 如果路径为空，使用特殊查询条件只查询子类目
+// GetCategoriesByPath 获取子类目路径下的所有后代类目
+// 参数：
+//   - c: Echo 上下文
+//   - path: 子类目路径（即 类目路径/类目 ID，形如 /1/2）
+//
+// 返回值：
+//   - []*category.Category: 后代类目列表
+//   - error: 操作过程中的错误
+func GetCategoriesByPath(c echo.Context, path string) ([]*category.Category, error) {
 	if path == "" {
-		if err := db.Model(&category.Category{}).
-			Where("deleted = ?", false).
-			Find(&categories).Error; err != nil {
-			return nil, fmt.Errorf("获取路径下类目失败: %v", err)
-		}
-	} else {
-		// 对于非空路径，确保只返回以该路径开头的类目
-		if err := db.Model(&category.Category{}).
-			Where("path LIKE ? AND deleted = ?", fmt.Sprintf("%s%%", path), false).
-			Find(&categories).Error; err != nil {
-			return nil, fmt.Errorf("获取路径下类目失败: %v", err)
-		}
+		return nil, fmt.Errorf("获取路径下类目失败: 路径不能为空")
+	}
+
+	var categories []*category.Category
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&category.Category{}).
+		Where("(path = ? OR path LIKE ?) AND deleted = ?", path, path+"/%", false).
+		Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("获取路径下类目失败: %v", err)
 	}
 
 	return categories, nil
@@ -88,6 +128,7 @@ func GetAllActivatedCategories(c echo.Context) ([]*category.Category, error) {
 	var categories []*category.Category
 	db := utils.GetDBFromContext(c)
 	if err := db.Where("deleted = ?", false).
+		Order("position ASC, id ASC").
 		Find(&categories).Error; err != nil {
 		return nil, fmt.Errorf("获取所有类目失败: %v", err)
 	}
@@ -124,26 +165,142 @@ func UpdateCategory(c echo.Context, category *category.Category) error {
 	return nil
 }
 
-// DeleteCategoriesByPathSoftly 软删除类目及其子类目
+// UpdateCategoryPositions 批量更新类目排序位置，单条 CASE 语句完成
+// 参数：
+//   - c: Echo 上下文
+//   - positions: 类目 ID 到排序位置的映射
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateCategoryPositions(c echo.Context, positions map[int64]int) error {
+	if len(positions) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(positions))
+	args := make([]interface{}, 0, len(positions)*2)
+	var sb strings.Builder
+	sb.WriteString("CASE id")
+	for id, position := range positions {
+		sb.WriteString(" WHEN ? THEN ?")
+		args = append(args, id, position)
+		ids = append(ids, id)
+	}
+	sb.WriteString(" ELSE position END")
+
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&category.Category{}).
+		Where("id IN ?", ids).
+		Update("position", gorm.Expr(sb.String(), args...)).Error; err != nil {
+		return fmt.Errorf("更新类目排序位置失败: %v", err)
+	}
+	return nil
+}
+
+// UpdateDescendantCategoryPaths 将子树中所有后代类目路径的前缀由 oldPrefix 替换为 newPrefix，按方言生成单条集合更新语句
+// 参数：
+//   - c: Echo 上下文
+//   - oldPrefix: 原路径前缀（即被移动类目的子类目路径，形如 /1/2）
+//   - newPrefix: 新路径前缀
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateDescendantCategoryPaths(c echo.Context, oldPrefix, newPrefix string) error {
+	if oldPrefix == newPrefix {
+		return nil
+	}
+
+	db := utils.GetDBFromContext(c)
+
+	// path 只包含数字与斜杠，字节长度与字符长度一致
+	start := len(oldPrefix) + 1
+	var expr interface{}
+	switch db.Dialector.Name() {
+	case "mysql":
+		expr = gorm.Expr("CONCAT(?, SUBSTRING(path, ?))", newPrefix, start)
+	case "postgres":
+		expr = gorm.Expr("CAST(? AS VARCHAR) || SUBSTRING(path FROM CAST(? AS INTEGER))", newPrefix, start)
+	default:
+		expr = gorm.Expr("? || SUBSTR(path, ?)", newPrefix, start)
+	}
+
+	if err := db.Model(&category.Category{}).
+		Where("(path = ? OR path LIKE ?) AND deleted = ?", oldPrefix, oldPrefix+"/%", false).
+		Update("path", expr).Error; err != nil {
+		return fmt.Errorf("更新子树类目路径失败: %v", err)
+	}
+	return nil
+}
+
+// UpdateCategoryParentID 将父类目下的所有直接子类目转移到新父类目
+// 参数：
+//   - c: Echo 上下文
+//   - fromParentID: 原父类目 ID
+//   - toParentID: 新父类目 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateCategoryParentID(c echo.Context, fromParentID, toParentID int64) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&category.Category{}).
+		Scopes(parentIs(fromParentID)).
+		Where("deleted = ?", false).
+		Update("parent_id", toParentID).Error; err != nil {
+		return fmt.Errorf("转移子类目失败: %v", err)
+	}
+	return nil
+}
+
+// DeleteCategorySoftly 软删除单个类目
 // 参数：
 //   - c: Echo 上下文
-//   - path: 类目路径
 //   - id: 类目 ID
 //
 // 返回值：
 //   - error: 操作过程中的错误
-func DeleteCategoriesByPathSoftly(c echo.Context, path string, id int64) error {
+func DeleteCategorySoftly(c echo.Context, id int64) error {
 	db := utils.GetDBFromContext(c)
 	if err := db.Model(&category.Category{}).
 		Where("id = ? AND deleted = ?", id, false).
 		Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("删除类目失败: %v", err)
+	}
+	return nil
+}
+
+// DeleteCategoriesByPathSoftly 软删除类目及其所有后代类目
+// 参数：
+//   - c: Echo 上下文
+//   - path: 子类目路径（即 类目路径/类目 ID）
+//   - id: 类目 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteCategoriesByPathSoftly(c echo.Context, path string, id int64) error {
+	if err := DeleteCategorySoftly(c, id); err != nil {
 		return fmt.Errorf("删除当前类目失败: %v", err)
 	}
 
+	db := utils.GetDBFromContext(c)
 	if err := db.Model(&category.Category{}).
-		Where("path LIKE ? AND deleted = ? AND path != ?", fmt.Sprintf("%s%%", path), false, path).
+		Where("(path = ? OR path LIKE ?) AND deleted = ?", path, path+"/%", false).
 		Update("deleted", true).Error; err != nil {
 		return fmt.Errorf("删除子类目失败: %v", err)
 	}
 	return nil
 }
+
+// parentIs 按父类目筛选，根类目的 parent_id 可能为 NULL 或 0
+// 参数：
+//   - parentID: 父类目 ID，0 表示根类目
+//
+// 返回值：
+//   - func(*gorm.DB) *gorm.DB: GORM 查询作用域
+func parentIs(parentID int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if parentID == 0 {
+			return db.Where("(parent_id IS NULL OR parent_id = 0)")
+		}
+		return db.Where("parent_id = ?", parentID)
+	}
+}
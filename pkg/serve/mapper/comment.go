@@ -7,6 +7,8 @@ import (
 	"fmt"
 
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	model "jank.com/jank_blog/internal/model/comment"
 	"jank.com/jank_blog/internal/utils"
@@ -44,40 +46,205 @@ func GetCommentByID(c echo.Context, id int64) (*model.Comment, error) {
 	return &comment, nil
 }
 
-// GetReplyByCommentID 获取评论的所有回复
+// commentSortOrders 评论列表排序方式对应的排序语句
+var commentSortOrders = map[string]string{
+	model.COMMENT_SORT_NEWEST:    "gmt_create DESC, id DESC",
+	model.COMMENT_SORT_OLDEST:    "gmt_create ASC, id ASC",
+	model.COMMENT_SORT_REACTIONS: "reaction_count DESC, gmt_create DESC, id DESC",
+}
+
+// GetRootCommentsByPostIDWithPaging 分页获取文章的顶层评论
 // 参数：
 //   - c: Echo 上下文
-//   - id: 评论 ID
+//   - postID: 文章 ID
+//   - sort: 排序方式
+//   - approvedOnly: 是否仅返回审核通过的评论
+//   - page: 页码
+//   - pageSize: 每页大小
 //
 // 返回值：
-//   - []*model.Comment: 回复列表
+//   - []*model.Comment: 顶层评论列表
+//   - int64: 顶层评论总数
 //   - error: 操作过程中的错误
-func GetReplyByCommentID(c echo.Context, id int64) ([]*model.Comment, error) {
+func GetRootCommentsByPostIDWithPaging(c echo.Context, postID int64, sort string, approvedOnly bool, page, pageSize int) ([]*model.Comment, int64, error) {
+	order, ok := commentSortOrders[sort]
+	if !ok {
+		return nil, 0, fmt.Errorf("不支持的评论排序方式「%s」", sort)
+	}
+
 	var comments []*model.Comment
+	var total int64
 	db := utils.GetDBFromContext(c)
-	if err := db.Where("reply_to_comment_id = ? AND deleted = ?", id, false).Find(&comments).Error; err != nil {
-		return nil, fmt.Errorf("获取评论回复失败: %w", err)
+	query := db.Model(&model.Comment{}).
+		Where("post_id = ? AND deleted = ?", postID, false).
+		Where("reply_to_comment_id IS NULL OR reply_to_comment_id = ?", 0)
+	if approvedOnly {
+		query = query.Where("status = ?", model.COMMENT_STATUS_APPROVED)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取顶层评论总数失败: %w", err)
+	}
+
+	if err := query.Order(order).
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Find(&comments).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取分页顶层评论失败: %w", err)
+	}
+	return comments, total, nil
+}
+
+// GetRepliesByParentIDs 批量获取多条评论的最早若干条直接回复
+// 参数：
+//   - c: Echo 上下文
+//   - parentIDs: 父评论 ID 列表
+//   - approvedOnly: 是否仅返回审核通过的回复
+//   - limit: 每条父评论返回的回复数上限
+//
+// 返回值：
+//   - []*model.Comment: 回复列表，按创建时间升序
+//   - error: 操作过程中的错误
+func GetRepliesByParentIDs(c echo.Context, parentIDs []int64, approvedOnly bool, limit int) ([]*model.Comment, error) {
+	var comments []*model.Comment
+	if len(parentIDs) == 0 || limit <= 0 {
+		return comments, nil
+	}
+
+	db := utils.GetDBFromContext(c)
+	ranked := db.Model(&model.Comment{}).
+		Select("comments.*, ROW_NUMBER() OVER (PARTITION BY reply_to_comment_id ORDER BY gmt_create ASC, id ASC) AS reply_rank").
+		Where("reply_to_comment_id IN ? AND deleted = ?", parentIDs, false)
+	if approvedOnly {
+		ranked = ranked.Where("status = ?", model.COMMENT_STATUS_APPROVED)
+	}
+
+	if err := db.Table("(?) AS ranked_comments", ranked).
+		Where("reply_rank <= ?", limit).
+		Order("gmt_create ASC, id ASC").
+		Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("批量获取评论回复失败: %w", err)
 	}
 	return comments, nil
 }
 
-// GetCommentsByPostID 根据文章 ID 查询所有评论
+// GetRepliesAfterCursor 按游标获取评论的直接回复，按创建时间升序
 // 参数：
 //   - c: Echo 上下文
-//   - postID: 文章 ID
+//   - parentID: 父评论 ID
+//   - approvedOnly: 是否仅返回审核通过的回复
+//   - afterCreate: 游标位置的创建时间，为 0 时从头开始
+//   - afterID: 游标位置的评论 ID
+//   - limit: 返回条数上限
 //
 // 返回值：
-//   - []*model.Comment: 评论列表
+//   - []*model.Comment: 回复列表
 //   - error: 操作过程中的错误
-func GetCommentsByPostID(c echo.Context, postID int64) ([]*model.Comment, error) {
+func GetRepliesAfterCursor(c echo.Context, parentID int64, approvedOnly bool, afterCreate, afterID int64, limit int) ([]*model.Comment, error) {
 	var comments []*model.Comment
 	db := utils.GetDBFromContext(c)
-	if err := db.Where("post_id = ? AND deleted = ?", postID, false).Find(&comments).Error; err != nil {
-		return nil, fmt.Errorf("获取文章评论失败: %w", err)
+	query := db.Where("reply_to_comment_id = ? AND deleted = ?", parentID, false)
+	if approvedOnly {
+		query = query.Where("status = ?", model.COMMENT_STATUS_APPROVED)
+	}
+	if afterCreate > 0 {
+		query = query.Where("gmt_create > ? OR (gmt_create = ? AND id > ?)", afterCreate, afterCreate, afterID)
+	}
+
+	if err := query.Order("gmt_create ASC, id ASC").Limit(limit).Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("获取评论回复失败: %w", err)
 	}
 	return comments, nil
 }
 
+// CountRepliesGroupByParent 批量统计评论的直接回复数
+// 参数：
+//   - c: Echo 上下文
+//   - parentIDs: 父评论 ID 列表
+//   - approvedOnly: 是否仅统计审核通过的回复
+//
+// 返回值：
+//   - map[int64]int64: 父评论 ID 到回复数的映射
+//   - error: 操作过程中的错误
+func CountRepliesGroupByParent(c echo.Context, parentIDs []int64, approvedOnly bool) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(parentIDs))
+	if len(parentIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ReplyToCommentID int64
+		ReplyCount       int64
+	}
+
+	db := utils.GetDBFromContext(c)
+	query := db.Model(&model.Comment{}).
+		Select("reply_to_comment_id AS reply_to_comment_id, COUNT(*) AS reply_count").
+		Where("reply_to_comment_id IN ? AND deleted = ?", parentIDs, false)
+	if approvedOnly {
+		query = query.Where("status = ?", model.COMMENT_STATUS_APPROVED)
+	}
+
+	if err := query.Group("reply_to_comment_id").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("统计评论回复数失败: %w", err)
+	}
+
+	for _, row := range rows {
+		counts[row.ReplyToCommentID] = row.ReplyCount
+	}
+	return counts, nil
+}
+
+// CountApprovedCommentsGroupByPost 批量统计文章下审核通过的评论数
+// 参数：
+//   - c: Echo 上下文
+//   - postIDs: 文章 ID 列表
+//
+// 返回值：
+//   - map[int64]int64: 文章 ID 到评论数的映射
+//   - error: 操作过程中的错误
+func CountApprovedCommentsGroupByPost(c echo.Context, postIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(postIDs))
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		PostID       int64
+		CommentCount int64
+	}
+
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&model.Comment{}).
+		Select("post_id AS post_id, COUNT(*) AS comment_count").
+		Where("post_id IN ? AND status = ? AND deleted = ?", postIDs, model.COMMENT_STATUS_APPROVED, false).
+		Group("post_id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("统计文章评论数失败: %w", err)
+	}
+
+	for _, row := range rows {
+		counts[row.PostID] = row.CommentCount
+	}
+	return counts, nil
+}
+
+// IncrCommentReactionCount 评论互动次数加一
+// 参数：
+//   - c: Echo 上下文
+//   - id: 评论 ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func IncrCommentReactionCount(c echo.Context, id int64) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&model.Comment{}).
+		Where("id = ? AND deleted = ?", id, false).
+		UpdateColumn("reaction_count", gorm.Expr("reaction_count + ?", 1)).Error; err != nil {
+		return fmt.Errorf("更新评论互动次数失败: %w", err)
+	}
+	return nil
+}
+
 // UpdateComment 更新评论
 // 参数：
 //   - c: Echo 上下文
@@ -92,3 +259,308 @@ func UpdateComment(c echo.Context, comment *model.Comment) error {
 	}
 	return nil
 }
+
+// CreateImportedComment 写入导入的评论，同一来源评论已存在时不写入
+// 参数：
+//   - c: Echo 上下文
+//   - comment: 评论
+//
+// 返回值：
+//   - bool: 是否写入，来源评论已被其他导入任务写入时为 false
+//   - error: 操作过程中的错误
+func CreateImportedComment(c echo.Context, comment *model.Comment) (bool, error) {
+	db := utils.GetDBFromContext(c)
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "import_source"}, {Name: "import_id"}},
+		DoNothing: true,
+	}).Create(comment)
+	if result.Error != nil {
+		return false, fmt.Errorf("导入评论失败: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountApprovedCommentsByAccountID 统计账户下审核通过的评论数量
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//   - excludeID: 不计入统计的评论 ID，用于编辑评论时排除自身
+//
+// 返回值：
+//   - int64: 审核通过的评论数量
+//   - error: 操作过程中的错误
+func CountApprovedCommentsByAccountID(c echo.Context, accountID int64, excludeID int64) (int64, error) {
+	var count int64
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&model.Comment{}).
+		Where("account_id = ? AND status = ? AND deleted = ? AND id <> ?", accountID, model.COMMENT_STATUS_APPROVED, false, excludeID).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计评论者审核通过的评论失败: %w", err)
+	}
+	return count, nil
+}
+
+// GetCommentsByStatusWithPaging 按审核状态分页获取评论，最新的在前
+// 参数：
+//   - c: Echo 上下文
+//   - status: 审核状态
+//   - page: 页码
+//   - pageSize: 每页大小
+//
+// 返回值：
+//   - []*model.Comment: 评论列表
+//   - int64: 评论总数
+//   - error: 操作过程中的错误
+func GetCommentsByStatusWithPaging(c echo.Context, status string, page, pageSize int) ([]*model.Comment, int64, error) {
+	var comments []*model.Comment
+	var total int64
+	db := utils.GetDBFromContext(c)
+
+	if err := db.Model(&model.Comment{}).
+		Where("status = ? AND deleted = ?", status, false).
+		Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取评论总数失败: %w", err)
+	}
+
+	if err := db.Where("status = ? AND deleted = ?", status, false).
+		Order("id DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Find(&comments).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取分页评论列表失败: %w", err)
+	}
+	return comments, total, nil
+}
+
+// UpdateCommentsStatus 批量更新评论审核状态
+// 参数：
+//   - c: Echo 上下文
+//   - ids: 评论 ID 列表
+//   - status: 审核状态
+//
+// 返回值：
+//   - int64: 更新的评论数量
+//   - error: 操作过程中的错误
+func UpdateCommentsStatus(c echo.Context, ids []int64, status string) (int64, error) {
+	db := utils.GetDBFromContext(c)
+	result := db.Model(&model.Comment{}).
+		Where("id IN ? AND deleted = ?", ids, false).
+		Update("status", status)
+	if result.Error != nil {
+		return 0, fmt.Errorf("批量更新评论审核状态失败: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetCommentsByIDs 根据 ID 列表批量查询评论
+// 参数：
+//   - c: Echo 上下文
+//   - ids: 评论 ID 列表
+//
+// 返回值：
+//   - []*model.Comment: 评论列表
+//   - error: 操作过程中的错误
+func GetCommentsByIDs(c echo.Context, ids []int64) ([]*model.Comment, error) {
+	var comments []*model.Comment
+	if len(ids) == 0 {
+		return comments, nil
+	}
+
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("id IN ? AND deleted = ?", ids, false).Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("批量获取评论失败: %w", err)
+	}
+	return comments, nil
+}
+
+// CountCommentsByIPSince 统计指定 IP 自某一时间起发表的评论数
+// 参数：
+//   - c: Echo 上下文
+//   - ip: 评论者 IP
+//   - since: 起始时间戳，单位秒
+//
+// 返回值：
+//   - int64: 评论数
+//   - error: 操作过程中的错误
+func CountCommentsByIPSince(c echo.Context, ip string, since int64) (int64, error) {
+	var count int64
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&model.Comment{}).
+		Where("author_ip = ? AND gmt_create >= ? AND deleted = ?", ip, since, false).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计 IP 近期评论数失败: %w", err)
+	}
+	return count, nil
+}
+
+// UpdateCommentSpamLabel 更新评论已用于训练分类器的标签
+// 参数：
+//   - c: Echo 上下文
+//   - id: 评论 ID
+//   - label: 训练标签
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateCommentSpamLabel(c echo.Context, id int64, label string) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&model.Comment{}).
+		Where("id = ? AND deleted = ?", id, false).
+		Update("spam_label", label).Error; err != nil {
+		return fmt.Errorf("更新评论训练标签失败: %w", err)
+	}
+	return nil
+}
+
+// CreateCommentRevision 保存评论编辑前的历史版本
+// 参数：
+//   - c: Echo 上下文
+//   - revision: 历史版本
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateCommentRevision(c echo.Context, revision *model.CommentRevision) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Create(revision).Error; err != nil {
+		return fmt.Errorf("保存评论历史版本失败: %w", err)
+	}
+	return nil
+}
+
+// GetCommentRevisionsByCommentID 获取评论的全部历史版本，最新的在前
+// 参数：
+//   - c: Echo 上下文
+//   - commentID: 评论 ID
+//
+// 返回值：
+//   - []*model.CommentRevision: 历史版本列表
+//   - error: 操作过程中的错误
+func GetCommentRevisionsByCommentID(c echo.Context, commentID int64) ([]*model.CommentRevision, error) {
+	var revisions []*model.CommentRevision
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("comment_id = ? AND deleted = ?", commentID, false).
+		Order("gmt_create DESC, id DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("获取评论历史版本失败: %w", err)
+	}
+	return revisions, nil
+}
+
+// GetCommentsByImportIDs 按导入来源与来源评论 ID 批量获取已导入的评论（含已删除）
+// 参数：
+//   - c: Echo 上下文
+//   - source: 导入来源
+//   - importIDs: 来源评论 ID 列表
+//
+// 返回值：
+//   - map[string]*model.Comment: 来源评论 ID 到评论的映射
+//   - error: 操作过程中的错误
+func GetCommentsByImportIDs(c echo.Context, source string, importIDs []string) (map[string]*model.Comment, error) {
+	comments := make(map[string]*model.Comment, len(importIDs))
+	if len(importIDs) == 0 {
+		return comments, nil
+	}
+
+	db := utils.GetDBFromContext(c)
+	const batchSize = 500
+	for start := 0; start < len(importIDs); start += batchSize {
+		end := min(start+batchSize, len(importIDs))
+
+		var batch []*model.Comment
+		if err := db.Where("import_source = ? AND import_id IN ?", source, importIDs[start:end]).
+			Find(&batch).Error; err != nil {
+			return nil, fmt.Errorf("获取已导入评论失败: %w", err)
+		}
+		for _, com := range batch {
+			if com.ImportID != nil {
+				comments[*com.ImportID] = com
+			}
+		}
+	}
+	return comments, nil
+}
+
+// UpdateCommentGmtCreate 修改评论的创建时间，用于导入时保留原始时间
+// 参数：
+//   - c: Echo 上下文
+//   - id: 评论 ID
+//   - gmtCreate: 创建时间
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateCommentGmtCreate(c echo.Context, id int64, gmtCreate int64) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&model.Comment{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"gmt_create": gmtCreate, "gmt_modified": gmtCreate}).Error; err != nil {
+		return fmt.Errorf("修改评论创建时间失败: %w", err)
+	}
+	return nil
+}
+
+// GetCommentsByAuthor 获取账户发表的评论，包括以该账户邮箱发表的游客评论，按发表时间排列
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户 ID
+//   - email: 账户邮箱
+//
+// 返回值：
+//   - []*model.Comment: 评论列表
+//   - error: 操作过程中的错误
+func GetCommentsByAuthor(c echo.Context, accountID int64, email string) ([]*model.Comment, error) {
+	var comments []*model.Comment
+	db := utils.GetDBFromContext(c)
+	if err := db.Scopes(commentsByAuthor(accountID, email)).
+		Where("deleted = ?", false).
+		Order("gmt_create ASC, id ASC").
+		Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("获取账户评论失败: %w", err)
+	}
+	return comments, nil
+}
+
+// AnonymizeCommentsByAuthor 匿名化账户发表的评论（含已删除的评论与以该账户邮箱发表的游客评论），
+// 保留评论内容，清除评论者身份信息、编辑凭证及历史版本中的编辑者信息
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户 ID
+//   - email: 账户邮箱
+//   - authorName: 匿名化后展示的评论者昵称
+//
+// 返回值：
+//   - int64: 匿名化的评论数量
+//   - error: 操作过程中的错误
+func AnonymizeCommentsByAuthor(c echo.Context, accountID int64, email, authorName string) (int64, error) {
+	db := utils.GetDBFromContext(c)
+
+	if err := db.Model(&model.CommentRevision{}).
+		Where("editor_account_id = ?", accountID).
+		Updates(map[string]interface{}{"editor_account_id": 0, "editor_ip": ""}).Error; err != nil {
+		return 0, fmt.Errorf("匿名化评论历史版本失败: %w", err)
+	}
+
+	result := db.Model(&model.Comment{}).
+		Scopes(commentsByAuthor(accountID, email)).
+		Updates(map[string]interface{}{
+			"account_id":      0,
+			"author_name":     authorName,
+			"author_email":    "",
+			"author_website":  "",
+			"author_ip":       "",
+			"edit_token_hash": "",
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("匿名化评论失败: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// commentsByAuthor 生成按评论者筛选评论的查询条件
+// 参数：
+//   - accountID: 账户 ID
+//   - email: 账户邮箱，按不区分大小写匹配
+//
+// 返回值：
+//   - func(*gorm.DB) *gorm.DB: GORM 查询作用域
+func commentsByAuthor(accountID int64, email string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(account_id = ? OR LOWER(author_email) = LOWER(?))", accountID, email)
+	}
+}
@@ -0,0 +1,55 @@
+// Package mapper 提供数据模型与数据库交互的映射层，处理邮件通知偏好相关数据操作
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	model "jank.com/jank_blog/internal/model/notification"
+	"jank.com/jank_blog/internal/utils"
+)
+
+// GetEmailPreferenceByEmail 根据邮箱查询邮件通知偏好
+// 参数：
+//   - c: Echo 上下文
+//   - email: 小写的邮箱地址
+//
+// 返回值：
+//   - *model.EmailPreference: 邮件通知偏好，不存在时返回 nil
+//   - error: 操作过程中的错误
+func GetEmailPreferenceByEmail(c echo.Context, email string) (*model.EmailPreference, error) {
+	var prefs []*model.EmailPreference
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("email = ? AND deleted = ?", email, false).Limit(1).Find(&prefs).Error; err != nil {
+		return nil, fmt.Errorf("获取邮件通知偏好失败: %w", err)
+	}
+	if len(prefs) == 0 {
+		return nil, nil
+	}
+	return prefs[0], nil
+}
+
+// SaveEmailPreference 保存邮件通知偏好，ID 为 0 时创建
+// 参数：
+//   - c: Echo 上下文
+//   - pref: 邮件通知偏好
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func SaveEmailPreference(c echo.Context, pref *model.EmailPreference) error {
+	db := utils.GetDBFromContext(c)
+	if pref.ID == 0 {
+		if err := db.Create(pref).Error; err != nil {
+			return fmt.Errorf("创建邮件通知偏好失败: %w", err)
+		}
+		return nil
+	}
+
+	if err := db.Save(pref).Error; err != nil {
+		return fmt.Errorf("更新邮件通知偏好失败: %w", err)
+	}
+	return nil
+}
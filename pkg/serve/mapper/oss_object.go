@@ -0,0 +1,65 @@
+// Package mapper 提供数据模型与数据库交互的映射层，处理对象存储文件记录的数据操作
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	model "jank.com/jank_blog/internal/model/oss"
+	"jank.com/jank_blog/internal/utils"
+)
+
+// CreateOssObject 保存上传文件记录
+// 参数：
+//   - c: Echo 上下文
+//   - object: 上传文件记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateOssObject(c echo.Context, object *model.OssObject) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Create(object).Error; err != nil {
+		return fmt.Errorf("保存上传文件记录失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteOssObject 逻辑删除上传文件记录
+// 参数：
+//   - c: Echo 上下文
+//   - bucketName: 存储桶名称
+//   - objectName: 对象名称
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteOssObject(c echo.Context, bucketName, objectName string) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&model.OssObject{}).
+		Where("bucket_name = ? AND object_name = ? AND deleted = ?", bucketName, objectName, false).
+		Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("删除上传文件记录失败: %w", err)
+	}
+	return nil
+}
+
+// GetOssObjectsByAccountID 获取账户上传且未删除的文件记录，按上传时间排列
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 上传者账户ID
+//
+// 返回值：
+//   - []*model.OssObject: 上传文件记录列表
+//   - error: 操作过程中的错误
+func GetOssObjectsByAccountID(c echo.Context, accountID int64) ([]*model.OssObject, error) {
+	var objects []*model.OssObject
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("account_id = ? AND deleted = ?", accountID, false).
+		Order("gmt_create ASC").
+		Find(&objects).Error; err != nil {
+		return nil, fmt.Errorf("获取上传文件记录失败: %w", err)
+	}
+	return objects, nil
+}
@@ -74,6 +74,39 @@ func GetAllPostsWithPaging(c echo.Context, page, pageSize int) ([]*post.Post, in
 	return posts, total, nil
 }
 
+// GetAllPosts 获取全部未删除的文章
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - []*post.Post: 文章列表
+//   - error: 操作过程中的错误
+func GetAllPosts(c echo.Context) ([]*post.Post, error) {
+	var posts []*post.Post
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("deleted = ?", false).Order("id ASC").Find(&posts).Error; err != nil {
+		return nil, fmt.Errorf("获取文章列表失败: %w", err)
+	}
+	return posts, nil
+}
+
+// GetPostsByAuthorID 获取账户撰写的全部文章
+// 参数：
+//   - c: Echo 上下文
+//   - authorID: 作者账户 ID
+//
+// 返回值：
+//   - []*post.Post: 文章列表
+//   - error: 操作过程中的错误
+func GetPostsByAuthorID(c echo.Context, authorID int64) ([]*post.Post, error) {
+	var posts []*post.Post
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("author_id = ? AND deleted = ?", authorID, false).Order("id ASC").Find(&posts).Error; err != nil {
+		return nil, fmt.Errorf("获取账户文章列表失败: %w", err)
+	}
+	return posts, nil
+}
+
 // UpdateOnePostByID 更新文章
 // 参数：
 //   - c: Echo 上下文
@@ -92,6 +125,30 @@ func UpdateOnePostByID(c echo.Context, postID int64, newPost *post.Post) error {
 	return nil
 }
 
+// UpdatePostCommentSettings 更新文章评论设置，布尔字段可能被置为零值，因此按列更新
+// 参数：
+//   - c: Echo 上下文
+//   - postID: 文章 ID
+//   - pos: 包含评论设置的文章信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdatePostCommentSettings(c echo.Context, postID int64, pos *post.Post) error {
+	db := utils.GetDBFromContext(c)
+	result := db.Model(&post.Post{}).
+		Where("id = ? AND deleted = ?", postID, false).
+		Updates(map[string]interface{}{
+			"comments_disabled":         pos.CommentsDisabled,
+			"comments_close_after_days": pos.CommentsCloseAfterDays,
+			"comments_login_required":   pos.CommentsLoginRequired,
+		})
+
+	if result.Error != nil {
+		return fmt.Errorf("更新文章评论设置失败: %w", result.Error)
+	}
+	return nil
+}
+
 // DeleteOnePostByID 根据 ID 进行软删除操作
 // 参数：
 //   - c: Echo 上下文
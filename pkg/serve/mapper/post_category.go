@@ -99,19 +99,123 @@ func DeletePostCategory(c echo.Context, postID int64) error {
 	return nil
 }
 
-// DeletePostCategoryByCategoryID 根据类目ID删除文章-类目关联
+// DeletePostCategoriesByCategoryIDs 根据类目 ID 列表批量删除文章-类目关联
+// 参数：
+//   - c: Echo 上下文
+//   - categoryIDs: 类目 ID 列表
+//
+// 返回值：
+//   - int64: 受影响的文章数量
+//   - error: 操作过程中的错误
+func DeletePostCategoriesByCategoryIDs(c echo.Context, categoryIDs []int64) (int64, error) {
+	if len(categoryIDs) == 0 {
+		return 0, nil
+	}
+
+	db := utils.GetDBFromContext(c)
+	result := db.Model(&association.PostCategory{}).
+		Where("category_id IN ? AND deleted = ?", categoryIDs, false).
+		Update("deleted", true)
+	if result.Error != nil {
+		return 0, fmt.Errorf("根据类目ID删除文章-类目关联失败: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ReassignPostCategories 将类目列表下的文章批量转移到目标类目
+// 参数：
+//   - c: Echo 上下文
+//   - categoryIDs: 原类目 ID 列表
+//   - targetID: 目标类目 ID
+//
+// 返回值：
+//   - int64: 受影响的文章数量
+//   - error: 操作过程中的错误
+func ReassignPostCategories(c echo.Context, categoryIDs []int64, targetID int64) (int64, error) {
+	if len(categoryIDs) == 0 {
+		return 0, nil
+	}
+
+	db := utils.GetDBFromContext(c)
+	result := db.Model(&association.PostCategory{}).
+		Where("category_id IN ? AND deleted = ?", categoryIDs, false).
+		Update("category_id", targetID)
+	if result.Error != nil {
+		return 0, fmt.Errorf("转移文章-类目关联失败: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// CountPostsGroupByCategory 按类目批量统计文章数量
+// 参数：
+//   - c: Echo 上下文
+//   - visibleOnly: 是否仅统计可见文章
+//
+// 返回值：
+//   - map[int64]int64: 类目 ID 到文章数量的映射
+//   - error: 操作过程中的错误
+func CountPostsGroupByCategory(c echo.Context, visibleOnly bool) (map[int64]int64, error) {
+	var rows []struct {
+		CategoryID int64
+		PostCount  int64
+	}
+
+	db := utils.GetDBFromContext(c)
+	query := db.Model(&association.PostCategory{}).
+		Select("post_categories.category_id AS category_id, COUNT(DISTINCT post_categories.post_id) AS post_count").
+		Joins("JOIN posts ON posts.id = post_categories.post_id AND posts.deleted = ?", false).
+		Where("post_categories.deleted = ?", false)
+	if visibleOnly {
+		query = query.Where("posts.visibility = ?", true)
+	}
+
+	if err := query.Group("post_categories.category_id").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("统计类目文章数量失败: %w", err)
+	}
+
+	counts := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.PostCount
+	}
+	return counts, nil
+}
+
+// GetDanglingPostCategories 获取指向已删除或不存在类目的文章-类目关联
 // 参数：
 //   - c: Echo 上下文
-//   - categoryID: 类目 ID
 //
 // 返回值：
+//   - []*association.PostCategory: 失效的文章-类目关联列表
 //   - error: 操作过程中的错误
-func DeletePostCategoryByCategoryID(c echo.Context, categoryID int64) error {
+func GetDanglingPostCategories(c echo.Context) ([]*association.PostCategory, error) {
+	var postCategories []*association.PostCategory
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&association.PostCategory{}).
+		Joins("LEFT JOIN categories ON categories.id = post_categories.category_id AND categories.deleted = ?", false).
+		Where("post_categories.deleted = ? AND categories.id IS NULL", false).
+		Find(&postCategories).Error; err != nil {
+		return nil, fmt.Errorf("获取失效的文章-类目关联失败: %w", err)
+	}
+	return postCategories, nil
+}
+
+// DeletePostCategoriesByIDs 根据关联 ID 列表批量删除文章-类目关联
+// 参数：
+//   - c: Echo 上下文
+//   - ids: 文章-类目关联 ID 列表
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeletePostCategoriesByIDs(c echo.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
 	db := utils.GetDBFromContext(c)
 	if err := db.Model(&association.PostCategory{}).
-		Where("category_id = ? AND deleted = ?", categoryID, false).
+		Where("id IN ? AND deleted = ?", ids, false).
 		Update("deleted", true).Error; err != nil {
-		return fmt.Errorf("根据类目ID删除文章-类目关联失败: %w", err)
+		return fmt.Errorf("批量删除文章-类目关联失败: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,79 @@
+// Package mapper 提供数据模型与数据库交互的映射层，处理垃圾评论分类器词元统计的数据操作
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package mapper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	model "jank.com/jank_blog/internal/model/spam"
+	"jank.com/jank_blog/internal/utils"
+)
+
+// GetSpamTokensByTokens 批量查询词元统计
+// 参数：
+//   - c: Echo 上下文
+//   - tokens: 词元列表
+//
+// 返回值：
+//   - map[string]*model.SpamToken: 词元到统计信息的映射，未出现过的词元不在其中
+//   - error: 操作过程中的错误
+func GetSpamTokensByTokens(c echo.Context, tokens []string) (map[string]*model.SpamToken, error) {
+	result := make(map[string]*model.SpamToken, len(tokens))
+	if len(tokens) == 0 {
+		return result, nil
+	}
+
+	var spamTokens []*model.SpamToken
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("token IN ? AND deleted = ?", tokens, false).Find(&spamTokens).Error; err != nil {
+		return nil, fmt.Errorf("获取词元统计失败: %w", err)
+	}
+
+	for _, spamToken := range spamTokens {
+		result[spamToken.Token] = spamToken
+	}
+	return result, nil
+}
+
+// IncrSpamTokens 批量累加词元统计，不存在的词元会被创建
+// 参数：
+//   - c: Echo 上下文
+//   - tokens: 词元列表
+//   - spamDelta: 垃圾样本计数增量，可为负数
+//   - hamDelta: 正常样本计数增量，可为负数
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func IncrSpamTokens(c echo.Context, tokens []string, spamDelta, hamDelta int64) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	spamTokens := make([]*model.SpamToken, 0, len(tokens))
+	for _, token := range tokens {
+		spamTokens = append(spamTokens, &model.SpamToken{
+			Token:     token,
+			SpamCount: max(spamDelta, 0),
+			HamCount:  max(hamDelta, 0),
+		})
+	}
+
+	db := utils.GetDBFromContext(c)
+	if err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "token"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"spam_count":   gorm.Expr("spam_count + ?", spamDelta),
+			"ham_count":    gorm.Expr("ham_count + ?", hamDelta),
+			"gmt_modified": time.Now().Unix(),
+		}),
+	}).Create(&spamTokens).Error; err != nil {
+		return fmt.Errorf("累加词元统计失败: %w", err)
+	}
+	return nil
+}
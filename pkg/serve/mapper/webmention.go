@@ -0,0 +1,115 @@
+// Package mapper 提供数据模型与数据库交互的映射层，处理 Webmention 相关数据操作
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	model "jank.com/jank_blog/internal/model/webmention"
+	"jank.com/jank_blog/internal/utils"
+)
+
+// CreateWebmention 保存 Webmention 到数据库
+// 参数：
+//   - c: Echo 上下文
+//   - mention: Webmention 信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func CreateWebmention(c echo.Context, mention *model.Webmention) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Create(mention).Error; err != nil {
+		return fmt.Errorf("创建 Webmention 失败: %w", err)
+	}
+	return nil
+}
+
+// GetWebmentionByID 根据 ID 查询 Webmention
+// 参数：
+//   - c: Echo 上下文
+//   - id: Webmention ID
+//
+// 返回值：
+//   - *model.Webmention: Webmention 信息
+//   - error: 操作过程中的错误
+func GetWebmentionByID(c echo.Context, id int64) (*model.Webmention, error) {
+	var mention model.Webmention
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("id = ? AND deleted = ?", id, false).First(&mention).Error; err != nil {
+		return nil, fmt.Errorf("获取 Webmention 失败: %w", err)
+	}
+	return &mention, nil
+}
+
+// GetWebmentionBySourceAndTarget 根据来源与目标地址查询 Webmention（包含已删除记录，便于重新提及时复用）
+// 参数：
+//   - c: Echo 上下文
+//   - source: 来源地址
+//   - target: 目标地址
+//
+// 返回值：
+//   - *model.Webmention: Webmention 信息
+//   - error: 操作过程中的错误
+func GetWebmentionBySourceAndTarget(c echo.Context, source, target string) (*model.Webmention, error) {
+	var mention model.Webmention
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("source = ? AND target = ?", source, target).First(&mention).Error; err != nil {
+		return nil, fmt.Errorf("获取 Webmention 失败: %w", err)
+	}
+	return &mention, nil
+}
+
+// GetVerifiedWebmentionsByPostID 获取文章下所有校验通过的 Webmention
+// 参数：
+//   - c: Echo 上下文
+//   - postID: 文章 ID
+//
+// 返回值：
+//   - []*model.Webmention: Webmention 列表
+//   - error: 操作过程中的错误
+func GetVerifiedWebmentionsByPostID(c echo.Context, postID int64) ([]*model.Webmention, error) {
+	var mentions []*model.Webmention
+	db := utils.GetDBFromContext(c)
+	if err := db.Where("post_id = ? AND status = ? AND deleted = ?", postID, model.WEBMENTION_STATUS_VERIFIED, false).
+		Order("id ASC").
+		Find(&mentions).Error; err != nil {
+		return nil, fmt.Errorf("获取文章 Webmention 失败: %w", err)
+	}
+	return mentions, nil
+}
+
+// GetPendingWebmentionIDs 获取所有待校验的 Webmention ID
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - []int64: Webmention ID 列表
+//   - error: 操作过程中的错误
+func GetPendingWebmentionIDs(c echo.Context) ([]int64, error) {
+	var ids []int64
+	db := utils.GetDBFromContext(c)
+	if err := db.Model(&model.Webmention{}).
+		Where("status = ? AND deleted = ?", model.WEBMENTION_STATUS_PENDING, false).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("获取待校验 Webmention 失败: %w", err)
+	}
+	return ids, nil
+}
+
+// UpdateWebmention 更新 Webmention
+// 参数：
+//   - c: Echo 上下文
+//   - mention: Webmention 信息
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UpdateWebmention(c echo.Context, mention *model.Webmention) error {
+	db := utils.GetDBFromContext(c)
+	if err := db.Save(mention).Error; err != nil {
+		return fmt.Errorf("更新 Webmention 失败: %w", err)
+	}
+	return nil
+}
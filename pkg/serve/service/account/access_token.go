@@ -0,0 +1,162 @@
+// Package service 提供业务逻辑处理，处理个人访问令牌相关业务
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// MAX_ACCESS_TOKENS_PER_ACCOUNT 单个账户可同时持有的个人访问令牌数量上限
+const MAX_ACCESS_TOKENS_PER_ACCOUNT = 20
+
+// CreateAccessToken 为当前账户创建个人访问令牌，授权范围不能超出账户角色拥有的权限
+// 参数：
+//   - c: Echo 上下文
+//   - req: 创建个人访问令牌请求
+//
+// 返回值：
+//   - *account.CreateAccessTokenVO: 令牌明文与令牌信息，明文仅返回这一次
+//   - error: 操作过程中的错误
+func CreateAccessToken(c echo.Context, req *dto.CreateAccessTokenRequest) (*account.CreateAccessTokenVO, error) {
+	accountID, _ := utils.GetAuthAccountID(c)
+	role := utils.GetAuthAccountRole(c)
+
+	scopes := make([]string, 0, len(req.Scopes))
+	seen := make(map[string]bool, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if seen[scope] {
+			continue
+		}
+		seen[scope] = true
+
+		if !utils.IsValidScope(scope) {
+			return nil, bizErr.New(bizErr.BAD_REQUEST, fmt.Sprintf("不支持的授权范围「%s」", scope))
+		}
+		if !utils.RoleCanGrantScope(role, scope) {
+			return nil, bizErr.New(bizErr.FORBIDDEN, fmt.Sprintf("当前账户角色无法授予授权范围「%s」", scope))
+		}
+		scopes = append(scopes, scope)
+	}
+
+	count, err := mapper.CountAccessTokensByAccountID(c, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("统计「%d」用户个人访问令牌失败: %v", accountID, err)
+		return nil, fmt.Errorf("统计「%d」用户个人访问令牌失败: %w", accountID, err)
+	}
+	if count >= MAX_ACCESS_TOKENS_PER_ACCOUNT {
+		return nil, bizErr.New(bizErr.BAD_REQUEST, fmt.Sprintf("个人访问令牌数量已达上限 %d 个，请先撤销不再使用的令牌", MAX_ACCESS_TOKENS_PER_ACCOUNT))
+	}
+
+	plainToken, tokenHash, tokenHint, err := utils.GenerateAccessToken()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成个人访问令牌失败: %v", err)
+		return nil, err
+	}
+
+	token := &model.AccessToken{
+		AccountID: accountID,
+		Name:      req.Name,
+		TokenHash: tokenHash,
+		TokenHint: tokenHint,
+		Scopes:    utils.JoinScopes(scopes),
+	}
+	if req.ExpiresInDays > 0 {
+		token.ExpiresAt = time.Now().AddDate(0, 0, req.ExpiresInDays).Unix()
+	}
+
+	if err := mapper.CreateAccessToken(c, token); err != nil {
+		utils.BizLogger(c).Errorf("保存「%d」用户个人访问令牌失败: %v", accountID, err)
+		return nil, fmt.Errorf("保存「%d」用户个人访问令牌失败: %w", accountID, err)
+	}
+
+	return &account.CreateAccessTokenVO{
+		Token:       plainToken,
+		AccessToken: toAccessTokenVO(token),
+	}, nil
+}
+
+// ListAccessTokens 获取当前账户未撤销的个人访问令牌，包括已过期的令牌
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - []*account.AccessTokenVO: 个人访问令牌列表
+//   - error: 操作过程中的错误
+func ListAccessTokens(c echo.Context) ([]*account.AccessTokenVO, error) {
+	accountID, _ := utils.GetAuthAccountID(c)
+	tokens, err := mapper.ListAccessTokensByAccountID(c, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取「%d」用户个人访问令牌失败: %v", accountID, err)
+		return nil, fmt.Errorf("获取「%d」用户个人访问令牌失败: %w", accountID, err)
+	}
+
+	tokenVOs := make([]*account.AccessTokenVO, 0, len(tokens))
+	for _, token := range tokens {
+		tokenVOs = append(tokenVOs, toAccessTokenVO(token))
+	}
+	return tokenVOs, nil
+}
+
+// RevokeAccessToken 撤销当前账户的个人访问令牌，撤销后立即失效
+// 参数：
+//   - c: Echo 上下文
+//   - req: 撤销个人访问令牌请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RevokeAccessToken(c echo.Context, req *dto.RevokeAccessTokenRequest) error {
+	accountID, _ := utils.GetAuthAccountID(c)
+	revoked, err := mapper.RevokeAccessToken(c, accountID, req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("撤销「%d」用户个人访问令牌「%d」失败: %v", accountID, req.ID, err)
+		return fmt.Errorf("撤销「%d」用户个人访问令牌「%d」失败: %w", accountID, req.ID, err)
+	}
+	if !revoked {
+		utils.BizLogger(c).Warnf("「%d」用户个人访问令牌「%d」不存在", accountID, req.ID)
+		return bizErr.New(bizErr.BAD_REQUEST, "个人访问令牌不存在或已撤销")
+	}
+	return nil
+}
+
+// toAccessTokenVO 将个人访问令牌转换为视图对象
+// 参数：
+//   - token: 个人访问令牌
+//
+// 返回值：
+//   - *account.AccessTokenVO: 个人访问令牌视图对象
+func toAccessTokenVO(token *model.AccessToken) *account.AccessTokenVO {
+	return &account.AccessTokenVO{
+		ID:         strconv.FormatInt(token.ID, 10),
+		Name:       token.Name,
+		TokenHint:  token.TokenHint,
+		Scopes:     utils.SplitScopes(token.Scopes),
+		ExpiresAt:  formatDisplayTime(token.ExpiresAt),
+		LastUsedAt: formatDisplayTime(token.LastUsedAt),
+		GmtCreate:  formatDisplayTime(token.GmtCreate),
+	}
+}
+
+// formatDisplayTime 格式化时间戳，0 表示未设置，返回空字符串
+// 参数：
+//   - timestamp: 秒级时间戳
+//
+// 返回值：
+//   - string: 格式化后的时间
+func formatDisplayTime(timestamp int64) string {
+	if timestamp == 0 {
+		return ""
+	}
+	return time.Unix(timestamp, 0).Format(DISPLAY_TIME_LAYOUT)
+}
@@ -4,19 +4,24 @@
 package service
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 
+	bizErr "jank.com/jank_blog/internal/error"
 	"jank.com/jank_blog/internal/global"
 	model "jank.com/jank_blog/internal/model/account"
+	auditModel "jank.com/jank_blog/internal/model/audit"
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/account/dto"
 	"jank.com/jank_blog/pkg/serve/mapper"
+	audit "jank.com/jank_blog/pkg/serve/service/audit"
 	"jank.com/jank_blog/pkg/vo/account"
 )
 
@@ -26,24 +31,48 @@ var (
 	logoutLock        sync.Mutex // 用户登出锁，保护并发用户登出操作
 )
 
+var (
+	dummyPasswordHash     []byte    // 账户不存在或未设置密码时参与比对的哈希，使各种失败情况的耗时一致
+	dummyPasswordHashOnce sync.Once // 保证哈希只生成一次
+)
+
 const (
-	USER_CACHE             = "USER_CACHE"
-	USER_CACHE_EXPIRE_TIME = time.Hour * 2 // Access Token 有效期
+	PASSWORD_RESET_ATTEMPT_SCOPE  = "password_reset" // 找回密码验证码校验限流场景
+	PASSWORD_RESET_ATTEMPT_LIMIT  = 5                // 找回密码验证码在有效期内允许的校验次数
+	PASSWORD_RESET_ATTEMPT_WINDOW = 15 * time.Minute // 找回密码验证码校验次数统计窗口，与验证码有效期一致
 )
 
-// GetAccount 获取用户信息逻辑
+// GetAccount 获取用户信息逻辑，默认返回当前登录用户的账户，查询其他账户须具备账户管理权限
 // 参数：
 //   - c: Echo 上下文
 //   - req: 获取账户请求
 //
 // 返回值：
 //   - *account.GetAccountVO: 用户账户视图对象
-//   - error: 操作过程中的错误
+//   - error: 操作过程中的错误，无权查询其他账户时返回业务错误
 func GetAccount(c echo.Context, req *dto.GetAccountRequest) (*account.GetAccountVO, error) {
-	userInfo, err := mapper.GetAccountByEmail(c, req.Email)
+	accountID, ok := utils.GetAuthAccountID(c)
+	if !ok {
+		return nil, bizErr.New(bizErr.UNAUTHORIZED)
+	}
+
+	userInfo, err := mapper.GetAccountByAccountID(c, accountID)
 	if err != nil {
-		utils.BizLogger(c).Errorf("「%s」用户不存在", req.Email)
-		return nil, fmt.Errorf("「%s」用户不存在", req.Email)
+		utils.BizLogger(c).Errorf("「%d」用户不存在: %v", accountID, err)
+		return nil, fmt.Errorf("「%d」用户不存在: %w", accountID, err)
+	}
+
+	if req.Email != "" && !strings.EqualFold(req.Email, userInfo.Email) {
+		if !utils.HasPermission(c, utils.PERMISSION_ACCOUNT_MANAGE) {
+			utils.BizLogger(c).Warnf("「%d」用户无权查询「%s」的账户信息", accountID, req.Email)
+			return nil, bizErr.New(bizErr.FORBIDDEN, "无权查询其他用户的账户信息")
+		}
+
+		userInfo, err = mapper.GetAccountByEmail(c, req.Email)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%s」用户不存在", req.Email)
+			return nil, bizErr.New(bizErr.BAD_REQUEST, fmt.Sprintf("「%s」用户不存在", req.Email))
+		}
 	}
 
 	vo, err := utils.MapModelToVO(userInfo, &account.GetAccountVO{})
@@ -76,11 +105,6 @@ func RegisterAcc(c echo.Context, req *dto.RegisterRequest) (*account.RegisterAcc
 			return fmt.Errorf("获取用户总数失败: %w", err)
 		}
 
-		if totalAccounts > 0 {
-			utils.BizLogger(c).Error("系统限制: 当前为单用户独立部署版本，已达到账户数量上限 (1/1)")
-			return fmt.Errorf("系统限制: 当前为单用户独立部署版本，已达到账户数量上限 (1/1)")
-		}
-
 		existingUser, _ := mapper.GetAccountByEmail(c, req.Email)
 		if existingUser != nil {
 			utils.BizLogger(c).Errorf("「%s」邮箱已被注册", req.Email)
@@ -98,6 +122,12 @@ func RegisterAcc(c echo.Context, req *dto.RegisterRequest) (*account.RegisterAcc
 			Password: string(hashedPassword),
 			Nickname: req.Nickname,
 			Phone:    req.Phone,
+			Role:     utils.ROLE_COMMENTER,
+		}
+
+		// 首个注册的账户为管理员，其余账户默认为评论者，由管理员分配角色
+		if totalAccounts == 0 {
+			acc.Role = utils.ROLE_ADMIN
 		}
 
 		if err := mapper.CreateAccount(c, acc); err != nil {
@@ -122,7 +152,7 @@ func RegisterAcc(c echo.Context, req *dto.RegisterRequest) (*account.RegisterAcc
 	return registerVO, nil
 }
 
-// LoginAcc 登录用户逻辑
+// LoginAcc 登录用户逻辑，启用双因素认证的账户返回登录挑战令牌
 // 参数：
 //   - c: Echo 上下文
 //   - req: 登录请求
@@ -131,30 +161,76 @@ func RegisterAcc(c echo.Context, req *dto.RegisterRequest) (*account.RegisterAcc
 //   - *account.LoginVO: 登录成功后的令牌视图对象
 //   - error: 操作过程中的错误
 func LoginAcc(c echo.Context, req *dto.LoginRequest) (*account.LoginVO, error) {
+	if err := checkLoginLock(c, req.Email); err != nil {
+		return nil, err
+	}
+
+	// 邮箱不存在与密码错误返回相同的错误并同样计入失败次数，避免探测已注册邮箱
+	loginFailErr := bizErr.New(bizErr.BAD_REQUEST, "邮箱或密码错误")
 	acc, err := mapper.GetAccountByEmail(c, req.Email)
 	if err != nil {
-		utils.BizLogger(c).Errorf("「%s」用户不存在: %v", req.Email, err)
-		return nil, fmt.Errorf("「%s」用户不存在: %w", req.Email, err)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BizLogger(c).Errorf("获取「%s」用户失败: %v", req.Email, err)
+			return nil, fmt.Errorf("获取「%s」用户失败: %w", req.Email, err)
+		}
+		utils.BizLogger(c).Warnf("「%s」用户不存在", req.Email)
+		compareDummyPassword(req.Password)
+		recordLoginAudit(c, auditModel.AUDIT_ACTION_LOGIN_FAILED, req.Email, 0, "账户不存在")
+		return nil, recordLoginFailure(c, req.Email, nil, loginFailErr)
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(req.Password))
+	if acc.Password == "" {
+		// 第三方登录创建的账户未设置密码，同样执行一次比对
+		compareDummyPassword(req.Password)
+		err = bcrypt.ErrMismatchedHashAndPassword
+	} else {
+		err = bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(req.Password))
+	}
 	if err != nil {
-		utils.BizLogger(c).Errorf("「%s」用户密码输入错误: %v", acc.Email, err)
-		return nil, fmt.Errorf("「%s」用户密码输入错误: %w", acc.Email, err)
+		utils.BizLogger(c).Warnf("「%s」用户密码输入错误: %v", acc.Email, err)
+		recordLoginAudit(c, auditModel.AUDIT_ACTION_LOGIN_FAILED, req.Email, acc.ID, "密码错误")
+		return nil, recordLoginFailure(c, req.Email, acc, loginFailErr)
 	}
 
-	accessTokenString, refreshTokenString, err := utils.GenerateJWT(acc.ID)
-	if err != nil {
-		utils.BizLogger(c).Errorf("access_token 生成失败: %v", err)
-		return nil, fmt.Errorf("access_token 生成失败: %w", err)
+	// 启用双因素认证的账户先签发登录挑战令牌，校验 TOTP 验证码后再签发访问令牌
+	if acc.TotpEnabled {
+		return createLoginChallenge(c, acc)
 	}
 
-	cacheKey := fmt.Sprintf("%s:%d", USER_CACHE, acc.ID)
+	resetLoginFailures(c, req.Email)
+	return issueLoginTokens(c, acc, "密码登录")
+}
+
+// compareDummyPassword 以固定哈希比对密码并丢弃结果，使账户不存在时的耗时与密码错误一致，避免通过响应时间探测已注册邮箱
+// 参数：
+//   - password: 请求中的密码
+func compareDummyPassword(password string) {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("jank-blog-dummy-password"), bcrypt.DefaultCost)
+	})
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+}
+
+// issueLoginTokens 为账户建立新的登录会话，并签发携带会话ID的访问令牌与刷新令牌，不影响其他设备的会话
+// 参数：
+//   - c: Echo 上下文
+//   - acc: 账户
+//   - method: 登录方式，记录在登录审计日志中
+//
+// 返回值：
+//   - *account.LoginVO: 登录成功后的令牌视图对象
+//   - error: 操作过程中的错误
+func issueLoginTokens(c echo.Context, acc *model.Account, method string) (*account.LoginVO, error) {
+	sessionID, err := utils.CreateSession(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("登录时创建会话失败: %v", err)
+		return nil, fmt.Errorf("登录时创建会话失败: %w", err)
+	}
 
-	err = global.RedisClient.Set(context.Background(), cacheKey, accessTokenString, USER_CACHE_EXPIRE_TIME).Err()
+	accessTokenString, refreshTokenString, err := utils.IssueSessionTokens(c, acc.ID, sessionID)
 	if err != nil {
-		utils.BizLogger(c).Errorf("登录时设置缓存失败: %v", err)
-		return nil, fmt.Errorf("登录时设置缓存失败: %w", err)
+		utils.BizLogger(c).Errorf("access_token 生成失败: %v", err)
+		return nil, fmt.Errorf("access_token 生成失败: %w", err)
 	}
 
 	token := &account.LoginVO{
@@ -168,10 +244,12 @@ func LoginAcc(c echo.Context, req *dto.LoginRequest) (*account.LoginVO, error) {
 		return nil, fmt.Errorf("用户登陆时映射 VO 失败: %v", err)
 	}
 
+	recordLoginAudit(c, auditModel.AUDIT_ACTION_LOGIN, acc.Email, acc.ID, method)
+
 	return vo.(*account.LoginVO), nil
 }
 
-// LogoutAcc 处理用户登出逻辑
+// LogoutAcc 处理用户登出逻辑，仅注销当前设备的会话
 // 参数：
 //   - c: Echo 上下文
 //
@@ -181,17 +259,10 @@ func LogoutAcc(c echo.Context) error {
 	logoutLock.Lock()
 	defer logoutLock.Unlock()
 
-	accountID, err := utils.ParseAccountFromJWT(c.Request().Header.Get("Authorization"))
-	if err != nil {
-		utils.BizLogger(c).Errorf("access_token 解析失败: %v", err)
-		return fmt.Errorf("access_token 解析失败: %w", err)
-	}
-
-	cacheKey := fmt.Sprintf("%s:%d", USER_CACHE, accountID)
-	err = global.RedisClient.Del(c.Request().Context(), cacheKey).Err()
-	if err != nil {
-		utils.BizLogger(c).Errorf("删除 Redis 缓存失败: %v", err)
-		return fmt.Errorf("删除 Redis 缓存失败: %w", err)
+	accountID, _ := utils.GetAuthAccountID(c)
+	if _, err := utils.RevokeSession(c, accountID, utils.GetAuthSessionID(c)); err != nil {
+		utils.BizLogger(c).Errorf("注销「%d」用户当前会话失败: %v", accountID, err)
+		return fmt.Errorf("注销「%d」用户当前会话失败: %w", accountID, err)
 	}
 
 	return nil
@@ -208,13 +279,15 @@ func ResetPassword(c echo.Context, req *dto.ResetPwdRequest) error {
 	passwordResetLock.Lock()
 	defer passwordResetLock.Unlock()
 
-	return utils.RunDBTransaction(c, func(tx error) error {
+	var accountID int64
+	err := utils.RunDBTransaction(c, func(tx error) error {
 		if req.NewPassword != req.AgainNewPassword {
 			utils.BizLogger(c).Errorf("两次密码输入不一致")
 			return fmt.Errorf("两次密码输入不一致")
 		}
 
-		accountID, err := utils.ParseAccountFromJWT(c.Request().Header.Get("Authorization"))
+		var err error
+		accountID, err = utils.ParseAccountFromJWT(c.Request().Header.Get("Authorization"))
 		if err != nil {
 			utils.BizLogger(c).Errorf("access_token 解析失败: %v", err)
 			return fmt.Errorf("access_token 解析失败: %w", err)
@@ -222,8 +295,14 @@ func ResetPassword(c echo.Context, req *dto.ResetPwdRequest) error {
 
 		acc, err := mapper.GetAccountByAccountID(c, accountID)
 		if err != nil {
-			utils.BizLogger(c).Errorf("「%s」用户不存在: %v", acc.Email, err)
-			return fmt.Errorf("「%s」用户不存在: %w", acc.Email, err)
+			utils.BizLogger(c).Errorf("「%d」用户不存在: %v", accountID, err)
+			return fmt.Errorf("「%d」用户不存在: %w", accountID, err)
+		}
+
+		// 邮箱验证码须发往当前账户的邮箱，仅凭访问令牌不能修改密码
+		if acc.Email != req.Email {
+			utils.BizLogger(c).Warnf("「%d」用户重置密码时提供的邮箱「%s」与账户不符", accountID, req.Email)
+			return bizErr.New(bizErr.BAD_REQUEST, "邮箱与当前账户不符")
 		}
 
 		newPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
@@ -240,6 +319,108 @@ func ResetPassword(c echo.Context, req *dto.ResetPwdRequest) error {
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_PASSWORD_CHANGE,
+		ActorID:    accountID,
+		TargetType: auditModel.AUDIT_TARGET_ACCOUNT,
+		TargetID:   req.Email,
+	})
+	return nil
+}
+
+// ForgotPassword 未登录时凭找回密码验证码设置新密码，并注销该账户的全部会话、撤销全部个人访问令牌
+// 参数：
+//   - c: Echo 上下文
+//   - req: 找回密码请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func ForgotPassword(c echo.Context, req *dto.ForgotPasswordRequest) error {
+	if req.NewPassword != req.AgainNewPassword {
+		utils.BizLogger(c).Errorf("两次密码输入不一致")
+		return bizErr.New(bizErr.BAD_REQUEST, "两次密码输入不一致")
+	}
+
+	// 限制验证码的校验次数，超出后作废验证码，防止穷举
+	allowed, err := utils.AllowRequest(c, PASSWORD_RESET_ATTEMPT_SCOPE, req.Email, PASSWORD_RESET_ATTEMPT_LIMIT, PASSWORD_RESET_ATTEMPT_WINDOW)
+	if err != nil {
+		utils.BizLogger(c).Errorf("找回密码限流失败: %v", err)
+		return fmt.Errorf("找回密码限流失败: %w", err)
+	}
+	if !allowed {
+		utils.BizLogger(c).Warnf("「%s」找回密码验证码校验次数过多", req.Email)
+		global.RedisClient.Del(c.Request().Context(), utils.PASSWORD_RESET_CODE_CACHE_KEY_PREFIX+req.Email)
+		return bizErr.New(bizErr.TOO_MANY_REQUESTS, "验证码校验次数过多，请重新获取验证码")
+	}
+
+	if !utils.VerifyPasswordResetCode(c, req.ResetCode, req.Email) {
+		return bizErr.New(bizErr.BAD_REQUEST, "找回密码验证码错误或已过期")
+	}
+
+	passwordResetLock.Lock()
+	defer passwordResetLock.Unlock()
+
+	var accountID int64
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		acc, err := mapper.GetAccountByEmail(c, req.Email)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%s」用户不存在: %v", req.Email, err)
+			return bizErr.New(bizErr.BAD_REQUEST, "找回密码验证码错误或已过期")
+		}
+
+		newPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%s」用户密码加密失败: %v", acc.Email, err)
+			return fmt.Errorf("「%s」用户密码加密失败: %w", acc.Email, err)
+		}
+		acc.Password = string(newPassword)
+
+		if err := mapper.UpdateAccount(c, acc); err != nil {
+			utils.BizLogger(c).Errorf("「%s」用户密码修改失败: %v", acc.Email, err)
+			return fmt.Errorf("「%s」用户密码修改失败: %w", acc.Email, err)
+		}
+
+		// 账户可能已被盗用，一并撤销泄露期间可能被创建的个人访问令牌
+		if err := mapper.RevokeAccessTokensByAccountID(c, acc.ID); err != nil {
+			utils.BizLogger(c).Errorf("撤销「%s」用户个人访问令牌失败: %v", acc.Email, err)
+			return err
+		}
+
+		accountID = acc.ID
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_PASSWORD_RESET,
+		ActorID:    accountID,
+		ActorEmail: req.Email,
+		TargetType: auditModel.AUDIT_TARGET_ACCOUNT,
+		TargetID:   req.Email,
+		Detail:     "通过邮箱验证码找回密码，已注销全部会话并撤销全部个人访问令牌",
+	})
+	return revokeAccountSessions(c, accountID)
+}
+
+// revokeAccountSessions 注销账户的全部会话，已签发的令牌随即失效
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func revokeAccountSessions(c echo.Context, accountID int64) error {
+	if err := utils.RevokeAllSessions(c, accountID); err != nil {
+		utils.BizLogger(c).Errorf("注销「%d」用户会话失败: %v", accountID, err)
+		return fmt.Errorf("注销「%d」用户会话失败: %w", accountID, err)
+	}
+	return nil
 }
 
 // UpdateAccount 更新账户信息逻辑
@@ -291,3 +472,128 @@ func UpdateAccount(c echo.Context, req *dto.UpdateAccountRequest) (*account.Upda
 
 	return updateVO, nil
 }
+
+// ChangeEmail 凭分别发送至当前邮箱与新邮箱的验证码修改账户邮箱，任一验证码错误时两个验证码均作废
+// 参数：
+//   - c: Echo 上下文
+//   - req: 修改邮箱请求
+//
+// 返回值：
+//   - *account.GetAccountVO: 修改后的账户视图对象
+//   - error: 操作过程中的错误
+func ChangeEmail(c echo.Context, req *dto.ChangeEmailRequest) (*account.GetAccountVO, error) {
+	acc, err := getAuthAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	oldEmail := acc.Email
+
+	// 验证码校验通过即失效，任一验证码错误时作废另一个，避免逐个穷举
+	oldIdentity := utils.EmailChangeCodeIdentity(acc.ID, oldEmail)
+	newIdentity := utils.EmailChangeCodeIdentity(acc.ID, req.NewEmail)
+	if !utils.VerifyEmailChangeCode(c, req.OldEmailCode, oldIdentity) ||
+		!utils.VerifyEmailChangeCode(c, req.NewEmailCode, newIdentity) {
+		utils.BizLogger(c).Warnf("「%s」用户修改邮箱验证码校验失败", oldEmail)
+		global.RedisClient.Del(c.Request().Context(),
+			utils.EMAIL_CHANGE_CODE_CACHE_KEY_PREFIX+oldIdentity,
+			utils.EMAIL_CHANGE_CODE_CACHE_KEY_PREFIX+newIdentity)
+		return nil, bizErr.New(bizErr.BAD_REQUEST, "验证码错误或已过期，请重新获取验证码")
+	}
+
+	var accountVO *account.GetAccountVO
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		if existing, _ := mapper.GetAccountByEmail(c, req.NewEmail); existing != nil {
+			utils.BizLogger(c).Warnf("「%s」用户修改邮箱时，「%s」邮箱已被注册", oldEmail, req.NewEmail)
+			return bizErr.New(bizErr.BAD_REQUEST, "该邮箱已被注册")
+		}
+
+		acc.Email = req.NewEmail
+		if err := mapper.UpdateAccount(c, acc); err != nil {
+			utils.BizLogger(c).Errorf("修改「%s」用户邮箱失败: %v", oldEmail, err)
+			return fmt.Errorf("修改「%s」用户邮箱失败: %w", oldEmail, err)
+		}
+
+		vo, err := utils.MapModelToVO(acc, &account.GetAccountVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("修改「%s」用户邮箱时映射 VO 失败: %v", oldEmail, err)
+			return fmt.Errorf("修改「%s」用户邮箱时映射 VO 失败: %w", oldEmail, err)
+		}
+
+		accountVO = vo.(*account.GetAccountVO)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_EMAIL_CHANGE,
+		TargetType: auditModel.AUDIT_TARGET_ACCOUNT,
+		TargetID:   req.NewEmail,
+		Before:     map[string]interface{}{"email": oldEmail},
+		After:      map[string]interface{}{"email": req.NewEmail},
+	})
+	return accountVO, nil
+}
+
+// UpdateAccountRole 修改账户角色，系统中至少保留一名管理员
+// 参数：
+//   - c: Echo 上下文
+//   - req: 修改账户角色请求
+//
+// 返回值：
+//   - *account.GetAccountVO: 修改后的账户视图对象
+//   - error: 操作过程中的错误
+func UpdateAccountRole(c echo.Context, req *dto.UpdateAccountRoleRequest) (*account.GetAccountVO, error) {
+	var accountVO *account.GetAccountVO
+	var oldRole string
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		acc, err := mapper.GetAccountByEmail(c, req.Email)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%s」用户不存在: %v", req.Email, err)
+			return bizErr.New(bizErr.BAD_REQUEST, fmt.Sprintf("「%s」用户不存在", req.Email))
+		}
+
+		if acc.Role == utils.ROLE_ADMIN && req.Role != utils.ROLE_ADMIN {
+			admins, err := mapper.CountAccountsByRole(c, utils.ROLE_ADMIN)
+			if err != nil {
+				utils.BizLogger(c).Errorf("统计管理员数量失败: %v", err)
+				return fmt.Errorf("统计管理员数量失败: %w", err)
+			}
+			if admins <= 1 {
+				utils.BizLogger(c).Warnf("「%s」为最后一名管理员，不能修改其角色", req.Email)
+				return bizErr.New(bizErr.BAD_REQUEST, "系统中至少需要保留一名管理员")
+			}
+		}
+
+		oldRole = acc.Role
+		acc.Role = req.Role
+		if err := mapper.UpdateAccount(c, acc); err != nil {
+			utils.BizLogger(c).Errorf("修改「%s」用户角色失败: %v", req.Email, err)
+			return fmt.Errorf("修改「%s」用户角色失败: %w", req.Email, err)
+		}
+
+		vo, err := utils.MapModelToVO(acc, &account.GetAccountVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("修改「%s」用户角色时映射 VO 失败: %v", req.Email, err)
+			return fmt.Errorf("修改「%s」用户角色时映射 VO 失败: %w", req.Email, err)
+		}
+
+		accountVO = vo.(*account.GetAccountVO)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_ROLE_UPDATE,
+		TargetType: auditModel.AUDIT_TARGET_ACCOUNT,
+		TargetID:   req.Email,
+		Before:     map[string]interface{}{"role": oldRole},
+		After:      map[string]interface{}{"role": req.Role},
+	})
+	return accountVO, nil
+}
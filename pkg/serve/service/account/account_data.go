@@ -0,0 +1,324 @@
+// Package service 提供业务逻辑处理，处理账户数据导出与账户注销相关业务
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/minio/minio-go/v7"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/global"
+	auditModel "jank.com/jank_blog/internal/model/audit"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	audit "jank.com/jank_blog/pkg/serve/service/audit"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+const (
+	ACCOUNT_EXPORT_SCOPE  = "account_export" // 账户数据导出限流场景
+	ACCOUNT_EXPORT_LIMIT  = 3                // 统计窗口内允许的导出次数
+	ACCOUNT_EXPORT_WINDOW = time.Hour        // 账户数据导出次数统计窗口
+
+	DELETED_ACCOUNT_NICKNAME     = "已注销用户"           // 注销后账户与评论展示的昵称
+	DELETED_ACCOUNT_EMAIL_DOMAIN = "deleted.invalid" // 注销后账户占位邮箱的域名
+)
+
+// ExportAccountData 收集当前账户的个人资料、撰写的文章、发表的评论与上传的文件记录
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - *account.AccountExportVO: 账户数据，由 WriteAccountExportArchive 打包为压缩包
+//   - error: 操作过程中的错误
+func ExportAccountData(c echo.Context) (*account.AccountExportVO, error) {
+	acc, err := getAuthAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := utils.AllowRequest(c, ACCOUNT_EXPORT_SCOPE, strconv.FormatInt(acc.ID, 10), ACCOUNT_EXPORT_LIMIT, ACCOUNT_EXPORT_WINDOW)
+	if err != nil {
+		utils.BizLogger(c).Errorf("账户数据导出限流失败: %v", err)
+		return nil, fmt.Errorf("账户数据导出限流失败: %w", err)
+	}
+	if !allowed {
+		utils.BizLogger(c).Warnf("「%s」用户导出账户数据过于频繁", acc.Email)
+		return nil, bizErr.New(bizErr.TOO_MANY_REQUESTS, "导出过于频繁，请稍后再试")
+	}
+
+	profileVO, err := utils.MapModelToVO(acc, &account.ExportProfileVO{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("导出「%s」用户资料时映射 VO 失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("导出「%s」用户资料时映射 VO 失败: %w", acc.Email, err)
+	}
+	exportVO := &account.AccountExportVO{
+		Profile:    profileVO.(*account.ExportProfileVO),
+		Posts:      []*account.ExportPostVO{},
+		Comments:   []*account.ExportCommentVO{},
+		Files:      []*account.ExportFileVO{},
+		ExportedAt: time.Now().Format(DISPLAY_TIME_LAYOUT),
+	}
+
+	posts, err := mapper.GetPostsByAuthorID(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("导出「%s」用户文章失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("导出「%s」用户文章失败: %w", acc.Email, err)
+	}
+	for _, pos := range posts {
+		vo, err := utils.MapModelToVO(pos, &account.ExportPostVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("导出文章时映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("导出文章时映射 VO 失败: %w", err)
+		}
+		exportVO.Posts = append(exportVO.Posts, vo.(*account.ExportPostVO))
+	}
+
+	comments, err := mapper.GetCommentsByAuthor(c, acc.ID, acc.Email)
+	if err != nil {
+		utils.BizLogger(c).Errorf("导出「%s」用户评论失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("导出「%s」用户评论失败: %w", acc.Email, err)
+	}
+	for _, com := range comments {
+		vo, err := utils.MapModelToVO(com, &account.ExportCommentVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("导出评论时映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("导出评论时映射 VO 失败: %w", err)
+		}
+		exportVO.Comments = append(exportVO.Comments, vo.(*account.ExportCommentVO))
+	}
+
+	objects, err := mapper.GetOssObjectsByAccountID(c, acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("导出「%s」用户上传文件失败: %v", acc.Email, err)
+		return nil, fmt.Errorf("导出「%s」用户上传文件失败: %w", acc.Email, err)
+	}
+	for _, object := range objects {
+		vo, err := utils.MapModelToVO(object, &account.ExportFileVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("导出上传文件时映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("导出上传文件时映射 VO 失败: %w", err)
+		}
+		exportVO.Files = append(exportVO.Files, vo.(*account.ExportFileVO))
+	}
+
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_ACCOUNT_EXPORT,
+		TargetType: auditModel.AUDIT_TARGET_ACCOUNT,
+		TargetID:   acc.Email,
+		After: map[string]interface{}{
+			"post_count":    len(exportVO.Posts),
+			"comment_count": len(exportVO.Comments),
+			"file_count":    len(exportVO.Files),
+		},
+	})
+	return exportVO, nil
+}
+
+// WriteAccountExportArchive 将账户数据打包为 zip 写入 w：profile.json、posts.json、comments.json、files.json 记录结构化数据，
+// posts/ 下为各文章的 Markdown 正文，files/ 下为从对象存储读取的上传文件；未能读取的文件只在 files.json 中列出
+// 参数：
+//   - c: Echo 上下文
+//   - exportVO: ExportAccountData 收集的账户数据
+//   - w: 压缩包写入目标
+//
+// 返回值：
+//   - error: 操作过程中的错误，压缩包可能已部分写入
+func WriteAccountExportArchive(c echo.Context, exportVO *account.AccountExportVO, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	for _, file := range exportVO.Files {
+		archivePath := path.Join("files", file.BucketName, strings.TrimPrefix(path.Clean("/"+file.ObjectName), "/"))
+		included, err := writeArchiveObject(c, zw, archivePath, file.BucketName, file.ObjectName)
+		if err != nil {
+			utils.BizLogger(c).Errorf("打包上传文件「%s/%s」失败: %v", file.BucketName, file.ObjectName, err)
+			return fmt.Errorf("打包上传文件「%s/%s」失败: %w", file.BucketName, file.ObjectName, err)
+		}
+		if included {
+			file.ArchivePath = archivePath
+		}
+	}
+
+	for _, pos := range exportVO.Posts {
+		entry, err := zw.Create(path.Join("posts", pos.ID+".md"))
+		if err != nil {
+			utils.BizLogger(c).Errorf("打包文章「%s」失败: %v", pos.ID, err)
+			return fmt.Errorf("打包文章「%s」失败: %w", pos.ID, err)
+		}
+		if _, err := io.WriteString(entry, pos.ContentMarkdown); err != nil {
+			utils.BizLogger(c).Errorf("打包文章「%s」失败: %v", pos.ID, err)
+			return fmt.Errorf("打包文章「%s」失败: %w", pos.ID, err)
+		}
+	}
+
+	entries := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", exportVO.Profile},
+		{"posts.json", exportVO.Posts},
+		{"comments.json", exportVO.Comments},
+		{"files.json", exportVO.Files},
+	}
+	for _, e := range entries {
+		data, err := json.MarshalIndent(e.data, "", "  ")
+		if err != nil {
+			utils.BizLogger(c).Errorf("序列化「%s」失败: %v", e.name, err)
+			return fmt.Errorf("序列化「%s」失败: %w", e.name, err)
+		}
+		entry, err := zw.Create(e.name)
+		if err != nil {
+			utils.BizLogger(c).Errorf("打包「%s」失败: %v", e.name, err)
+			return fmt.Errorf("打包「%s」失败: %w", e.name, err)
+		}
+		if _, err := entry.Write(data); err != nil {
+			utils.BizLogger(c).Errorf("打包「%s」失败: %v", e.name, err)
+			return fmt.Errorf("打包「%s」失败: %w", e.name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		utils.BizLogger(c).Errorf("写入账户数据压缩包失败: %v", err)
+		return fmt.Errorf("写入账户数据压缩包失败: %w", err)
+	}
+	return nil
+}
+
+// writeArchiveObject 从对象存储读取文件写入压缩包
+// 参数：
+//   - c: Echo 上下文
+//   - zw: 压缩包
+//   - archivePath: 文件在压缩包中的路径
+//   - bucketName: 存储桶名称
+//   - objectName: 对象名称
+//
+// 返回值：
+//   - bool: 文件是否已写入，对象存储不可用或文件已不存在时为 false
+//   - error: 写入过程中的错误
+func writeArchiveObject(c echo.Context, zw *zip.Writer, archivePath, bucketName, objectName string) (bool, error) {
+	if global.MinioClient == nil {
+		utils.BizLogger(c).Warnf("对象存储不可用，跳过文件「%s/%s」", bucketName, objectName)
+		return false, nil
+	}
+
+	ctx := context.Background()
+	if _, err := global.MinioClient.StatObject(ctx, bucketName, objectName, minio.StatObjectOptions{}); err != nil {
+		utils.BizLogger(c).Warnf("文件「%s/%s」不存在，跳过: %v", bucketName, objectName, err)
+		return false, nil
+	}
+
+	object, err := global.MinioClient.GetObject(ctx, bucketName, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return false, err
+	}
+	defer object.Close()
+
+	entry, err := zw.Create(archivePath)
+	if err != nil {
+		return false, err
+	}
+	if _, err := io.Copy(entry, object); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteAccount 凭当前密码或身份验证令牌（已启用双因素认证时还需 TOTP 验证码）注销当前账户：
+// 匿名化账户发表的评论，撤销个人访问令牌、恢复码与第三方账号绑定，清除账户个人信息并注销全部会话；
+// 账户撰写的文章与上传的文件保留，系统中的最后一名管理员不能注销
+// 参数：
+//   - c: Echo 上下文
+//   - req: 注销账户请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DeleteAccount(c echo.Context, req *dto.DeleteAccountRequest) error {
+	var accountID int64
+	var email, role string
+	var anonymized int64
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		acc, err := getAuthAccount(c)
+		if err != nil {
+			return err
+		}
+
+		if err := verifyAccountCredential(c, acc, req.Password, req.ReauthToken); err != nil {
+			return err
+		}
+		if acc.TotpEnabled {
+			if req.Code == "" {
+				return bizErr.New(bizErr.BAD_REQUEST, "已启用双因素认证，请提供验证码")
+			}
+			if err := verifySecondFactor(c, acc, req.Code, ""); err != nil {
+				return err
+			}
+		}
+
+		if acc.Role == utils.ROLE_ADMIN {
+			admins, err := mapper.CountAccountsByRole(c, utils.ROLE_ADMIN)
+			if err != nil {
+				utils.BizLogger(c).Errorf("统计管理员数量失败: %v", err)
+				return fmt.Errorf("统计管理员数量失败: %w", err)
+			}
+			if admins <= 1 {
+				utils.BizLogger(c).Warnf("「%s」为最后一名管理员，不能注销", acc.Email)
+				return bizErr.New(bizErr.BAD_REQUEST, "系统中至少需要保留一名管理员")
+			}
+		}
+
+		anonymized, err = mapper.AnonymizeCommentsByAuthor(c, acc.ID, acc.Email, DELETED_ACCOUNT_NICKNAME)
+		if err != nil {
+			utils.BizLogger(c).Errorf("匿名化「%s」用户评论失败: %v", acc.Email, err)
+			return fmt.Errorf("匿名化「%s」用户评论失败: %w", acc.Email, err)
+		}
+
+		if err := mapper.RevokeAccessTokensByAccountID(c, acc.ID); err != nil {
+			utils.BizLogger(c).Errorf("撤销「%s」用户个人访问令牌失败: %v", acc.Email, err)
+			return err
+		}
+		if err := mapper.DeleteRecoveryCodesByAccountID(c, acc.ID); err != nil {
+			utils.BizLogger(c).Errorf("作废「%s」用户恢复码失败: %v", acc.Email, err)
+			return err
+		}
+		if err := mapper.DeleteExternalIdentitiesByAccountID(c, acc.ID); err != nil {
+			utils.BizLogger(c).Errorf("解除「%s」用户第三方账号绑定失败: %v", acc.Email, err)
+			return err
+		}
+
+		placeholderEmail := fmt.Sprintf("deleted_%d@%s", acc.ID, DELETED_ACCOUNT_EMAIL_DOMAIN)
+		if err := mapper.AnonymizeAccount(c, acc.ID, placeholderEmail, DELETED_ACCOUNT_NICKNAME); err != nil {
+			utils.BizLogger(c).Errorf("注销「%s」用户失败: %v", acc.Email, err)
+			return fmt.Errorf("注销「%s」用户失败: %w", acc.Email, err)
+		}
+
+		accountID, email, role = acc.ID, acc.Email, acc.Role
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	utils.BizLogger(c).Infof("「%s」用户已注销账户，匿名化评论 %d 条", email, anonymized)
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_ACCOUNT_DELETE,
+		ActorID:    accountID,
+		TargetType: auditModel.AUDIT_TARGET_ACCOUNT,
+		TargetID:   email,
+		Before:     map[string]interface{}{"role": role},
+		After:      map[string]interface{}{"deleted": true, "anonymized_comment_count": anonymized},
+	})
+	return revokeAccountSessions(c, accountID)
+}
@@ -0,0 +1,59 @@
+// Package service 提供业务逻辑处理，处理 JWKS 公钥发布相关业务
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// GetJWKS 获取用于校验令牌签名的公钥集合，仅包含 RS256 与 EdDSA 密钥，HS256 对称密钥不会公开
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - *account.JWKSVO: 公钥集合
+//   - error: 操作过程中的错误
+func GetJWKS(c echo.Context) (*account.JWKSVO, error) {
+	publicKeys, err := utils.JWTPublicKeys()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载 JWT 公钥失败: %v", err)
+		return nil, fmt.Errorf("加载 JWT 公钥失败: %w", err)
+	}
+
+	jwks := &account.JWKSVO{Keys: make([]account.JWKVO, 0, len(publicKeys))}
+	for _, publicKey := range publicKeys {
+		jwk := account.JWKVO{
+			Kid: publicKey.Kid,
+			Use: "sig",
+			Alg: publicKey.Algorithm,
+		}
+
+		switch key := publicKey.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(key)
+		default:
+			utils.BizLogger(c).Warnf("JWT 密钥「%s」的公钥类型不受支持，已跳过", publicKey.Kid)
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks, nil
+}
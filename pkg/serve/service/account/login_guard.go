@@ -0,0 +1,221 @@
+// Package service 提供业务逻辑处理，处理登录防暴力破解相关业务
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/account"
+	auditModel "jank.com/jank_blog/internal/model/audit"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	audit "jank.com/jank_blog/pkg/serve/service/audit"
+)
+
+// checkLoginLock 校验账户与客户端 IP 是否处于登录锁定期
+// 参数：
+//   - c: Echo 上下文
+//   - email: 登录邮箱
+//
+// 返回值：
+//   - error: 处于锁定期时返回 ACCOUNT_LOCKED 业务错误
+func checkLoginLock(c echo.Context, email string) error {
+	lock, err := utils.CheckLoginLock(c, email)
+	if err != nil {
+		utils.BizLogger(c).Errorf("读取「%s」登录锁定状态失败: %v", email, err)
+		return fmt.Errorf("读取「%s」登录锁定状态失败: %w", email, err)
+	}
+	if lock == nil {
+		return nil
+	}
+
+	utils.BizLogger(c).Warnf("「%s」登录请求被拒绝，%s 维度锁定剩余 %s", email, lock.Kind, lock.RetryAfter.Round(time.Second))
+	recordLoginAudit(c, auditModel.AUDIT_ACTION_LOGIN_FAILED, email, 0, "登录已被临时锁定")
+	return loginLockedErr(c, lock)
+}
+
+// recordLoginFailure 记录一次登录失败，触发锁定时返回锁定错误，账户首次被锁定时邮件通知账户所有者
+// 参数：
+//   - c: Echo 上下文
+//   - email: 登录邮箱
+//   - acc: 登录邮箱对应的账户，账户不存在时为 nil
+//   - failErr: 未触发锁定时返回的错误
+//
+// 返回值：
+//   - error: 触发锁定时为 ACCOUNT_LOCKED 业务错误，否则为 failErr
+func recordLoginFailure(c echo.Context, email string, acc *model.Account, failErr error) error {
+	failure, err := utils.RecordLoginFailure(c, email)
+	if err != nil {
+		utils.BizLogger(c).Errorf("记录「%s」登录失败次数失败: %v", email, err)
+		return failErr
+	}
+	if failure.Lock == nil {
+		return failErr
+	}
+
+	utils.BizLogger(c).Warnf("「%s」登录失败 %d 次，%s 维度锁定 %s", email, failure.Failures, failure.Lock.Kind, failure.Lock.RetryAfter)
+	if failure.AccountLocked && acc != nil {
+		notifyAccountLocked(acc.Email, c.RealIP(), failure.Lock.RetryAfter)
+	}
+
+	targetType, targetID := auditModel.AUDIT_TARGET_ACCOUNT, email
+	if failure.Lock.Kind == utils.LOGIN_GUARD_IP {
+		targetType, targetID = auditModel.AUDIT_TARGET_IP, c.RealIP()
+	}
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_ACCOUNT_LOCK,
+		ActorEmail: email,
+		TargetType: targetType,
+		TargetID:   targetID,
+		After: map[string]interface{}{
+			"failures":     failure.Failures,
+			"lock_seconds": int(failure.Lock.RetryAfter.Seconds()),
+		},
+	})
+	return loginLockedErr(c, failure.Lock)
+}
+
+// resetLoginFailures 登录成功后清除账户的失败计数，清除失败不影响本次登录
+// 参数：
+//   - c: Echo 上下文
+//   - email: 登录邮箱
+func resetLoginFailures(c echo.Context, email string) {
+	if err := utils.ResetLoginFailures(c, email); err != nil {
+		utils.BizLogger(c).Errorf("清除「%s」登录失败次数失败: %v", email, err)
+	}
+}
+
+// UnlockAccount 管理员解除账户的登录锁定，可同时解除指定客户端 IP 的锁定
+// 参数：
+//   - c: Echo 上下文
+//   - req: 解除登录锁定请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UnlockAccount(c echo.Context, req *dto.UnlockAccountRequest) error {
+	operatorID, _ := utils.GetAuthAccountID(c)
+
+	locked, err := utils.UnlockLogin(c, utils.LOGIN_GUARD_ACCOUNT, req.Email)
+	if err != nil {
+		utils.BizLogger(c).Errorf("解除「%s」用户登录锁定失败: %v", req.Email, err)
+		return fmt.Errorf("解除「%s」用户登录锁定失败: %w", req.Email, err)
+	}
+	utils.BizLogger(c).Infof("「%d」用户解除了「%s」用户的登录锁定，解除前锁定状态: %t", operatorID, req.Email, locked)
+	recordUnlockAudit(c, auditModel.AUDIT_TARGET_ACCOUNT, req.Email, locked)
+
+	if req.IP == "" {
+		return nil
+	}
+
+	locked, err = utils.UnlockLogin(c, utils.LOGIN_GUARD_IP, req.IP)
+	if err != nil {
+		utils.BizLogger(c).Errorf("解除 IP「%s」登录锁定失败: %v", req.IP, err)
+		return fmt.Errorf("解除 IP「%s」登录锁定失败: %w", req.IP, err)
+	}
+	utils.BizLogger(c).Infof("「%d」用户解除了 IP「%s」的登录锁定，解除前锁定状态: %t", operatorID, req.IP, locked)
+	recordUnlockAudit(c, auditModel.AUDIT_TARGET_IP, req.IP, locked)
+	return nil
+}
+
+// recordLoginAudit 记录登录成功或失败的审计日志
+// 参数：
+//   - c: Echo 上下文
+//   - action: 审计事件
+//   - email: 登录邮箱
+//   - accountID: 登录邮箱对应的账户ID，账户不存在时为 0
+//   - detail: 登录方式或失败原因
+func recordLoginAudit(c echo.Context, action, email string, accountID int64, detail string) {
+	audit.Record(c, &audit.Event{
+		Action:     action,
+		ActorID:    accountID,
+		ActorEmail: email,
+		TargetType: auditModel.AUDIT_TARGET_ACCOUNT,
+		TargetID:   email,
+		Detail:     detail,
+	})
+}
+
+// recordUnlockAudit 记录管理员解除登录锁定的审计日志
+// 参数：
+//   - c: Echo 上下文
+//   - targetType: 审计对象类型，账户或 IP
+//   - targetID: 账户邮箱或 IP
+//   - locked: 解除前是否处于锁定期
+func recordUnlockAudit(c echo.Context, targetType, targetID string, locked bool) {
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_ACCOUNT_UNLOCK,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     map[string]interface{}{"locked": locked},
+		After:      map[string]interface{}{"locked": false},
+	})
+}
+
+// loginLockedErr 生成登录锁定业务错误，并通过 Retry-After 响应头告知客户端剩余锁定时长
+// 参数：
+//   - c: Echo 上下文
+//   - lock: 锁定状态
+//
+// 返回值：
+//   - error: ACCOUNT_LOCKED 业务错误
+func loginLockedErr(c echo.Context, lock *utils.LoginLock) error {
+	seconds := int(math.Ceil(lock.RetryAfter.Seconds()))
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+	return bizErr.New(bizErr.ACCOUNT_LOCKED, fmt.Sprintf("登录失败次数过多，请于 %s后再试", formatLockDuration(seconds)))
+}
+
+// formatLockDuration 将锁定秒数格式化为便于阅读的时长
+// 参数：
+//   - seconds: 锁定秒数
+//
+// 返回值：
+//   - string: 格式化后的时长
+func formatLockDuration(seconds int) string {
+	if seconds < 60 {
+		return fmt.Sprintf("%d 秒", seconds)
+	}
+	return fmt.Sprintf("%d 分钟", (seconds+59)/60)
+}
+
+// notifyAccountLocked 异步邮件通知账户所有者账户已被临时锁定
+// 参数：
+//   - email: 账户邮箱
+//   - ip: 触发锁定的客户端 IP
+//   - lockFor: 锁定时长
+func notifyAccountLocked(email, ip string, lockFor time.Duration) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				global.SysLog.Errorf("发送「%s」账户锁定通知时发生 panic: %v", email, r)
+			}
+		}()
+
+		var body strings.Builder
+		body.WriteString("您好，\n\n")
+		body.WriteString("您的 Jank Blog 账户因连续多次登录失败，已被临时锁定。\n\n")
+		fmt.Fprintf(&body, "触发时间：%s\n", time.Now().Format(DISPLAY_TIME_LAYOUT))
+		fmt.Fprintf(&body, "来源 IP：%s\n", ip)
+		fmt.Fprintf(&body, "锁定时长：%s，锁定结束后若继续登录失败，锁定时长将逐次翻倍\n\n", formatLockDuration(int(lockFor.Seconds())))
+		body.WriteString("如果这不是您本人的操作，说明有人正在尝试登录您的账户，建议您尽快通过「找回密码」修改密码，并开启双因素认证。\n")
+		body.WriteString("如需提前解除锁定，请联系站点管理员。\n")
+
+		msg := &utils.EmailMessage{
+			Subject:  "【Jank Blog】账户已被临时锁定",
+			TextBody: body.String(),
+		}
+		if _, err := utils.SendEmailMessage(msg, []string{email}); err != nil {
+			global.SysLog.Errorf("发送「%s」账户锁定通知失败: %v", email, err)
+			return
+		}
+		global.SysLog.Infof("已发送「%s」账户锁定通知", email)
+	}()
+}
@@ -0,0 +1,613 @@
+// Package service 提供业务逻辑处理，处理第三方登录与账号绑定相关业务
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/account"
+	auditModel "jank.com/jank_blog/internal/model/audit"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	audit "jank.com/jank_blog/pkg/serve/service/audit"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+const (
+	OAUTH_AUTHORIZE_SCOPE  = "oauth_authorize" // 发起第三方授权限流场景
+	OAUTH_AUTHORIZE_LIMIT  = 20                // 单个 IP 在统计窗口内允许发起授权的次数
+	OAUTH_AUTHORIZE_WINDOW = 10 * time.Minute  // 发起第三方授权次数统计窗口
+
+	OAUTH_REAUTH_CACHE_PREFIX = "OAUTH:REAUTH:" // 重新验证身份令牌缓存前缀，键为令牌摘要
+	OAUTH_REAUTH_EXPIRE_TIME  = 5 * time.Minute // 重新验证身份令牌有效期
+	oauthReauthTokenBytes     = 32              // 重新验证身份令牌随机字节数
+
+	oauthNicknameMaxLength = 64  // 自动创建账户时昵称的最大字符数，与账户昵称字段长度一致
+	oauthEmailMaxLength    = 64  // 自动创建账户时邮箱的最大字符数，与账户邮箱字段长度一致
+	oauthAvatarMaxLength   = 255 // 自动创建账户时头像地址的最大字符数，超出时不保存头像
+)
+
+var oauthIdentityLock sync.Mutex // 第三方账号绑定锁，保护并发绑定同一第三方账号的操作
+
+// ListOAuthProviders 获取已启用的第三方登录提供方
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - []*account.OAuthProviderVO: 提供方列表，未启用第三方登录时为空列表
+//   - error: 操作过程中的错误
+func ListOAuthProviders(c echo.Context) ([]*account.OAuthProviderVO, error) {
+	providers, err := utils.GetOAuthProviders()
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取第三方登录提供方失败: %v", err)
+		return nil, fmt.Errorf("获取第三方登录提供方失败: %w", err)
+	}
+
+	providerVOs := make([]*account.OAuthProviderVO, 0, len(providers))
+	for _, provider := range providers {
+		displayName := provider.DisplayName
+		if displayName == "" {
+			displayName = provider.Name
+		}
+		providerVOs = append(providerVOs, &account.OAuthProviderVO{
+			Name:        provider.Name,
+			Type:        provider.Type,
+			DisplayName: displayName,
+		})
+	}
+	return providerVOs, nil
+}
+
+// GetOAuthAuthorizationURL 获取第三方登录的授权地址
+// 参数：
+//   - c: Echo 上下文
+//   - req: 获取第三方授权地址请求
+//
+// 返回值：
+//   - *account.OAuthAuthorizationVO: 授权地址
+//   - error: 操作过程中的错误
+func GetOAuthAuthorizationURL(c echo.Context, req *dto.GetOAuthAuthorizationRequest) (*account.OAuthAuthorizationVO, error) {
+	return createOAuthAuthorization(c, req.Provider, utils.OAUTH_MODE_LOGIN, 0)
+}
+
+// GetOAuthLinkURL 获取为当前账户绑定第三方账号的授权地址
+// 参数：
+//   - c: Echo 上下文
+//   - req: 获取第三方授权地址请求
+//
+// 返回值：
+//   - *account.OAuthAuthorizationVO: 授权地址
+//   - error: 操作过程中的错误
+func GetOAuthLinkURL(c echo.Context, req *dto.GetOAuthAuthorizationRequest) (*account.OAuthAuthorizationVO, error) {
+	accountID, _ := utils.GetAuthAccountID(c)
+	return createOAuthAuthorization(c, req.Provider, utils.OAUTH_MODE_LINK, accountID)
+}
+
+// OAuthLogin 第三方登录回调，校验授权结果后登录已绑定的账户，未绑定时以提供方已验证的邮箱自动创建评论者账户
+// 参数：
+//   - c: Echo 上下文
+//   - req: 第三方授权回调请求
+//
+// 返回值：
+//   - *account.LoginVO: 登录成功后的令牌视图对象，已启用双因素认证的账户返回登录挑战令牌
+//   - error: 操作过程中的错误
+func OAuthLogin(c echo.Context, req *dto.OAuthCallbackRequest) (*account.LoginVO, error) {
+	oauthState, identity, err := exchangeOAuthCallback(c, req, utils.OAUTH_MODE_LOGIN)
+	if err != nil {
+		return nil, err
+	}
+
+	oauthIdentityLock.Lock()
+	defer oauthIdentityLock.Unlock()
+	registerLock.Lock()
+	defer registerLock.Unlock()
+
+	var acc *model.Account
+	var created bool
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		existing, err := mapper.GetExternalIdentity(c, oauthState.Provider, identity.Subject)
+		if err == nil {
+			acc, err = mapper.GetAccountByAccountID(c, existing.AccountID)
+			if err != nil {
+				utils.BizLogger(c).Errorf("「%s」第三方账号「%s」绑定的「%d」用户不存在: %v", oauthState.Provider, identity.Subject, existing.AccountID, err)
+				return bizErr.New(bizErr.BAD_REQUEST, "该第三方账号绑定的账户不存在")
+			}
+
+			existing.Email = truncateRunes(identity.Email, oauthEmailMaxLength)
+			existing.DisplayName = truncateRunes(identity.Name, oauthNicknameMaxLength)
+			existing.LastLoginAt = time.Now().Unix()
+			if err := mapper.UpdateExternalIdentityLogin(c, existing); err != nil {
+				utils.BizLogger(c).Errorf("更新「%s」第三方账号登录时间失败: %v", oauthState.Provider, err)
+				return fmt.Errorf("更新「%s」第三方账号登录时间失败: %w", oauthState.Provider, err)
+			}
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BizLogger(c).Errorf("获取「%s」第三方账号绑定记录失败: %v", oauthState.Provider, err)
+			return fmt.Errorf("获取「%s」第三方账号绑定记录失败: %w", oauthState.Provider, err)
+		}
+
+		// 未绑定的第三方账号仅凭提供方已验证的邮箱创建账户，邮箱已注册时须登录后主动绑定，避免通过第三方账号接管已有账户
+		if identity.Email == "" || !identity.EmailVerified || utf8.RuneCountInString(identity.Email) > oauthEmailMaxLength {
+			utils.BizLogger(c).Warnf("「%s」第三方账号「%s」未提供可用的已验证邮箱", oauthState.Provider, identity.Subject)
+			return bizErr.New(bizErr.BAD_REQUEST, "第三方账号未提供已验证的邮箱，请先在第三方平台验证邮箱后重试")
+		}
+		_, err = mapper.GetAccountByEmail(c, identity.Email)
+		if err == nil {
+			utils.BizLogger(c).Warnf("「%s」第三方账号的邮箱「%s」已被注册", oauthState.Provider, identity.Email)
+			return bizErr.New(bizErr.BAD_REQUEST, "该邮箱已注册，请使用邮箱密码登录后在账户设置中绑定第三方账号")
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BizLogger(c).Errorf("获取「%s」用户失败: %v", identity.Email, err)
+			return fmt.Errorf("获取「%s」用户失败: %w", identity.Email, err)
+		}
+
+		acc = &model.Account{
+			Email:    identity.Email,
+			Nickname: oauthNickname(identity),
+			Role:     utils.ROLE_COMMENTER,
+		}
+		if len(identity.AvatarURL) <= oauthAvatarMaxLength {
+			acc.Avatar = identity.AvatarURL
+		}
+		if err := mapper.CreateAccount(c, acc); err != nil {
+			utils.BizLogger(c).Errorf("「%s」第三方登录创建账户失败: %v", identity.Email, err)
+			return fmt.Errorf("「%s」第三方登录创建账户失败: %w", identity.Email, err)
+		}
+
+		if err := mapper.CreateExternalIdentity(c, &model.ExternalIdentity{
+			AccountID:   acc.ID,
+			Provider:    oauthState.Provider,
+			Subject:     identity.Subject,
+			Email:       identity.Email,
+			DisplayName: truncateRunes(identity.Name, oauthNicknameMaxLength),
+			LastLoginAt: time.Now().Unix(),
+		}); err != nil {
+			utils.BizLogger(c).Errorf("保存「%s」第三方账号绑定记录失败: %v", oauthState.Provider, err)
+			return fmt.Errorf("保存「%s」第三方账号绑定记录失败: %w", oauthState.Provider, err)
+		}
+
+		created = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		utils.BizLogger(c).Infof("「%s」通过「%s」第三方登录自动创建了账户", acc.Email, oauthState.Provider)
+		recordOAuthIdentityAudit(c, auditModel.AUDIT_ACTION_OAUTH_LINK, acc, oauthState.Provider, identity.Subject, "首次第三方登录自动创建账户")
+	}
+
+	// 启用双因素认证的账户同样须校验 TOTP 验证码后才能换取访问令牌
+	if acc.TotpEnabled {
+		return createLoginChallenge(c, acc)
+	}
+	return issueLoginTokens(c, acc, fmt.Sprintf("「%s」第三方登录", oauthState.Provider))
+}
+
+// LinkOAuthIdentity 第三方授权回调，为当前账户绑定第三方账号，每个提供方只能绑定一个第三方账号
+// 参数：
+//   - c: Echo 上下文
+//   - req: 第三方授权回调请求
+//
+// 返回值：
+//   - *account.OAuthIdentityVO: 绑定的第三方账号
+//   - error: 操作过程中的错误
+func LinkOAuthIdentity(c echo.Context, req *dto.OAuthCallbackRequest) (*account.OAuthIdentityVO, error) {
+	oauthState, identity, err := exchangeOAuthCallback(c, req, utils.OAUTH_MODE_LINK)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := getAuthAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	// 授权须由当前账户发起，防止诱导他人完成授权后将第三方账号绑定到攻击者的账户
+	if oauthState.AccountID != acc.ID {
+		utils.BizLogger(c).Warnf("「%d」用户提交了「%d」用户发起的绑定授权", acc.ID, oauthState.AccountID)
+		return nil, bizErr.New(bizErr.BAD_REQUEST, utils.ErrOAuthStateInvalid.Error())
+	}
+
+	oauthIdentityLock.Lock()
+	defer oauthIdentityLock.Unlock()
+
+	externalIdentity := &model.ExternalIdentity{
+		AccountID:   acc.ID,
+		Provider:    oauthState.Provider,
+		Subject:     identity.Subject,
+		Email:       truncateRunes(identity.Email, oauthEmailMaxLength),
+		DisplayName: truncateRunes(identity.Name, oauthNicknameMaxLength),
+	}
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		existing, err := mapper.GetExternalIdentity(c, oauthState.Provider, identity.Subject)
+		if err == nil {
+			if existing.AccountID == acc.ID {
+				return bizErr.New(bizErr.BAD_REQUEST, "该第三方账号已绑定当前账户")
+			}
+			utils.BizLogger(c).Warnf("「%s」第三方账号「%s」已绑定「%d」用户", oauthState.Provider, identity.Subject, existing.AccountID)
+			return bizErr.New(bizErr.BAD_REQUEST, "该第三方账号已绑定其他账户")
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BizLogger(c).Errorf("获取「%s」第三方账号绑定记录失败: %v", oauthState.Provider, err)
+			return fmt.Errorf("获取「%s」第三方账号绑定记录失败: %w", oauthState.Provider, err)
+		}
+
+		identities, err := mapper.ListExternalIdentitiesByAccountID(c, acc.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取「%d」用户第三方账号绑定列表失败: %v", acc.ID, err)
+			return fmt.Errorf("获取「%d」用户第三方账号绑定列表失败: %w", acc.ID, err)
+		}
+		for _, bound := range identities {
+			if bound.Provider == oauthState.Provider {
+				return bizErr.New(bizErr.BAD_REQUEST, fmt.Sprintf("当前账户已绑定「%s」账号，请先解除绑定", oauthState.Provider))
+			}
+		}
+
+		if err := mapper.CreateExternalIdentity(c, externalIdentity); err != nil {
+			utils.BizLogger(c).Errorf("保存「%s」第三方账号绑定记录失败: %v", oauthState.Provider, err)
+			return fmt.Errorf("保存「%s」第三方账号绑定记录失败: %w", oauthState.Provider, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	utils.BizLogger(c).Infof("「%d」用户绑定了「%s」第三方账号", acc.ID, oauthState.Provider)
+	recordOAuthIdentityAudit(c, auditModel.AUDIT_ACTION_OAUTH_LINK, acc, oauthState.Provider, identity.Subject, "")
+	return toOAuthIdentityVO(externalIdentity), nil
+}
+
+// ListOAuthIdentities 获取当前账户绑定的第三方账号
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - []*account.OAuthIdentityVO: 已绑定的第三方账号列表
+//   - error: 操作过程中的错误
+func ListOAuthIdentities(c echo.Context) ([]*account.OAuthIdentityVO, error) {
+	accountID, _ := utils.GetAuthAccountID(c)
+	identities, err := mapper.ListExternalIdentitiesByAccountID(c, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取「%d」用户第三方账号绑定列表失败: %v", accountID, err)
+		return nil, fmt.Errorf("获取「%d」用户第三方账号绑定列表失败: %w", accountID, err)
+	}
+
+	identityVOs := make([]*account.OAuthIdentityVO, 0, len(identities))
+	for _, identity := range identities {
+		identityVOs = append(identityVOs, toOAuthIdentityVO(identity))
+	}
+	return identityVOs, nil
+}
+
+// UnlinkOAuthIdentity 解除当前账户绑定的第三方账号，未设置密码的账户不能解除最后一个绑定
+// 参数：
+//   - c: Echo 上下文
+//   - req: 解除第三方账号绑定请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func UnlinkOAuthIdentity(c echo.Context, req *dto.UnlinkOAuthIdentityRequest) error {
+	acc, err := getAuthAccount(c)
+	if err != nil {
+		return err
+	}
+
+	oauthIdentityLock.Lock()
+	defer oauthIdentityLock.Unlock()
+
+	var unlinked *model.ExternalIdentity
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		identities, err := mapper.ListExternalIdentitiesByAccountID(c, acc.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取「%d」用户第三方账号绑定列表失败: %v", acc.ID, err)
+			return fmt.Errorf("获取「%d」用户第三方账号绑定列表失败: %w", acc.ID, err)
+		}
+		for _, identity := range identities {
+			if identity.ID == req.ID {
+				unlinked = identity
+				break
+			}
+		}
+		if unlinked == nil {
+			return bizErr.New(bizErr.BAD_REQUEST, "第三方账号绑定记录不存在")
+		}
+
+		// 通过第三方登录自动创建的账户没有密码，解除最后一个绑定后将无法登录
+		if acc.Password == "" && len(identities) == 1 {
+			return bizErr.New(bizErr.BAD_REQUEST, "当前账户尚未设置密码，请先通过找回密码设置密码后再解除最后一个第三方账号绑定")
+		}
+
+		if _, err := mapper.DeleteExternalIdentity(c, acc.ID, req.ID); err != nil {
+			utils.BizLogger(c).Errorf("解除「%d」用户第三方账号绑定失败: %v", acc.ID, err)
+			return fmt.Errorf("解除「%d」用户第三方账号绑定失败: %w", acc.ID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	utils.BizLogger(c).Infof("「%d」用户解除了「%s」第三方账号绑定", acc.ID, unlinked.Provider)
+	recordOAuthIdentityAudit(c, auditModel.AUDIT_ACTION_OAUTH_UNLINK, acc, unlinked.Provider, unlinked.Subject, "")
+	return nil
+}
+
+// GetOAuthReauthURL 获取通过已绑定的第三方账号重新验证身份的授权地址，供未设置密码的账户执行敏感操作前使用
+// 参数：
+//   - c: Echo 上下文
+//   - req: 获取第三方授权地址请求
+//
+// 返回值：
+//   - *account.OAuthAuthorizationVO: 授权地址
+//   - error: 操作过程中的错误
+func GetOAuthReauthURL(c echo.Context, req *dto.GetOAuthAuthorizationRequest) (*account.OAuthAuthorizationVO, error) {
+	accountID, _ := utils.GetAuthAccountID(c)
+	return createOAuthAuthorization(c, req.Provider, utils.OAUTH_MODE_REAUTH, accountID)
+}
+
+// VerifyOAuthReauth 第三方授权回调，校验授权的第三方账号已绑定当前账户后，签发与当前会话绑定、短时有效的一次性身份验证令牌，
+// 可在注销账户、关闭双因素认证时代替密码
+// 参数：
+//   - c: Echo 上下文
+//   - req: 第三方授权回调请求
+//
+// 返回值：
+//   - *account.OAuthReauthVO: 身份验证令牌
+//   - error: 操作过程中的错误
+func VerifyOAuthReauth(c echo.Context, req *dto.OAuthCallbackRequest) (*account.OAuthReauthVO, error) {
+	oauthState, identity, err := exchangeOAuthCallback(c, req, utils.OAUTH_MODE_REAUTH)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := getAuthAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	if oauthState.AccountID != acc.ID {
+		utils.BizLogger(c).Warnf("「%d」用户提交了「%d」用户发起的身份验证授权", acc.ID, oauthState.AccountID)
+		return nil, bizErr.New(bizErr.BAD_REQUEST, utils.ErrOAuthStateInvalid.Error())
+	}
+
+	existing, err := mapper.GetExternalIdentity(c, oauthState.Provider, identity.Subject)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.BizLogger(c).Errorf("获取「%s」第三方账号绑定记录失败: %v", oauthState.Provider, err)
+		return nil, fmt.Errorf("获取「%s」第三方账号绑定记录失败: %w", oauthState.Provider, err)
+	}
+	if err != nil || existing.AccountID != acc.ID {
+		utils.BizLogger(c).Warnf("「%d」用户重新验证身份时使用了未绑定的「%s」第三方账号「%s」", acc.ID, oauthState.Provider, identity.Subject)
+		return nil, bizErr.New(bizErr.BAD_REQUEST, "该第三方账号未绑定当前账户")
+	}
+
+	buf := make([]byte, oauthReauthTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		utils.BizLogger(c).Errorf("生成身份验证令牌失败: %v", err)
+		return nil, fmt.Errorf("生成身份验证令牌失败: %w", err)
+	}
+	reauthToken := base64.RawURLEncoding.EncodeToString(buf)
+
+	key := OAUTH_REAUTH_CACHE_PREFIX + hashChallengeToken(reauthToken)
+	if err := global.RedisClient.Set(c.Request().Context(), key, reauthOwner(c, acc.ID), OAUTH_REAUTH_EXPIRE_TIME).Err(); err != nil {
+		utils.BizLogger(c).Errorf("身份验证令牌写入缓存失败: %v", err)
+		return nil, fmt.Errorf("身份验证令牌写入缓存失败: %w", err)
+	}
+
+	return &account.OAuthReauthVO{
+		ReauthToken: reauthToken,
+		ExpiresIn:   int64(OAUTH_REAUTH_EXPIRE_TIME.Seconds()),
+	}, nil
+}
+
+// verifyAccountCredential 敏感操作前校验账户身份：提供身份验证令牌时校验并作废该令牌，否则校验当前密码
+// 参数：
+//   - c: Echo 上下文
+//   - acc: 当前账户
+//   - password: 当前密码
+//   - reauthToken: 通过第三方账号重新验证身份后获得的令牌
+//
+// 返回值：
+//   - error: 校验失败时返回业务错误
+func verifyAccountCredential(c echo.Context, acc *model.Account, password, reauthToken string) error {
+	if reauthToken != "" {
+		key := OAUTH_REAUTH_CACHE_PREFIX + hashChallengeToken(reauthToken)
+		owner, err := global.RedisClient.GetDel(c.Request().Context(), key).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			utils.BizLogger(c).Errorf("读取身份验证令牌失败: %v", err)
+			return fmt.Errorf("读取身份验证令牌失败: %w", err)
+		}
+		// 令牌仅在签发时的会话中有效，防止泄露后被其他会话使用
+		if err != nil || owner != reauthOwner(c, acc.ID) {
+			utils.BizLogger(c).Warnf("「%s」用户的身份验证令牌无效或已过期", acc.Email)
+			return bizErr.New(bizErr.BAD_REQUEST, "身份验证已过期，请重新通过第三方账号验证身份")
+		}
+		return nil
+	}
+
+	// 通过第三方登录自动创建的账户没有密码，须通过已绑定的第三方账号重新验证身份
+	if acc.Password == "" {
+		return bizErr.New(bizErr.BAD_REQUEST, "当前账户尚未设置密码，请通过已绑定的第三方账号重新验证身份")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(password)); err != nil {
+		utils.BizLogger(c).Warnf("「%s」用户身份验证时密码错误", acc.Email)
+		return bizErr.New(bizErr.BAD_REQUEST, "密码错误")
+	}
+	return nil
+}
+
+// reauthOwner 生成身份验证令牌所属的账户与会话标识
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - string: 形如 <账户ID>:<会话ID> 的标识
+func reauthOwner(c echo.Context, accountID int64) string {
+	return fmt.Sprintf("%d:%s", accountID, utils.GetAuthSessionID(c))
+}
+
+// createOAuthAuthorization 按客户端 IP 限流后生成第三方授权地址
+// 参数：
+//   - c: Echo 上下文
+//   - provider: 提供方名称
+//   - mode: 授权用途：login、link、reauth
+//   - accountID: 发起授权的账户ID，login 用途为 0
+//
+// 返回值：
+//   - *account.OAuthAuthorizationVO: 授权地址
+//   - error: 操作过程中的错误
+func createOAuthAuthorization(c echo.Context, provider, mode string, accountID int64) (*account.OAuthAuthorizationVO, error) {
+	allowed, err := utils.AllowRequest(c, OAUTH_AUTHORIZE_SCOPE, c.RealIP(), OAUTH_AUTHORIZE_LIMIT, OAUTH_AUTHORIZE_WINDOW)
+	if err != nil {
+		utils.BizLogger(c).Errorf("第三方授权限流计数失败: %v", err)
+		return nil, fmt.Errorf("第三方授权限流计数失败: %w", err)
+	}
+	if !allowed {
+		return nil, bizErr.New(bizErr.TOO_MANY_REQUESTS, "发起第三方登录过于频繁，请稍后再试")
+	}
+
+	authURL, err := utils.CreateOAuthAuthorization(c, provider, mode, accountID)
+	if err != nil {
+		return nil, oauthErr(c, provider, err)
+	}
+	return &account.OAuthAuthorizationVO{AuthorizationURL: authURL}, nil
+}
+
+// exchangeOAuthCallback 校验并作废授权 state，再用授权码换取第三方账号信息
+// 参数：
+//   - c: Echo 上下文
+//   - req: 第三方授权回调请求
+//   - mode: 期望的授权用途
+//
+// 返回值：
+//   - *utils.OAuthState: 发起授权时保存的上下文
+//   - *utils.OAuthIdentity: 第三方账号信息
+//   - error: 操作过程中的错误
+func exchangeOAuthCallback(c echo.Context, req *dto.OAuthCallbackRequest, mode string) (*utils.OAuthState, *utils.OAuthIdentity, error) {
+	oauthState, err := utils.ConsumeOAuthState(c, req.State)
+	if err != nil {
+		return nil, nil, oauthErr(c, req.Provider, err)
+	}
+	if oauthState.Provider != req.Provider || oauthState.Mode != mode {
+		utils.BizLogger(c).Warnf("授权 state 与回调不匹配，state 提供方「%s」用途「%s」，回调提供方「%s」用途「%s」", oauthState.Provider, oauthState.Mode, req.Provider, mode)
+		return nil, nil, bizErr.New(bizErr.BAD_REQUEST, utils.ErrOAuthStateInvalid.Error())
+	}
+
+	identity, err := utils.ExchangeOAuthCode(c, oauthState, req.Code)
+	if err != nil {
+		return nil, nil, oauthErr(c, req.Provider, err)
+	}
+	return oauthState, identity, nil
+}
+
+// oauthErr 将第三方登录工具返回的错误转换为业务错误，提供方配置或网络错误按服务器错误处理
+// 参数：
+//   - c: Echo 上下文
+//   - provider: 提供方名称
+//   - err: 第三方登录工具返回的错误
+//
+// 返回值：
+//   - error: 业务错误
+func oauthErr(c echo.Context, provider string, err error) error {
+	switch {
+	case errors.Is(err, utils.ErrOAuthDisabled), errors.Is(err, utils.ErrOAuthProviderNotFound), errors.Is(err, utils.ErrOAuthStateInvalid):
+		utils.BizLogger(c).Warnf("「%s」第三方登录失败: %v", provider, err)
+		return bizErr.New(bizErr.BAD_REQUEST, err.Error())
+	case errors.Is(err, utils.ErrOAuthExchangeFailed):
+		utils.BizLogger(c).Warnf("「%s」第三方登录失败: %v", provider, err)
+		return bizErr.New(bizErr.BAD_REQUEST, "第三方授权失败，请重新登录")
+	default:
+		utils.BizLogger(c).Errorf("「%s」第三方登录失败: %v", provider, err)
+		return fmt.Errorf("「%s」第三方登录失败: %w", provider, err)
+	}
+}
+
+// recordOAuthIdentityAudit 记录绑定或解除第三方账号的审计日志
+// 参数：
+//   - c: Echo 上下文
+//   - action: 审计事件
+//   - acc: 账户
+//   - provider: 提供方名称
+//   - subject: 第三方账号唯一标识
+//   - detail: 补充说明
+func recordOAuthIdentityAudit(c echo.Context, action string, acc *model.Account, provider, subject, detail string) {
+	summary := map[string]interface{}{"provider": provider, "subject": subject}
+	event := &audit.Event{
+		Action:     action,
+		ActorID:    acc.ID,
+		ActorEmail: acc.Email,
+		TargetType: auditModel.AUDIT_TARGET_ACCOUNT,
+		TargetID:   acc.Email,
+		Detail:     detail,
+	}
+	if action == auditModel.AUDIT_ACTION_OAUTH_UNLINK {
+		event.Before = summary
+	} else {
+		event.After = summary
+	}
+	audit.Record(c, event)
+}
+
+// toOAuthIdentityVO 将第三方账号绑定记录转换为视图对象
+// 参数：
+//   - identity: 绑定记录
+//
+// 返回值：
+//   - *account.OAuthIdentityVO: 已绑定的第三方账号视图对象
+func toOAuthIdentityVO(identity *model.ExternalIdentity) *account.OAuthIdentityVO {
+	return &account.OAuthIdentityVO{
+		ID:          strconv.FormatInt(identity.ID, 10),
+		Provider:    identity.Provider,
+		Email:       identity.Email,
+		DisplayName: identity.DisplayName,
+		LastLoginAt: formatDisplayTime(identity.LastLoginAt),
+		GmtCreate:   formatDisplayTime(identity.GmtCreate),
+	}
+}
+
+// oauthNickname 生成自动创建账户的昵称，第三方账号未提供名称时使用邮箱前缀
+// 参数：
+//   - identity: 第三方账号信息
+//
+// 返回值：
+//   - string: 昵称
+func oauthNickname(identity *utils.OAuthIdentity) string {
+	nickname := strings.TrimSpace(identity.Name)
+	if nickname == "" {
+		nickname, _, _ = strings.Cut(identity.Email, "@")
+	}
+	return truncateRunes(nickname, oauthNicknameMaxLength)
+}
+
+// truncateRunes 按字符数截断字符串
+// 参数：
+//   - s: 字符串
+//   - maxRunes: 最大字符数
+//
+// 返回值：
+//   - string: 截断后的字符串
+func truncateRunes(s string, maxRunes int) string {
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	return string([]rune(s)[:maxRunes])
+}
@@ -0,0 +1,518 @@
+// Package service 第三方登录测试，使用本地模拟 OIDC 提供方覆盖发现文档、JWKS、PKCE 授权码换取、ID Token 校验以及登录、绑定与重新验证身份流程
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/crypto/bcrypt"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+const (
+	testProvider     = "mock"        // 测试配置中的提供方名称
+	testClientID     = "jank-client" // 测试配置中的客户端 ID
+	testClientSecret = "jank-secret" // 测试配置中的客户端密钥
+	testSigningKID   = "mock-key"    // 模拟提供方 JWKS 中公钥的 kid
+)
+
+// oidcGrant 模拟提供方签发的授权码对应的授权信息
+type oidcGrant struct {
+	challenge   string // PKCE code_challenge
+	nonce       string // OIDC nonce
+	redirectURI string // 回调地址
+	subject     string // 第三方账号唯一标识
+	email       string // 邮箱
+}
+
+// oidcProvider 本地模拟 OIDC 提供方，记录发现文档与 JWKS 的请求次数
+type oidcProvider struct {
+	server     *httptest.Server
+	key        *rsa.PrivateKey // JWKS 中发布的公钥对应的私钥
+	signingKey *rsa.PrivateKey // 签发 ID Token 使用的私钥，默认与 key 相同
+
+	mu             sync.Mutex
+	grants         map[string]*oidcGrant      // 授权码到授权信息的映射，授权码仅能使用一次
+	nextCode       int                        // 下一个授权码序号
+	discoveryHits  int                        // 发现文档请求次数
+	jwksHits       int                        // JWKS 请求次数
+	mutateClaims   func(claims jwt.MapClaims) // 签发前修改 ID Token 声明
+	wrongChallenge bool                       // 授权时记录错误的 code_challenge，模拟 code_verifier 不匹配
+}
+
+// newOIDCProvider 启动本地模拟 OIDC 提供方
+// 参数：
+//   - t: 测试对象
+//
+// 返回值：
+//   - *oidcProvider: 模拟提供方
+func newOIDCProvider(t *testing.T) *oidcProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成 RSA 密钥失败: %v", err)
+	}
+	p := &oidcProvider{key: key, signingKey: key, grants: make(map[string]*oidcGrant)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		p.discoveryHits++
+		p.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"userinfo_endpoint":      p.server.URL + "/userinfo",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		p.jwksHits++
+		p.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": testSigningKID,
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", p.handleToken)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// handleToken 令牌端点：校验客户端凭据、授权码、回调地址与 PKCE code_verifier 后签发 ID Token
+// 参数：
+//   - w: 响应
+//   - r: 请求
+func (p *oidcProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	form := r.PostForm
+	if form.Get("grant_type") != "authorization_code" || form.Get("client_id") != testClientID || form.Get("client_secret") != testClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	grant := p.grants[form.Get("code")]
+	delete(p.grants, form.Get("code"))
+	mutate, signingKey := p.mutateClaims, p.signingKey
+	p.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(form.Get("code_verifier")))
+	if grant == nil || grant.redirectURI != form.Get("redirect_uri") ||
+		base64.RawURLEncoding.EncodeToString(challenge[:]) != grant.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            p.server.URL,
+		"sub":            grant.subject,
+		"aud":            testClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          grant.nonce,
+		"email":          grant.email,
+		"email_verified": true,
+		"name":           "Mock " + grant.subject,
+	}
+	if mutate != nil {
+		mutate(claims)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testSigningKID
+	idToken, err := token.SignedString(signingKey)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": "mock-access-token",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+// authorize 模拟用户在提供方完成授权：校验授权地址中的参数后签发授权码
+// 参数：
+//   - t: 测试对象
+//   - authURL: 本站生成的授权地址
+//   - subject: 第三方账号唯一标识
+//   - email: 第三方账号邮箱
+//
+// 返回值：
+//   - *dto.OAuthCallbackRequest: 前端回调页面提交的授权结果
+func (p *oidcProvider) authorize(t *testing.T, authURL, subject, email string) *dto.OAuthCallbackRequest {
+	t.Helper()
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("解析授权地址失败: %v", err)
+	}
+	if !strings.HasPrefix(authURL, p.server.URL+"/authorize?") {
+		t.Fatalf("授权地址应指向发现文档中的授权端点，实际为 %s", authURL)
+	}
+	query := parsed.Query()
+	if query.Get("response_type") != "code" || query.Get("client_id") != testClientID {
+		t.Fatalf("授权地址缺少 response_type 或 client_id: %s", authURL)
+	}
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		t.Fatalf("授权地址未使用 PKCE S256: %s", authURL)
+	}
+	if query.Get("state") == "" || query.Get("nonce") == "" {
+		t.Fatalf("授权地址缺少 state 或 nonce: %s", authURL)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.nextCode++
+	code := "code-" + strconv.Itoa(p.nextCode)
+	challenge := query.Get("code_challenge")
+	if p.wrongChallenge {
+		challenge = base64.RawURLEncoding.EncodeToString(make([]byte, sha256.Size))
+	}
+	p.grants[code] = &oidcGrant{
+		challenge:   challenge,
+		nonce:       query.Get("nonce"),
+		redirectURI: query.Get("redirect_uri"),
+		subject:     subject,
+		email:       email,
+	}
+	return &dto.OAuthCallbackRequest{Provider: testProvider, Code: code, State: query.Get("state")}
+}
+
+// hits 返回发现文档与 JWKS 的请求次数
+// 返回值：
+//   - int: 发现文档请求次数
+//   - int: JWKS 请求次数
+func (p *oidcProvider) hits() (int, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.discoveryHits, p.jwksHits
+}
+
+// writeJSON 写入 JSON 响应
+// 参数：
+//   - w: 响应
+//   - status: 状态码
+//   - body: 响应体
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// oauthLogin 发起第三方登录并在模拟提供方完成授权后提交回调
+// 参数：
+//   - t: 测试对象
+//   - p: 模拟提供方
+//   - subject: 第三方账号唯一标识
+//   - email: 第三方账号邮箱
+//
+// 返回值：
+//   - *account.LoginVO: 登录结果
+//   - error: 登录失败时的错误
+func oauthLogin(t *testing.T, p *oidcProvider, subject, email string) (*account.LoginVO, error) {
+	t.Helper()
+
+	authVO, err := GetOAuthAuthorizationURL(newTestContext(0, ""), &dto.GetOAuthAuthorizationRequest{Provider: testProvider})
+	if err != nil {
+		t.Fatalf("获取授权地址失败: %v", err)
+	}
+	return OAuthLogin(newTestContext(0, ""), p.authorize(t, authVO.AuthorizationURL, subject, email))
+}
+
+// countAccounts 统计账户数量
+// 参数：
+//   - t: 测试对象
+//
+// 返回值：
+//   - int64: 账户数量
+func countAccounts(t *testing.T) int64 {
+	t.Helper()
+
+	var count int64
+	if err := utils.GetDBFromContext(newTestContext(0, "")).Model(&model.Account{}).Count(&count).Error; err != nil {
+		t.Fatalf("统计账户数量失败: %v", err)
+	}
+	return count
+}
+
+// assertBizErr 断言错误为包含指定信息的业务错误
+// 参数：
+//   - t: 测试对象
+//   - err: 错误
+//   - msg: 期望错误信息包含的内容
+func assertBizErr(t *testing.T, err error, msg string) {
+	t.Helper()
+
+	var e *bizErr.Err
+	if !errors.As(err, &e) {
+		t.Fatalf("期望业务错误「%s」，实际为 %v", msg, err)
+	}
+	if !strings.Contains(e.Msg, msg) {
+		t.Fatalf("期望错误信息包含「%s」，实际为「%s」", msg, e.Msg)
+	}
+}
+
+func TestOAuthLoginCreatesAccountAndReusesIdentity(t *testing.T) {
+	p := newOIDCProvider(t)
+	setupTestEnv(t, p.server.URL)
+
+	loginVO, err := oauthLogin(t, p, "sub-alice", "alice@example.com")
+	if err != nil {
+		t.Fatalf("首次第三方登录失败: %v", err)
+	}
+	if loginVO.AccessToken == "" || loginVO.RefreshToken == "" {
+		t.Fatalf("首次第三方登录未签发令牌: %+v", loginVO)
+	}
+	if discovery, jwks := p.hits(); discovery == 0 || jwks == 0 {
+		t.Fatalf("应通过发现文档与 JWKS 校验 ID Token，发现文档请求 %d 次，JWKS 请求 %d 次", discovery, jwks)
+	}
+
+	c := newTestContext(0, "")
+	acc, err := mapper.GetAccountByEmail(c, "alice@example.com")
+	if err != nil {
+		t.Fatalf("首次第三方登录应自动创建账户: %v", err)
+	}
+	if acc.Password != "" || acc.Role != utils.ROLE_COMMENTER || acc.Nickname != "Mock sub-alice" {
+		t.Fatalf("自动创建的账户应为未设置密码的评论者，实际为 %+v", acc)
+	}
+	identity, err := mapper.GetExternalIdentity(c, testProvider, "sub-alice")
+	if err != nil || identity.AccountID != acc.ID {
+		t.Fatalf("第三方账号应绑定自动创建的账户: %+v, %v", identity, err)
+	}
+
+	if _, err := oauthLogin(t, p, "sub-alice", "alice@example.com"); err != nil {
+		t.Fatalf("已绑定的第三方账号再次登录失败: %v", err)
+	}
+	if count := countAccounts(t); count != 1 {
+		t.Fatalf("再次登录不应创建新账户，账户数量为 %d", count)
+	}
+	sessions, err := utils.ListSessions(c, acc.ID)
+	if err != nil || len(sessions) != 2 {
+		t.Fatalf("两次登录应各自创建会话，实际为 %d, %v", len(sessions), err)
+	}
+}
+
+func TestOAuthLoginRejectsInvalidIDToken(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成 RSA 密钥失败: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(claims jwt.MapClaims)
+		key    *rsa.PrivateKey
+	}{
+		{name: "nonce 不匹配", mutate: func(claims jwt.MapClaims) { claims["nonce"] = "replayed-nonce" }},
+		{name: "受众不匹配", mutate: func(claims jwt.MapClaims) { claims["aud"] = "another-client" }},
+		{name: "签发方不匹配", mutate: func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" }},
+		{name: "已过期", mutate: func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() }},
+		{name: "缺少 sub", mutate: func(claims jwt.MapClaims) { delete(claims, "sub") }},
+		{name: "签名与 JWKS 公钥不符", key: otherKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newOIDCProvider(t)
+			setupTestEnv(t, p.server.URL)
+			p.mutateClaims = tt.mutate
+			if tt.key != nil {
+				p.signingKey = tt.key
+			}
+
+			_, err := oauthLogin(t, p, "sub-mallory", "mallory@example.com")
+			assertBizErr(t, err, utils.ErrOAuthExchangeFailed.Error())
+			if count := countAccounts(t); count != 0 {
+				t.Fatalf("ID Token 校验失败时不应创建账户，账户数量为 %d", count)
+			}
+		})
+	}
+}
+
+func TestOAuthLoginRejectsInvalidCodeVerifierAndReusedState(t *testing.T) {
+	p := newOIDCProvider(t)
+	setupTestEnv(t, p.server.URL)
+
+	p.wrongChallenge = true
+	_, err := oauthLogin(t, p, "sub-alice", "alice@example.com")
+	assertBizErr(t, err, utils.ErrOAuthExchangeFailed.Error())
+	p.wrongChallenge = false
+
+	authVO, err := GetOAuthAuthorizationURL(newTestContext(0, ""), &dto.GetOAuthAuthorizationRequest{Provider: testProvider})
+	if err != nil {
+		t.Fatalf("获取授权地址失败: %v", err)
+	}
+	callback := p.authorize(t, authVO.AuthorizationURL, "sub-alice", "alice@example.com")
+	if _, err := OAuthLogin(newTestContext(0, ""), callback); err != nil {
+		t.Fatalf("第三方登录失败: %v", err)
+	}
+
+	replay := p.authorize(t, authVO.AuthorizationURL, "sub-alice", "alice@example.com")
+	_, err = OAuthLogin(newTestContext(0, ""), replay)
+	assertBizErr(t, err, utils.ErrOAuthStateInvalid.Error())
+}
+
+func TestOAuthLoginRequiresLinkForRegisteredEmail(t *testing.T) {
+	p := newOIDCProvider(t)
+	setupTestEnv(t, p.server.URL)
+
+	password, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	bob := &model.Account{Email: "bob@example.com", Nickname: "bob", Password: string(password), Role: utils.ROLE_COMMENTER}
+	if err := mapper.CreateAccount(newTestContext(0, ""), bob); err != nil {
+		t.Fatalf("创建账户失败: %v", err)
+	}
+
+	_, err := oauthLogin(t, p, "sub-bob", "bob@example.com")
+	assertBizErr(t, err, "该邮箱已注册")
+	if _, err := mapper.GetExternalIdentity(newTestContext(0, ""), testProvider, "sub-bob"); err == nil {
+		t.Fatalf("邮箱已注册时不应自动绑定第三方账号")
+	}
+
+	c := newTestContext(bob.ID, "bob-session")
+	linkVO, err := GetOAuthLinkURL(c, &dto.GetOAuthAuthorizationRequest{Provider: testProvider})
+	if err != nil {
+		t.Fatalf("获取绑定授权地址失败: %v", err)
+	}
+	identityVO, err := LinkOAuthIdentity(c, p.authorize(t, linkVO.AuthorizationURL, "sub-bob", "bob@example.com"))
+	if err != nil {
+		t.Fatalf("绑定第三方账号失败: %v", err)
+	}
+	if identityVO.Provider != testProvider {
+		t.Fatalf("绑定结果的提供方应为 %s，实际为 %s", testProvider, identityVO.Provider)
+	}
+
+	if _, err := oauthLogin(t, p, "sub-bob", "bob@example.com"); err != nil {
+		t.Fatalf("绑定后通过第三方账号登录失败: %v", err)
+	}
+	sessions, err := utils.ListSessions(c, bob.ID)
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("绑定后第三方登录应登录已有账户，会话数量为 %d, %v", len(sessions), err)
+	}
+	if count := countAccounts(t); count != 1 {
+		t.Fatalf("绑定后第三方登录不应创建新账户，账户数量为 %d", count)
+	}
+}
+
+func TestLinkOAuthIdentityRejectsAuthorizationFromAnotherAccount(t *testing.T) {
+	p := newOIDCProvider(t)
+	setupTestEnv(t, p.server.URL)
+
+	attacker := &model.Account{Email: "attacker@example.com", Nickname: "attacker", Role: utils.ROLE_COMMENTER}
+	victim := &model.Account{Email: "victim@example.com", Nickname: "victim", Role: utils.ROLE_COMMENTER}
+	for _, acc := range []*model.Account{attacker, victim} {
+		if err := mapper.CreateAccount(newTestContext(0, ""), acc); err != nil {
+			t.Fatalf("创建账户失败: %v", err)
+		}
+	}
+
+	linkVO, err := GetOAuthLinkURL(newTestContext(attacker.ID, "attacker-session"), &dto.GetOAuthAuthorizationRequest{Provider: testProvider})
+	if err != nil {
+		t.Fatalf("获取绑定授权地址失败: %v", err)
+	}
+	callback := p.authorize(t, linkVO.AuthorizationURL, "sub-victim", "victim@example.com")
+
+	_, err = LinkOAuthIdentity(newTestContext(victim.ID, "victim-session"), callback)
+	assertBizErr(t, err, utils.ErrOAuthStateInvalid.Error())
+	if _, err := mapper.GetExternalIdentity(newTestContext(0, ""), testProvider, "sub-victim"); err == nil {
+		t.Fatalf("其他账户发起的绑定授权不应生效")
+	}
+}
+
+func TestOAuthReauthAllowsPasswordlessAccountDeletion(t *testing.T) {
+	p := newOIDCProvider(t)
+	setupTestEnv(t, p.server.URL)
+
+	if _, err := oauthLogin(t, p, "sub-carol", "carol@example.com"); err != nil {
+		t.Fatalf("第三方登录失败: %v", err)
+	}
+	acc, err := mapper.GetAccountByEmail(newTestContext(0, ""), "carol@example.com")
+	if err != nil {
+		t.Fatalf("获取账户失败: %v", err)
+	}
+	sessions, err := utils.ListSessions(newTestContext(0, ""), acc.ID)
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("第三方登录应创建会话，会话数量为 %d, %v", len(sessions), err)
+	}
+	c := newTestContext(acc.ID, sessions[0].ID)
+
+	err = DeleteAccount(c, &dto.DeleteAccountRequest{Password: "guessed-password"})
+	assertBizErr(t, err, "尚未设置密码")
+
+	reauthVO, err := GetOAuthReauthURL(c, &dto.GetOAuthAuthorizationRequest{Provider: testProvider})
+	if err != nil {
+		t.Fatalf("获取重新验证身份授权地址失败: %v", err)
+	}
+	_, err = VerifyOAuthReauth(c, p.authorize(t, reauthVO.AuthorizationURL, "sub-mallory", "mallory@example.com"))
+	assertBizErr(t, err, "未绑定当前账户")
+
+	reauthVO, err = GetOAuthReauthURL(c, &dto.GetOAuthAuthorizationRequest{Provider: testProvider})
+	if err != nil {
+		t.Fatalf("获取重新验证身份授权地址失败: %v", err)
+	}
+	tokenVO, err := VerifyOAuthReauth(c, p.authorize(t, reauthVO.AuthorizationURL, "sub-carol", "carol@example.com"))
+	if err != nil {
+		t.Fatalf("通过第三方账号重新验证身份失败: %v", err)
+	}
+
+	// 身份验证令牌仅在签发时的会话中有效，且使用一次后即失效
+	err = DeleteAccount(newTestContext(acc.ID, "another-session"), &dto.DeleteAccountRequest{ReauthToken: tokenVO.ReauthToken})
+	assertBizErr(t, err, "身份验证已过期")
+	err = DeleteAccount(c, &dto.DeleteAccountRequest{ReauthToken: tokenVO.ReauthToken})
+	assertBizErr(t, err, "身份验证已过期")
+
+	reauthVO, err = GetOAuthReauthURL(c, &dto.GetOAuthAuthorizationRequest{Provider: testProvider})
+	if err != nil {
+		t.Fatalf("获取重新验证身份授权地址失败: %v", err)
+	}
+	tokenVO, err = VerifyOAuthReauth(c, p.authorize(t, reauthVO.AuthorizationURL, "sub-carol", "carol@example.com"))
+	if err != nil {
+		t.Fatalf("通过第三方账号重新验证身份失败: %v", err)
+	}
+	if err := DeleteAccount(c, &dto.DeleteAccountRequest{ReauthToken: tokenVO.ReauthToken}); err != nil {
+		t.Fatalf("凭身份验证令牌注销账户失败: %v", err)
+	}
+	if _, err := mapper.GetAccountByEmail(newTestContext(0, ""), "carol@example.com"); err == nil {
+		t.Fatalf("注销后账户邮箱应被清除")
+	}
+	if _, err := mapper.GetExternalIdentity(newTestContext(0, ""), testProvider, "sub-carol"); err == nil {
+		t.Fatalf("注销后第三方账号绑定应被解除")
+	}
+}
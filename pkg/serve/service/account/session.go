@@ -0,0 +1,108 @@
+// Package service 提供业务逻辑处理，处理登录会话相关业务
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// DISPLAY_TIME_LAYOUT 会话与令牌时间的展示格式，与 VO 映射的时间格式一致
+const DISPLAY_TIME_LAYOUT = "2006-01-02 15:04:05"
+
+// ListSessions 获取当前账户全部有效的登录会话
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - []*account.SessionVO: 会话列表，按最近活跃时间倒序排列
+//   - error: 操作过程中的错误
+func ListSessions(c echo.Context) ([]*account.SessionVO, error) {
+	accountID, _ := utils.GetAuthAccountID(c)
+	sessions, err := utils.ListSessions(c, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取「%d」用户登录会话失败: %v", accountID, err)
+		return nil, fmt.Errorf("获取「%d」用户登录会话失败: %w", accountID, err)
+	}
+
+	currentID := utils.GetAuthSessionID(c)
+	sessionVOs := make([]*account.SessionVO, 0, len(sessions))
+	for _, session := range sessions {
+		sessionVOs = append(sessionVOs, &account.SessionVO{
+			SessionID:  session.ID,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  time.Unix(session.CreatedAt, 0).Format(DISPLAY_TIME_LAYOUT),
+			LastSeenAt: time.Unix(session.LastSeenAt, 0).Format(DISPLAY_TIME_LAYOUT),
+			Current:    session.ID == currentID,
+		})
+	}
+	return sessionVOs, nil
+}
+
+// RevokeSession 注销当前账户的指定登录会话，该会话的令牌随即失效
+// 参数：
+//   - c: Echo 上下文
+//   - req: 注销登录会话请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RevokeSession(c echo.Context, req *dto.RevokeSessionRequest) error {
+	accountID, _ := utils.GetAuthAccountID(c)
+	revoked, err := utils.RevokeSession(c, accountID, req.SessionID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("注销「%d」用户会话「%s」失败: %v", accountID, req.SessionID, err)
+		return fmt.Errorf("注销「%d」用户会话「%s」失败: %w", accountID, req.SessionID, err)
+	}
+	if !revoked {
+		utils.BizLogger(c).Warnf("「%d」用户会话「%s」不存在", accountID, req.SessionID)
+		return bizErr.New(bizErr.BAD_REQUEST, "会话不存在或已失效")
+	}
+	return nil
+}
+
+// RevokeAllSessions 注销当前账户在全部设备上的登录会话，包括当前会话
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func RevokeAllSessions(c echo.Context) error {
+	accountID, _ := utils.GetAuthAccountID(c)
+	return revokeAccountSessions(c, accountID)
+}
+
+// RefreshToken 使用 Refresh Token 换取新的令牌对，旧的 Refresh Token 随即作废；
+// 已作废的 Refresh Token 被再次使用时注销其所属会话
+// 参数：
+//   - c: Echo 上下文
+//   - req: 刷新令牌请求
+//
+// 返回值：
+//   - *account.LoginVO: 新的访问令牌与刷新令牌
+//   - error: 操作过程中的错误
+func RefreshToken(c echo.Context, req *dto.RefreshTokenRequest) (*account.LoginVO, error) {
+	tokens, err := utils.RefreshTokenLogic(c, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, utils.ErrRefreshTokenReused) {
+			utils.BizLogger(c).Warnf("检测到 refresh token 重复使用，已注销所属会话")
+			return nil, bizErr.New(bizErr.UNAUTHORIZED, "Refresh-Token 已被使用，当前会话已注销，请重新登录")
+		}
+		utils.BizLogger(c).Warnf("刷新令牌失败: %v", err)
+		return nil, bizErr.New(bizErr.UNAUTHORIZED, "Refresh-Token 无效或已过期，请重新登录")
+	}
+
+	return &account.LoginVO{
+		AccessToken:  tokens["Authorization"],
+		RefreshToken: tokens["Refresh-Token"],
+	}, nil
+}
@@ -0,0 +1,119 @@
+// Package service 账户业务测试环境：测试配置、内存 SQLite 与 miniredis
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/model"
+	"jank.com/jank_blog/internal/utils"
+)
+
+// testConfigTemplate 测试配置，%s 依次替换为 OIDC 提供方的 Issuer
+const testConfigTemplate = `
+APP:
+  SITE:
+    SITE_URL: "https://blog.example.com"
+  JWT:
+    ACTIVE_KID: "test"
+    ACCESS_TOKEN_EXPIRE: 7200
+    REFRESH_TOKEN_EXPIRE: 172800
+    KEYS:
+      - KID: "test"
+        ALGORITHM: "HS256"
+        SECRET: "jank-blog-test-secret-0123456789abcdef"
+  OAUTH:
+    OAUTH_ENABLED: true
+    REDIRECT_URL: "https://blog.example.com/oauth/callback/{provider}"
+    ALLOW_PRIVATE: true
+    PROVIDERS:
+      - NAME: "mock"
+        TYPE: "oidc"
+        ISSUER: "%s"
+        CLIENT_ID: "jank-client"
+        CLIENT_SECRET: "jank-secret"
+`
+
+// setupTestEnv 使用内存 SQLite 与 miniredis 替换全局连接，并加载指向测试提供方的配置
+// 参数：
+//   - t: 测试对象
+//   - issuer: OIDC 提供方的 Issuer
+//
+// 返回值：
+//   - *miniredis.Miniredis: 内存 Redis 服务
+func setupTestEnv(t *testing.T, issuer string) *miniredis.Miniredis {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(fmt.Sprintf(testConfigTemplate, issuer)), 0o600); err != nil {
+		t.Fatalf("写入测试配置失败: %v", err)
+	}
+	if err := configs.Init(path); err != nil {
+		t.Fatalf("加载测试配置失败: %v", err)
+	}
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("打开 SQLite 失败: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("获取 SQLite 连接失败: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(model.GetAllModels()...); err != nil {
+		t.Fatalf("迁移测试表失败: %v", err)
+	}
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	previousDB, previousRedis := global.DB, global.RedisClient
+	global.DB, global.RedisClient = db, client
+	t.Cleanup(func() {
+		global.DB, global.RedisClient = previousDB, previousRedis
+		client.Close()
+		sqlDB.Close()
+	})
+
+	if global.SysLog == nil {
+		log := logrus.New()
+		log.SetLevel(logrus.PanicLevel)
+		global.SysLog = log
+	}
+	return server
+}
+
+// newTestContext 创建测试用的 Echo 上下文，accountID 不为 0 时模拟已登录的会话
+// 参数：
+//   - accountID: 账户ID
+//   - sessionID: 会话ID
+//
+// 返回值：
+//   - echo.Context: Echo 上下文
+func newTestContext(accountID int64, sessionID string) echo.Context {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	if accountID != 0 {
+		c.Set(utils.AUTH_ACCOUNT_ID_CONTEXT_KEY, accountID)
+		c.Set(utils.AUTH_ACCOUNT_ROLE_CONTEXT_KEY, utils.ROLE_COMMENTER)
+		c.Set(utils.AUTH_SESSION_ID_CONTEXT_KEY, sessionID)
+	}
+	return c
+}
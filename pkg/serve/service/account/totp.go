@@ -0,0 +1,446 @@
+// Package service 提供业务逻辑处理，处理双因素认证相关业务
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+
+	"jank.com/jank_blog/configs"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/account"
+	auditModel "jank.com/jank_blog/internal/model/audit"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+const (
+	LOGIN_CHALLENGE_CACHE_PREFIX = "LOGIN:TOTP:CHALLENGE:" // 登录挑战令牌缓存前缀
+	LOGIN_CHALLENGE_EXPIRE_TIME  = 5 * time.Minute         // 登录挑战令牌有效期
+	LOGIN_CHALLENGE_TOKEN_BYTES  = 32                      // 登录挑战令牌随机字节数
+
+	TOTP_ATTEMPT_SCOPE  = "totp"          // 第二因素校验限流场景
+	TOTP_ATTEMPT_LIMIT  = 5               // 单个账户在统计窗口内允许连续校验失败的次数
+	TOTP_ATTEMPT_WINDOW = 5 * time.Minute // 第二因素校验次数统计窗口
+)
+
+// createLoginChallenge 为通过密码校验的账户签发短时有效的登录挑战令牌，缓存中仅保存令牌摘要
+// 参数：
+//   - c: Echo 上下文
+//   - acc: 账户
+//
+// 返回值：
+//   - *account.LoginVO: 仅包含登录挑战令牌的视图对象
+//   - error: 操作过程中的错误
+func createLoginChallenge(c echo.Context, acc *model.Account) (*account.LoginVO, error) {
+	buf := make([]byte, LOGIN_CHALLENGE_TOKEN_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		utils.BizLogger(c).Errorf("生成登录挑战令牌失败: %v", err)
+		return nil, fmt.Errorf("生成登录挑战令牌失败: %w", err)
+	}
+	challengeToken := base64.RawURLEncoding.EncodeToString(buf)
+
+	key := LOGIN_CHALLENGE_CACHE_PREFIX + hashChallengeToken(challengeToken)
+	if err := global.RedisClient.Set(c.Request().Context(), key, acc.ID, LOGIN_CHALLENGE_EXPIRE_TIME).Err(); err != nil {
+		utils.BizLogger(c).Errorf("登录挑战令牌写入缓存失败: %v", err)
+		return nil, fmt.Errorf("登录挑战令牌写入缓存失败: %w", err)
+	}
+
+	return &account.LoginVO{
+		TwoFactorRequired: true,
+		ChallengeToken:    challengeToken,
+	}, nil
+}
+
+// VerifyTotpLogin 登录第二步，校验 TOTP 验证码或恢复码后签发访问令牌，登录挑战令牌随即失效
+// 参数：
+//   - c: Echo 上下文
+//   - req: 双因素认证登录请求
+//
+// 返回值：
+//   - *account.LoginVO: 登录成功后的令牌视图对象
+//   - error: 操作过程中的错误
+func VerifyTotpLogin(c echo.Context, req *dto.VerifyTotpLoginRequest) (*account.LoginVO, error) {
+	key := LOGIN_CHALLENGE_CACHE_PREFIX + hashChallengeToken(req.ChallengeToken)
+	accountIDStr, err := global.RedisClient.Get(c.Request().Context(), key).Result()
+	if errors.Is(err, redis.Nil) {
+		utils.BizLogger(c).Warn("登录挑战令牌不存在或已过期")
+		return nil, bizErr.New(bizErr.BAD_REQUEST, "登录已过期，请重新输入密码登录")
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("读取登录挑战令牌失败: %v", err)
+		return nil, fmt.Errorf("读取登录挑战令牌失败: %w", err)
+	}
+
+	accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+	if err != nil {
+		utils.BizLogger(c).Errorf("登录挑战令牌内容无效: %v", err)
+		return nil, fmt.Errorf("登录挑战令牌内容无效: %w", err)
+	}
+
+	acc, err := mapper.GetAccountByAccountID(c, accountID)
+	if err != nil || !acc.TotpEnabled {
+		utils.BizLogger(c).Errorf("「%d」用户不存在或未启用双因素认证: %v", accountID, err)
+		return nil, bizErr.New(bizErr.BAD_REQUEST, "登录已过期，请重新输入密码登录")
+	}
+
+	if err := checkLoginLock(c, acc.Email); err != nil {
+		global.RedisClient.Del(c.Request().Context(), key)
+		return nil, err
+	}
+
+	if err := verifySecondFactor(c, acc, req.Code, req.RecoveryCode); err != nil {
+		// 验证码错误同样计入登录失败次数，防止持有密码者绕过登录锁定穷举验证码
+		var e *bizErr.Err
+		if errors.As(err, &e) && e.Code == bizErr.BAD_REQUEST {
+			recordLoginAudit(c, auditModel.AUDIT_ACTION_LOGIN_FAILED, acc.Email, acc.ID, "双因素认证"+e.Msg)
+			err = recordLoginFailure(c, acc.Email, acc, err)
+		}
+		// 校验次数超限或触发登录锁定时作废登录挑战令牌，须重新输入密码
+		if errors.As(err, &e) && (e.Code == bizErr.TOO_MANY_REQUESTS || e.Code == bizErr.ACCOUNT_LOCKED) {
+			global.RedisClient.Del(c.Request().Context(), key)
+		}
+		return nil, err
+	}
+
+	// 删除成功者才能换取令牌，防止同一挑战令牌被并发重复使用
+	deleted, err := global.RedisClient.Del(c.Request().Context(), key).Result()
+	if err != nil {
+		utils.BizLogger(c).Errorf("删除登录挑战令牌失败: %v", err)
+		return nil, fmt.Errorf("删除登录挑战令牌失败: %w", err)
+	}
+	if deleted == 0 {
+		return nil, bizErr.New(bizErr.BAD_REQUEST, "登录已过期，请重新输入密码登录")
+	}
+
+	resetLoginFailures(c, acc.Email)
+	return issueLoginTokens(c, acc, "双因素认证登录")
+}
+
+// EnrollTotp 为当前账户生成待确认的 TOTP 密钥，返回 otpauth URI 与二维码，确认启用前不生效
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - *account.TotpEnrollmentVO: 双因素认证绑定信息
+//   - error: 操作过程中的错误
+func EnrollTotp(c echo.Context) (*account.TotpEnrollmentVO, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return nil, fmt.Errorf("加载配置失败: %w", err)
+	}
+
+	var enrollmentVO *account.TotpEnrollmentVO
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		acc, err := getAuthAccount(c)
+		if err != nil {
+			return err
+		}
+		if acc.TotpEnabled {
+			utils.BizLogger(c).Warnf("「%s」用户已启用双因素认证", acc.Email)
+			return bizErr.New(bizErr.BAD_REQUEST, "已启用双因素认证，请先关闭后再重新绑定")
+		}
+
+		key, err := utils.GenerateTOTPKey(cfg.AppConfig.AppName, acc.Email)
+		if err != nil {
+			utils.BizLogger(c).Errorf("生成 TOTP 密钥失败: %v", err)
+			return fmt.Errorf("生成 TOTP 密钥失败: %w", err)
+		}
+		qrCode, err := utils.TOTPQRCodeBase64(key)
+		if err != nil {
+			utils.BizLogger(c).Errorf("生成 TOTP 二维码失败: %v", err)
+			return fmt.Errorf("生成 TOTP 二维码失败: %w", err)
+		}
+
+		acc.TotpSecret = key.Secret()
+		acc.TotpLastStep = 0
+		if err := mapper.UpdateAccount(c, acc); err != nil {
+			utils.BizLogger(c).Errorf("保存「%s」用户 TOTP 密钥失败: %v", acc.Email, err)
+			return fmt.Errorf("保存「%s」用户 TOTP 密钥失败: %w", acc.Email, err)
+		}
+
+		enrollmentVO = &account.TotpEnrollmentVO{
+			Secret:     key.Secret(),
+			OtpauthURI: key.URL(),
+			QRCode:     qrCode,
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return enrollmentVO, nil
+}
+
+// EnableTotp 校验待确认密钥生成的验证码后启用双因素认证，并生成一次性恢复码
+// 参数：
+//   - c: Echo 上下文
+//   - req: 启用双因素认证请求
+//
+// 返回值：
+//   - *account.TotpRecoveryCodesVO: 恢复码，仅展示一次
+//   - error: 操作过程中的错误
+func EnableTotp(c echo.Context, req *dto.EnableTotpRequest) (*account.TotpRecoveryCodesVO, error) {
+	var recoveryVO *account.TotpRecoveryCodesVO
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		acc, err := getAuthAccount(c)
+		if err != nil {
+			return err
+		}
+		if acc.TotpEnabled {
+			return bizErr.New(bizErr.BAD_REQUEST, "已启用双因素认证")
+		}
+		if acc.TotpSecret == "" {
+			return bizErr.New(bizErr.BAD_REQUEST, "请先获取双因素认证绑定二维码")
+		}
+
+		if err := verifySecondFactor(c, acc, req.Code, ""); err != nil {
+			return err
+		}
+
+		acc.TotpEnabled = true
+		if err := mapper.UpdateAccount(c, acc); err != nil {
+			utils.BizLogger(c).Errorf("启用「%s」用户双因素认证失败: %v", acc.Email, err)
+			return fmt.Errorf("启用「%s」用户双因素认证失败: %w", acc.Email, err)
+		}
+
+		codes, err := replaceRecoveryCodes(c, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		recoveryVO = &account.TotpRecoveryCodesVO{RecoveryCodes: codes}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryVO, nil
+}
+
+// DisableTotp 凭当前密码、身份验证令牌或 TOTP 验证码关闭双因素认证，并作废密钥与恢复码
+// 参数：
+//   - c: Echo 上下文
+//   - req: 关闭双因素认证请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func DisableTotp(c echo.Context, req *dto.DisableTotpRequest) error {
+	return utils.RunDBTransaction(c, func(tx error) error {
+		acc, err := getAuthAccount(c)
+		if err != nil {
+			return err
+		}
+		if !acc.TotpEnabled {
+			return bizErr.New(bizErr.BAD_REQUEST, "未启用双因素认证")
+		}
+
+		if req.Code != "" {
+			if err := verifySecondFactor(c, acc, req.Code, ""); err != nil {
+				return err
+			}
+		} else if err := verifyAccountCredential(c, acc, req.Password, req.ReauthToken); err != nil {
+			return err
+		}
+
+		acc.TotpEnabled = false
+		acc.TotpSecret = ""
+		acc.TotpLastStep = 0
+		if err := mapper.UpdateAccount(c, acc); err != nil {
+			utils.BizLogger(c).Errorf("关闭「%s」用户双因素认证失败: %v", acc.Email, err)
+			return fmt.Errorf("关闭「%s」用户双因素认证失败: %w", acc.Email, err)
+		}
+
+		if err := mapper.DeleteRecoveryCodesByAccountID(c, acc.ID); err != nil {
+			utils.BizLogger(c).Errorf("作废「%s」用户恢复码失败: %v", acc.Email, err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// RegenerateRecoveryCodes 凭 TOTP 验证码作废旧恢复码并生成新的恢复码
+// 参数：
+//   - c: Echo 上下文
+//   - req: 重新生成恢复码请求
+//
+// 返回值：
+//   - *account.TotpRecoveryCodesVO: 新的恢复码，仅展示一次
+//   - error: 操作过程中的错误
+func RegenerateRecoveryCodes(c echo.Context, req *dto.RegenerateRecoveryCodesRequest) (*account.TotpRecoveryCodesVO, error) {
+	var recoveryVO *account.TotpRecoveryCodesVO
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		acc, err := getAuthAccount(c)
+		if err != nil {
+			return err
+		}
+		if !acc.TotpEnabled {
+			return bizErr.New(bizErr.BAD_REQUEST, "未启用双因素认证")
+		}
+
+		if err := verifySecondFactor(c, acc, req.Code, ""); err != nil {
+			return err
+		}
+
+		codes, err := replaceRecoveryCodes(c, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		recoveryVO = &account.TotpRecoveryCodesVO{RecoveryCodes: codes}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryVO, nil
+}
+
+// verifySecondFactor 校验 TOTP 验证码或恢复码，通过后记录时间步或作废恢复码；单个账户连续校验失败的次数受限
+// 参数：
+//   - c: Echo 上下文
+//   - acc: 账户
+//   - code: TOTP 验证码
+//   - recoveryCode: 恢复码，TOTP 验证码为空时使用
+//
+// 返回值：
+//   - error: 校验失败时返回业务错误
+func verifySecondFactor(c echo.Context, acc *model.Account, code, recoveryCode string) error {
+	identity := strconv.FormatInt(acc.ID, 10)
+	allowed, err := utils.AllowRequest(c, TOTP_ATTEMPT_SCOPE, identity, TOTP_ATTEMPT_LIMIT, TOTP_ATTEMPT_WINDOW)
+	if err != nil {
+		utils.BizLogger(c).Errorf("双因素认证限流失败: %v", err)
+		return fmt.Errorf("双因素认证限流失败: %w", err)
+	}
+	if !allowed {
+		utils.BizLogger(c).Warnf("「%s」用户双因素认证校验次数过多", acc.Email)
+		return bizErr.New(bizErr.TOO_MANY_REQUESTS, "验证码校验次数过多，请稍后再试")
+	}
+
+	if code != "" {
+		step, ok := utils.ValidateTOTPCode(acc.TotpSecret, code, acc.TotpLastStep, time.Now())
+		if !ok {
+			utils.BizLogger(c).Warnf("「%s」用户 TOTP 验证码错误", acc.Email)
+			return bizErr.New(bizErr.BAD_REQUEST, "验证码错误")
+		}
+
+		acc.TotpLastStep = step
+		if err := mapper.UpdateAccount(c, acc); err != nil {
+			utils.BizLogger(c).Errorf("记录「%s」用户 TOTP 时间步失败: %v", acc.Email, err)
+			return fmt.Errorf("记录「%s」用户 TOTP 时间步失败: %w", acc.Email, err)
+		}
+		return resetSecondFactorAttempts(c, identity)
+	}
+
+	consumed, err := mapper.ConsumeRecoveryCode(c, acc.ID, utils.HashRecoveryCode(recoveryCode))
+	if err != nil {
+		utils.BizLogger(c).Errorf("校验「%s」用户恢复码失败: %v", acc.Email, err)
+		return err
+	}
+	if !consumed {
+		utils.BizLogger(c).Warnf("「%s」用户恢复码错误或已使用", acc.Email)
+		return bizErr.New(bizErr.BAD_REQUEST, "恢复码错误或已使用")
+	}
+
+	if remaining, err := mapper.CountRecoveryCodes(c, acc.ID); err == nil {
+		utils.BizLogger(c).Infof("「%s」用户使用了恢复码，剩余 %d 个", acc.Email, remaining)
+	}
+	return resetSecondFactorAttempts(c, identity)
+}
+
+// resetSecondFactorAttempts 校验通过后清除账户的第二因素失败计数，清除失败仅记录日志
+// 参数：
+//   - c: Echo 上下文
+//   - identity: 限流对象标识
+//
+// 返回值：
+//   - error: 始终为 nil
+func resetSecondFactorAttempts(c echo.Context, identity string) error {
+	if err := utils.ResetRequestLimit(c, TOTP_ATTEMPT_SCOPE, identity); err != nil {
+		utils.BizLogger(c).Warnf("清除双因素认证失败计数失败: %v", err)
+	}
+	return nil
+}
+
+// replaceRecoveryCodes 作废账户的旧恢复码并生成新的恢复码，库中仅保存摘要
+// 参数：
+//   - c: Echo 上下文
+//   - accountID: 账户ID
+//
+// 返回值：
+//   - []string: 新的恢复码明文
+//   - error: 操作过程中的错误
+func replaceRecoveryCodes(c echo.Context, accountID int64) ([]string, error) {
+	if err := mapper.DeleteRecoveryCodesByAccountID(c, accountID); err != nil {
+		utils.BizLogger(c).Errorf("作废「%d」用户恢复码失败: %v", accountID, err)
+		return nil, err
+	}
+
+	codes, err := utils.GenerateRecoveryCodes()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成恢复码失败: %v", err)
+		return nil, err
+	}
+
+	records := make([]*model.RecoveryCode, 0, len(codes))
+	for _, code := range codes {
+		records = append(records, &model.RecoveryCode{AccountID: accountID, CodeHash: utils.HashRecoveryCode(code)})
+	}
+	if err := mapper.CreateRecoveryCodes(c, records); err != nil {
+		utils.BizLogger(c).Errorf("保存「%d」用户恢复码失败: %v", accountID, err)
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// getAuthAccount 获取当前请求已认证的账户
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - *model.Account: 账户
+//   - error: 操作过程中的错误
+func getAuthAccount(c echo.Context) (*model.Account, error) {
+	accountID, _ := utils.GetAuthAccountID(c)
+	acc, err := mapper.GetAccountByAccountID(c, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」用户不存在: %v", accountID, err)
+		return nil, fmt.Errorf("「%d」用户不存在: %w", accountID, err)
+	}
+	return acc, nil
+}
+
+// hashChallengeToken 计算登录挑战令牌的 SHA-256 摘要
+// 参数：
+//   - token: 登录挑战令牌
+//
+// 返回值：
+//   - string: 十六进制摘要
+func hashChallengeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
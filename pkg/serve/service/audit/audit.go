@@ -0,0 +1,235 @@
+// Package service 提供业务逻辑处理，处理审计日志相关业务
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/audit"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/audit/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/audit"
+)
+
+const (
+	DEFAULT_AUDIT_PAGE_SIZE = 20        // 审计日志默认每页条数
+	AUDIT_CLEANUP_INTERVAL  = time.Hour // 过期审计日志清理间隔
+
+	auditRedactedValue = "******" // 敏感配置项在审计日志中的占位值
+)
+
+// auditSensitiveKeywords 配置项名称包含以下关键词时不记录具体取值
+var auditSensitiveKeywords = []string{"secret", "password", "psw", "apikey", "accesskey", "sessiontoken", "keys", "providers"}
+
+var startOnce sync.Once
+
+// Event 审计事件
+type Event struct {
+	Action     string                 // 审计事件
+	ActorID    int64                  // 操作者账户 ID，为 0 时取当前登录账户
+	ActorEmail string                 // 操作者邮箱，登录相关事件记录提交的邮箱
+	TargetType string                 // 审计对象类型
+	TargetID   string                 // 审计对象标识
+	Before     map[string]interface{} // 变更前摘要
+	After      map[string]interface{} // 变更后摘要
+	Detail     string                 // 补充说明
+}
+
+// Start 注册配置热更新审计并启动过期审计日志清理协程，启动时调用一次
+func Start() {
+	startOnce.Do(func() {
+		configs.OnReload(recordConfigReload)
+		go runRetention()
+	})
+}
+
+// Record 追加一条由当前请求触发的审计日志，写入失败只记录日志，不影响业务结果
+// 审计日志使用独立的数据库连接写入，须在业务事务提交后调用，确保只记录已生效的变更
+// 参数：
+//   - c: Echo 上下文
+//   - event: 审计事件
+func Record(c echo.Context, event *Event) {
+	actorID := event.ActorID
+	if actorID == 0 {
+		actorID, _ = utils.GetAuthAccountID(c)
+	}
+
+	write(&model.AuditLog{
+		Action:     event.Action,
+		ActorID:    actorID,
+		ActorEmail: event.ActorEmail,
+		IP:         c.RealIP(),
+		RequestID:  c.Response().Header().Get(echo.HeaderXRequestID),
+		TargetType: event.TargetType,
+		TargetID:   event.TargetID,
+		Before:     event.Before,
+		After:      event.After,
+		Detail:     event.Detail,
+	})
+}
+
+// ContentDigest 生成正文摘要，审计日志不保存正文，仅凭摘要判断正文是否变化
+// 参数：
+//   - content: 正文
+//
+// 返回值：
+//   - string: 正文 SHA-256 摘要的前 16 位十六进制字符
+func ContentDigest(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// ListAuditLogs 按条件分页查询审计日志
+// 参数：
+//   - c: Echo 上下文
+//   - req: 查询审计日志请求
+//
+// 返回值：
+//   - *audit.AuditLogPageVO: 审计日志分页结果
+//   - error: 操作过程中的错误
+func ListAuditLogs(c echo.Context, req *dto.ListAuditLogsRequest) (*audit.AuditLogPageVO, error) {
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = DEFAULT_AUDIT_PAGE_SIZE
+	}
+
+	filter := &mapper.AuditLogFilter{
+		Action:     req.Action,
+		ActorID:    req.ActorID,
+		IP:         req.IP,
+		RequestID:  req.RequestID,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+	}
+	logs, total, err := mapper.GetAuditLogsWithPaging(c, filter, page, pageSize)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询审计日志失败: %v", err)
+		return nil, fmt.Errorf("查询审计日志失败: %w", err)
+	}
+
+	pageVO := &audit.AuditLogPageVO{
+		Logs:        make([]*audit.AuditLogVO, 0, len(logs)),
+		Total:       total,
+		TotalPages:  int(math.Ceil(float64(total) / float64(pageSize))),
+		CurrentPage: page,
+	}
+	for _, log := range logs {
+		vo, err := utils.MapModelToVO(log, &audit.AuditLogVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("查询审计日志时映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("查询审计日志时映射 VO 失败: %w", err)
+		}
+		pageVO.Logs = append(pageVO.Logs, vo.(*audit.AuditLogVO))
+	}
+
+	return pageVO, nil
+}
+
+// write 写入审计日志
+// 参数：
+//   - log: 审计日志
+func write(log *model.AuditLog) {
+	if err := mapper.CreateAuditLog(utils.NewDetachedContext(), log); err != nil {
+		global.SysLog.Errorf("写入「%s」审计日志失败: %v", log.Action, err)
+	}
+}
+
+// recordConfigReload 记录配置热更新，敏感配置项只记录发生了变化，不记录取值
+// 参数：
+//   - changes: 发生变化的配置项路径及其变更前后的值
+func recordConfigReload(changes map[string][2]interface{}) {
+	before := make(map[string]interface{}, len(changes))
+	after := make(map[string]interface{}, len(changes))
+	paths := make([]string, 0, len(changes))
+	for path, values := range changes {
+		paths = append(paths, path)
+		if isSensitiveConfig(path) {
+			before[path], after[path] = auditRedactedValue, auditRedactedValue
+			continue
+		}
+		before[path], after[path] = values[0], values[1]
+	}
+
+	write(&model.AuditLog{
+		Action:     model.AUDIT_ACTION_CONFIG_RELOAD,
+		TargetType: model.AUDIT_TARGET_CONFIG,
+		TargetID:   configs.ConfigFilePath(),
+		Before:     before,
+		After:      after,
+		Detail:     fmt.Sprintf("%d 个配置项发生变化", len(paths)),
+	})
+}
+
+// isSensitiveConfig 判断配置项是否包含密钥等敏感信息
+// 参数：
+//   - path: 配置项路径，如 AppConfig.JWT.Keys
+//
+// 返回值：
+//   - bool: 是否为敏感配置项
+func isSensitiveConfig(path string) bool {
+	name := strings.ToLower(path[strings.LastIndex(path, ".")+1:])
+	for _, keyword := range auditSensitiveKeywords {
+		if strings.Contains(name, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// runRetention 定期按配置的保留天数清理过期审计日志
+func runRetention() {
+	ticker := time.NewTicker(AUDIT_CLEANUP_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		purgeExpiredLogs()
+		<-ticker.C
+	}
+}
+
+// purgeExpiredLogs 清理超过保留天数的审计日志
+func purgeExpiredLogs() {
+	defer func() {
+		if r := recover(); r != nil {
+			global.SysLog.Errorf("清理过期审计日志时发生 panic: %v", r)
+		}
+	}()
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		global.SysLog.Errorf("加载审计日志配置失败: %v", err)
+		return
+	}
+	retentionDays := cfg.AppConfig.Audit.RetentionDays
+	if retentionDays <= 0 {
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -retentionDays).Unix()
+	deleted, err := mapper.DeleteAuditLogsBefore(utils.NewDetachedContext(), cutoff)
+	if err != nil {
+		global.SysLog.Errorf("清理过期审计日志失败: %v", err)
+		return
+	}
+	if deleted > 0 {
+		global.SysLog.Infof("已清理 %d 条超过 %d 天的审计日志", deleted, retentionDays)
+	}
+}
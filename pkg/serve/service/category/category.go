@@ -5,14 +5,17 @@ package service
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 
+	auditModel "jank.com/jank_blog/internal/model/audit"
 	model "jank.com/jank_blog/internal/model/category"
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/category/dto"
 	"jank.com/jank_blog/pkg/serve/mapper"
+	audit "jank.com/jank_blog/pkg/serve/service/audit"
 	"jank.com/jank_blog/pkg/vo/category"
 )
 
@@ -37,7 +40,31 @@ func GetCategoryByID(c echo.Context, req *dto.GetOneCategoryRequest) (*category.
 		return nil, fmt.Errorf("获取类目时映射 VO 失败: %w", err)
 	}
 
-	return categoryVO.(*category.CategoriesVO), nil
+	catVO := categoryVO.(*category.CategoriesVO)
+
+	counts, err := getCategoryPostCounts(c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取类目文章数量失败: %v", err)
+		return nil, fmt.Errorf("获取类目文章数量失败: %w", err)
+	}
+
+	categories, err := mapper.GetAllActivatedCategories(c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取类目文章数量失败: %v", err)
+		return nil, fmt.Errorf("获取类目文章数量失败: %w", err)
+	}
+
+	// 后代类目的路径均以 自身路径/自身 ID 为前缀
+	childrenPath := fmt.Sprintf("%s/%d", cat.Path, cat.ID)
+	catVO.PostCount = counts[cat.ID]
+	catVO.TotalPostCount = catVO.PostCount
+	for _, descendant := range categories {
+		if descendant.Path == childrenPath || strings.HasPrefix(descendant.Path, childrenPath+"/") {
+			catVO.TotalPostCount += counts[descendant.ID]
+		}
+	}
+
+	return catVO, nil
 }
 
 // GetCategoryTree 获取类目树
@@ -84,6 +111,13 @@ func GetCategoryTree(c echo.Context) ([]*category.CategoriesVO, error) {
 		rootCategoriesVO = append(rootCategoriesVO, rootCategoryVO)
 	}
 
+	counts, err := getCategoryPostCounts(c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取类目文章数量失败: %v", err)
+		return nil, fmt.Errorf("获取类目文章数量失败: %w", err)
+	}
+	fillCategoryPostCounts(rootCategoriesVO, counts)
+
 	return rootCategoriesVO, nil
 }
 
@@ -139,9 +173,79 @@ func GetCategoryChildrenByID(c echo.Context, req *dto.GetOneCategoryRequest) ([]
 		}
 	}
 
+	counts, err := getCategoryPostCounts(c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取类目文章数量失败: %v", err)
+		return nil, fmt.Errorf("获取类目文章数量失败: %w", err)
+	}
+	fillCategoryPostCounts(childrenVO, counts)
+
 	return childrenVO, nil
 }
 
+// GetCategoryBreadcrumb 根据类目路径获取从根类目到当前类目的祖先链
+// 参数：
+//   - c: Echo 上下文
+//   - req: 获取类目请求
+//
+// 返回值：
+//   - []*category.CategoriesVO: 从根类目到当前类目的有序列表
+//   - error: 操作过程中的错误
+func GetCategoryBreadcrumb(c echo.Context, req *dto.GetOneCategoryRequest) ([]*category.CategoriesVO, error) {
+	cat, err := mapper.GetCategoryByID(c, req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("根据 ID 获取类目失败: %v", err)
+		return nil, fmt.Errorf("根据 ID 获取类目失败: %w", err)
+	}
+
+	var ancestorIDs []int64
+	for _, segment := range strings.Split(cat.Path, "/") {
+		if segment == "" {
+			continue
+		}
+		ancestorID, err := strconv.ParseInt(segment, 10, 64)
+		if err != nil {
+			utils.BizLogger(c).Errorf("类目「%d」路径「%s」格式错误", cat.ID, cat.Path)
+			return nil, fmt.Errorf("类目「%d」路径「%s」格式错误", cat.ID, cat.Path)
+		}
+		ancestorIDs = append(ancestorIDs, ancestorID)
+	}
+
+	ancestors, err := mapper.GetCategoriesByIDs(c, ancestorIDs)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取祖先类目失败: %v", err)
+		return nil, fmt.Errorf("获取祖先类目失败: %w", err)
+	}
+
+	ancestorMap := make(map[int64]*model.Category, len(ancestors))
+	for _, ancestor := range ancestors {
+		ancestorMap[ancestor.ID] = ancestor
+	}
+
+	chain := make([]*model.Category, 0, len(ancestorIDs)+1)
+	for _, ancestorID := range ancestorIDs {
+		ancestor, exists := ancestorMap[ancestorID]
+		if !exists {
+			utils.BizLogger(c).Errorf("类目「%d」的祖先类目「%d」不存在", cat.ID, ancestorID)
+			return nil, fmt.Errorf("类目「%d」的祖先类目「%d」不存在", cat.ID, ancestorID)
+		}
+		chain = append(chain, ancestor)
+	}
+	chain = append(chain, cat)
+
+	breadcrumb := make([]*category.CategoriesVO, 0, len(chain))
+	for _, node := range chain {
+		nodeVO, err := buildCategoryVOTree(c, node)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取类目面包屑时映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("获取类目面包屑时映射 VO 失败: %w", err)
+		}
+		breadcrumb = append(breadcrumb, nodeVO)
+	}
+
+	return breadcrumb, nil
+}
+
 // CreateCategory 创建类目
 // 参数：
 //   - c: Echo 上下文
@@ -152,9 +256,10 @@ func GetCategoryChildrenByID(c echo.Context, req *dto.GetOneCategoryRequest) ([]
 //   - error: 操作过程中的错误
 func CreateCategory(c echo.Context, req *dto.CreateOneCategoryRequest) (*category.CategoriesVO, error) {
 	var categoryVO *category.CategoriesVO
+	var newCategory *model.Category
 
 	err := utils.RunDBTransaction(c, func(tx error) error {
-		newCategory := &model.Category{
+		newCategory = &model.Category{
 			Name:        req.Name,
 			Description: req.Description,
 			ParentID:    req.ParentID,
@@ -176,6 +281,14 @@ func CreateCategory(c echo.Context, req *dto.CreateOneCategoryRequest) (*categor
 			}
 		}
 
+		// 新类目追加到同级末尾
+		position, err := mapper.GetNextCategoryPosition(c, req.ParentID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取类目排序位置失败: %v", err)
+			return fmt.Errorf("获取类目排序位置失败: %w", err)
+		}
+		newCategory.Position = position
+
 		// 创建类目
 		if err := mapper.CreateCategory(c, newCategory); err != nil {
 			utils.BizLogger(c).Errorf("创建类目失败: %v", err)
@@ -196,6 +309,7 @@ func CreateCategory(c echo.Context, req *dto.CreateOneCategoryRequest) (*categor
 		return nil, err
 	}
 
+	recordCategoryAudit(c, auditModel.AUDIT_ACTION_CATEGORY_CREATE, newCategory.ID, nil, categoryAuditSummary(newCategory))
 	return categoryVO, nil
 }
 
@@ -209,6 +323,7 @@ func CreateCategory(c echo.Context, req *dto.CreateOneCategoryRequest) (*categor
 //   - error: 操作过程中的错误
 func UpdateCategory(c echo.Context, req *dto.UpdateOneCategoryRequest) (*category.CategoriesVO, error) {
 	var updatedVO *category.CategoriesVO
+	var before, after map[string]interface{}
 
 	err := utils.RunDBTransaction(c, func(tx error) error {
 		existingCategory, err := mapper.GetCategoryByID(c, req.ID)
@@ -216,53 +331,23 @@ func UpdateCategory(c echo.Context, req *dto.UpdateOneCategoryRequest) (*categor
 			utils.BizLogger(c).Errorf("获取类目失败: %v", err)
 			return fmt.Errorf("获取类目失败: %w", err)
 		}
+		before = categoryAuditSummary(existingCategory)
 
-		if req.ParentID == req.ID {
-			utils.BizLogger(c).Error("父类目不能设置为自身")
-			return fmt.Errorf("父类目不能设置为自身")
-		}
-
-		var parentPath string
-		if req.ParentID != 0 {
-			parentCategory, err := mapper.GetCategoryByID(c, req.ParentID)
-			if err != nil {
-				utils.BizLogger(c).Errorf("获取父类目「%d」失败: %v", req.ParentID, err)
-				return fmt.Errorf("获取父类目「%d」失败: %w", req.ParentID, err)
-			}
-
-			if parentCategory.Path != "" && strings.Contains(parentCategory.Path, fmt.Sprintf("/%d/", req.ID)) {
-				utils.BizLogger(c).Errorf("检测到循环引用: 无法将子类目「%d」设置为父类目", req.ID)
-				return fmt.Errorf("检测到循环引用: 无法将子类目「%d」设置为父类目", req.ID)
-			}
-
-			parentPath = parentCategory.Path
-		}
-
-		oldPath := existingCategory.Path
 		existingCategory.Name = req.Name
 		existingCategory.Description = req.Description
-		existingCategory.ParentID = req.ParentID
 
-		if req.ParentID == 0 {
-			existingCategory.Path = ""
-		} else if parentPath == "" {
-			existingCategory.Path = fmt.Sprintf("/%d", req.ParentID)
-		} else {
-			existingCategory.Path = fmt.Sprintf("%s/%d", parentPath, req.ParentID)
-		}
-
-		if err := mapper.UpdateCategory(c, existingCategory); err != nil {
+		if req.ParentID != existingCategory.ParentID {
+			// 父类目变更时追加到新父类目下的末尾
+			if err := moveCategoryTo(c, existingCategory, req.ParentID, -1); err != nil {
+				utils.BizLogger(c).Errorf("移动类目失败: %v", err)
+				return fmt.Errorf("移动类目失败: %w", err)
+			}
+		} else if err := mapper.UpdateCategory(c, existingCategory); err != nil {
 			utils.BizLogger(c).Errorf("更新类目失败: %v", err)
 			return fmt.Errorf("更新类目失败: %w", err)
 		}
 
-		if oldPath != existingCategory.Path {
-			if err := recursivelyUpdateChildrenPaths(c, existingCategory); err != nil {
-				utils.BizLogger(c).Errorf("更新子类目路径失败: %v", err)
-				return fmt.Errorf("更新子类目路径失败: %w", err)
-			}
-		}
-
+		after = categoryAuditSummary(existingCategory)
 		vo, err := buildCategoryVOTree(c, existingCategory)
 		if err != nil {
 			utils.BizLogger(c).Errorf("更新类目时映射 VO 失败: %v", err)
@@ -277,19 +362,69 @@ func UpdateCategory(c echo.Context, req *dto.UpdateOneCategoryRequest) (*categor
 		return nil, err
 	}
 
+	recordCategoryAudit(c, auditModel.AUDIT_ACTION_CATEGORY_UPDATE, req.ID, before, after)
 	return updatedVO, nil
 }
 
-// DeleteCategory 软删除类目
+// MoveCategory 移动类目到新父类目下的指定位置
+// 参数：
+//   - c: Echo 上下文
+//   - req: 移动类目请求
+//
+// 返回值：
+//   - *category.CategoriesVO: 移动后的类目视图对象
+//   - error: 操作过程中的错误
+func MoveCategory(c echo.Context, req *dto.MoveCategoryRequest) (*category.CategoriesVO, error) {
+	var movedVO *category.CategoriesVO
+	var before, after map[string]interface{}
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		cat, err := mapper.GetCategoryByID(c, req.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取类目失败: %v", err)
+			return fmt.Errorf("获取类目失败: %w", err)
+		}
+		before = categoryAuditSummary(cat)
+
+		if err := moveCategoryTo(c, cat, req.ParentID, req.Position); err != nil {
+			utils.BizLogger(c).Errorf("移动类目失败: %v", err)
+			return fmt.Errorf("移动类目失败: %w", err)
+		}
+
+		after = categoryAuditSummary(cat)
+		vo, err := buildCategoryVOTree(c, cat)
+		if err != nil {
+			utils.BizLogger(c).Errorf("移动类目时映射 VO 失败: %v", err)
+			return fmt.Errorf("移动类目时映射 VO 失败: %w", err)
+		}
+
+		movedVO = vo
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	recordCategoryAudit(c, auditModel.AUDIT_ACTION_CATEGORY_UPDATE, req.ID, before, after)
+	return movedVO, nil
+}
+
+// DeleteCategory 软删除类目及其后代类目，并按策略处理其下的文章
 // 参数：
 //   - c: Echo 上下文
 //   - req: 删除类目请求
 //
 // 返回值：
-//   - []*category.CategoriesVO: 被删除的类目树结构
+//   - *category.DeleteCategoryVO: 被删除的类目树结构与受影响的文章数量
 //   - error: 操作过程中的错误
-func DeleteCategory(c echo.Context, req *dto.DeleteOneCategoryRequest) ([]*category.CategoriesVO, error) {
-	var deletedCategoriesVO []*category.CategoriesVO
+func DeleteCategory(c echo.Context, req *dto.DeleteOneCategoryRequest) (*category.DeleteCategoryVO, error) {
+	strategy := req.Strategy
+	if strategy == "" {
+		strategy = dto.DELETE_STRATEGY_NONE
+	}
+	deleteVO := &category.DeleteCategoryVO{Strategy: strategy}
+	var before map[string]interface{}
 
 	err := utils.RunDBTransaction(c, func(tx error) error {
 		cat, err := mapper.GetCategoryByID(c, req.ID)
@@ -298,40 +433,75 @@ func DeleteCategory(c echo.Context, req *dto.DeleteOneCategoryRequest) ([]*categ
 			return fmt.Errorf("获取类目失败: %w", err)
 		}
 
-		categoriesToDelete, err := mapper.GetCategoriesByPath(c, cat.Path)
+		childrenPath := fmt.Sprintf("%s/%d", cat.Path, cat.ID)
+		descendants, err := mapper.GetCategoriesByPath(c, childrenPath)
 		if err != nil {
 			utils.BizLogger(c).Errorf("获取子类目失败: %v", err)
 			return fmt.Errorf("获取子类目失败: %w", err)
 		}
 
-		// 删除对应类目及其子类目并返回被删除的类目树
-		var deletedCategories []*model.Category
-		var categoryIDs []int64
-
-		categoryIDs = append(categoryIDs, req.ID)
-		deletedCategories = append(deletedCategories, cat)
+		// 删除对应类目及其后代类目并返回被删除的类目树
+		deletedCategories := append([]*model.Category{cat}, descendants...)
+		categoryIDs := make([]int64, 0, len(deletedCategories))
+		for _, deleted := range deletedCategories {
+			categoryIDs = append(categoryIDs, deleted.ID)
+		}
+		before = categoryAuditSummary(cat)
+		before["descendant_count"] = len(descendants)
 
-		for _, childCat := range categoriesToDelete {
-			if childCat.ID != req.ID && strings.HasPrefix(childCat.Path, cat.Path) {
-				deletedCategories = append(deletedCategories, childCat)
-				categoryIDs = append(categoryIDs, childCat.ID)
+		var targetID int64
+		switch strategy {
+		case dto.DELETE_STRATEGY_PARENT:
+			if cat.ParentID == 0 {
+				utils.BizLogger(c).Errorf("类目「%d」为根类目，无法将文章转移到父类目", cat.ID)
+				return fmt.Errorf("类目「%d」为根类目，无法将文章转移到父类目", cat.ID)
+			}
+			targetID = cat.ParentID
+		case dto.DELETE_STRATEGY_CATEGORY:
+			if req.TargetID == 0 {
+				utils.BizLogger(c).Error("未指定文章转移的目标类目")
+				return fmt.Errorf("未指定文章转移的目标类目")
 			}
+			for _, id := range categoryIDs {
+				if id == req.TargetID {
+					utils.BizLogger(c).Errorf("目标类目「%d」不能是被删除的类目或其子类目", req.TargetID)
+					return fmt.Errorf("目标类目「%d」不能是被删除的类目或其子类目", req.TargetID)
+				}
+			}
+			if _, err := mapper.GetCategoryByID(c, req.TargetID); err != nil {
+				utils.BizLogger(c).Errorf("获取目标类目「%d」失败: %v", req.TargetID, err)
+				return fmt.Errorf("获取目标类目「%d」失败: %w", req.TargetID, err)
+			}
+			targetID = req.TargetID
 		}
 
-		// 删除相关文章-类目关联
-		for _, categoryID := range categoryIDs {
-			if err := mapper.DeletePostCategoryByCategoryID(c, categoryID); err != nil {
-				utils.BizLogger(c).Errorf("删除类目「%d」的文章关联失败: %v", categoryID, err)
-				return fmt.Errorf("删除类目「%d」的文章关联失败: %w", categoryID, err)
+		// 处理相关文章-类目关联
+		if targetID != 0 {
+			deleteVO.AffectedPostCount, err = mapper.ReassignPostCategories(c, categoryIDs, targetID)
+			if err != nil {
+				utils.BizLogger(c).Errorf("转移类目文章失败: %v", err)
+				return fmt.Errorf("转移类目文章失败: %w", err)
+			}
+			deleteVO.ReassignedCategoryID = strconv.FormatInt(targetID, 10)
+		} else {
+			deleteVO.AffectedPostCount, err = mapper.DeletePostCategoriesByCategoryIDs(c, categoryIDs)
+			if err != nil {
+				utils.BizLogger(c).Errorf("删除类目的文章关联失败: %v", err)
+				return fmt.Errorf("删除类目的文章关联失败: %w", err)
 			}
 		}
 
 		// 软删除类目
-		if err := mapper.DeleteCategoriesByPathSoftly(c, cat.Path, req.ID); err != nil {
+		if err := mapper.DeleteCategoriesByPathSoftly(c, childrenPath, req.ID); err != nil {
 			utils.BizLogger(c).Errorf("软删除类目失败: %v", err)
 			return fmt.Errorf("软删除类目失败: %w", err)
 		}
 
+		if err := compactCategoryPositions(c, cat.ParentID); err != nil {
+			utils.BizLogger(c).Errorf("整理同级类目排序失败: %v", err)
+			return fmt.Errorf("整理同级类目排序失败: %w", err)
+		}
+
 		// 构建被删除类目的树形结构
 		deletedCategoryMap := make(map[int64]*model.Category)
 		for i := range deletedCategories {
@@ -362,7 +532,7 @@ func DeleteCategory(c echo.Context, req *dto.DeleteOneCategoryRequest) ([]*categ
 				utils.BizLogger(c).Errorf("构建删除类目树 VO 失败: %v", err)
 				return fmt.Errorf("构建删除类目树 VO 失败: %w", err)
 			}
-			deletedCategoriesVO = append(deletedCategoriesVO, rootVO)
+			deleteVO.DeletedCategories = append(deleteVO.DeletedCategories, rootVO)
 		}
 
 		return nil
@@ -372,41 +542,313 @@ func DeleteCategory(c echo.Context, req *dto.DeleteOneCategoryRequest) ([]*categ
 		return nil, err
 	}
 
-	return deletedCategoriesVO, nil
+	recordCategoryAudit(c, auditModel.AUDIT_ACTION_CATEGORY_DELETE, req.ID, before, map[string]interface{}{
+		"strategy":               deleteVO.Strategy,
+		"reassigned_category_id": deleteVO.ReassignedCategoryID,
+		"affected_post_count":    deleteVO.AffectedPostCount,
+	})
+	return deleteVO, nil
 }
 
-// recursivelyUpdateChildrenPaths 递归更新子类目路径
+// MergeCategories 将源类目的文章与子类目全部转移到目标类目，并删除源类目
 // 参数：
 //   - c: Echo 上下文
-//   - parentCategory: 父类目
+//   - req: 合并类目请求
 //
 // 返回值：
+//   - *category.MergeCategoriesVO: 合并后的目标类目与受影响的数量
 //   - error: 操作过程中的错误
-func recursivelyUpdateChildrenPaths(c echo.Context, parentCategory *model.Category) error {
-	children, err := mapper.GetCategoriesByParentID(c, parentCategory.ID)
+func MergeCategories(c echo.Context, req *dto.MergeCategoriesRequest) (*category.MergeCategoriesVO, error) {
+	mergeVO := &category.MergeCategoriesVO{}
+	var before map[string]interface{}
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		if req.SourceID == req.TargetID {
+			utils.BizLogger(c).Error("源类目与目标类目不能相同")
+			return fmt.Errorf("源类目与目标类目不能相同")
+		}
+
+		source, err := mapper.GetCategoryByID(c, req.SourceID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取源类目「%d」失败: %v", req.SourceID, err)
+			return fmt.Errorf("获取源类目「%d」失败: %w", req.SourceID, err)
+		}
+
+		target, err := mapper.GetCategoryByID(c, req.TargetID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取目标类目「%d」失败: %v", req.TargetID, err)
+			return fmt.Errorf("获取目标类目「%d」失败: %w", req.TargetID, err)
+		}
+
+		before = categoryAuditSummary(source)
+
+		sourceID := strconv.FormatInt(source.ID, 10)
+		for _, ancestor := range strings.Split(target.Path, "/") {
+			if ancestor == sourceID {
+				utils.BizLogger(c).Errorf("检测到循环引用: 无法将类目「%d」合并到其子类目「%d」", source.ID, target.ID)
+				return fmt.Errorf("检测到循环引用: 无法将类目「%d」合并到其子类目「%d」", source.ID, target.ID)
+			}
+		}
+
+		// 子类目按原有顺序追加到目标类目下
+		children, err := mapper.GetCategoriesByParentID(c, source.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取源类目子类目失败: %v", err)
+			return fmt.Errorf("获取源类目子类目失败: %w", err)
+		}
+
+		next, err := mapper.GetNextCategoryPosition(c, target.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取类目排序位置失败: %v", err)
+			return fmt.Errorf("获取类目排序位置失败: %w", err)
+		}
+
+		positions := make(map[int64]int, len(children))
+		for i, child := range children {
+			positions[child.ID] = next + i
+		}
+
+		if err := mapper.UpdateCategoryParentID(c, source.ID, target.ID); err != nil {
+			utils.BizLogger(c).Errorf("转移子类目失败: %v", err)
+			return fmt.Errorf("转移子类目失败: %w", err)
+		}
+
+		if err := mapper.UpdateCategoryPositions(c, positions); err != nil {
+			utils.BizLogger(c).Errorf("更新子类目排序失败: %v", err)
+			return fmt.Errorf("更新子类目排序失败: %w", err)
+		}
+
+		if err := mapper.UpdateDescendantCategoryPaths(c,
+			fmt.Sprintf("%s/%d", source.Path, source.ID),
+			fmt.Sprintf("%s/%d", target.Path, target.ID)); err != nil {
+			utils.BizLogger(c).Errorf("更新子类目路径失败: %v", err)
+			return fmt.Errorf("更新子类目路径失败: %w", err)
+		}
+
+		mergeVO.MovedChildCount = len(children)
+		mergeVO.AffectedPostCount, err = mapper.ReassignPostCategories(c, []int64{source.ID}, target.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("转移类目文章失败: %v", err)
+			return fmt.Errorf("转移类目文章失败: %w", err)
+		}
+
+		if err := mapper.DeleteCategorySoftly(c, source.ID); err != nil {
+			utils.BizLogger(c).Errorf("删除源类目失败: %v", err)
+			return fmt.Errorf("删除源类目失败: %w", err)
+		}
+
+		if err := compactCategoryPositions(c, source.ParentID); err != nil {
+			utils.BizLogger(c).Errorf("整理同级类目排序失败: %v", err)
+			return fmt.Errorf("整理同级类目排序失败: %w", err)
+		}
+
+		target.Children, err = mapper.GetCategoriesByParentID(c, target.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取目标类目子类目失败: %v", err)
+			return fmt.Errorf("获取目标类目子类目失败: %w", err)
+		}
+
+		mergeVO.Target, err = buildCategoryVOTree(c, target)
+		if err != nil {
+			utils.BizLogger(c).Errorf("合并类目时映射 VO 失败: %v", err)
+			return fmt.Errorf("合并类目时映射 VO 失败: %w", err)
+		}
+
+		return nil
+	})
+
 	if err != nil {
-		return fmt.Errorf("获取子类目失败: %w", err)
+		return nil, err
 	}
 
-	for _, child := range children {
-		if parentCategory.Path == "" {
-			child.Path = fmt.Sprintf("/%d", parentCategory.ID)
-		} else {
-			child.Path = fmt.Sprintf("%s/%d", parentCategory.Path, parentCategory.ID)
+	recordCategoryAudit(c, auditModel.AUDIT_ACTION_CATEGORY_MERGE, req.SourceID, before, map[string]interface{}{
+		"target_id":           strconv.FormatInt(req.TargetID, 10),
+		"moved_child_count":   mergeVO.MovedChildCount,
+		"affected_post_count": mergeVO.AffectedPostCount,
+	})
+	return mergeVO, nil
+}
+
+// recordCategoryAudit 记录类目变更的审计日志
+// 参数：
+//   - c: Echo 上下文
+//   - action: 审计事件
+//   - categoryID: 类目ID
+//   - before: 变更前摘要
+//   - after: 变更后摘要
+func recordCategoryAudit(c echo.Context, action string, categoryID int64, before, after map[string]interface{}) {
+	audit.Record(c, &audit.Event{
+		Action:     action,
+		TargetType: auditModel.AUDIT_TARGET_CATEGORY,
+		TargetID:   strconv.FormatInt(categoryID, 10),
+		Before:     before,
+		After:      after,
+	})
+}
+
+// categoryAuditSummary 生成类目的审计摘要
+// 参数：
+//   - cat: 类目
+//
+// 返回值：
+//   - map[string]interface{}: 审计摘要
+func categoryAuditSummary(cat *model.Category) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        cat.Name,
+		"description": cat.Description,
+		"parent_id":   strconv.FormatInt(cat.ParentID, 10),
+		"path":        cat.Path,
+		"position":    cat.Position,
+	}
+}
+
+// moveCategoryTo 将类目移动到新父类目下的指定位置，重排新旧父类目下的同级顺序，并以单条集合更新重写子树路径
+// 参数：
+//   - c: Echo 上下文
+//   - cat: 待移动的类目
+//   - newParentID: 新父类目 ID，0 表示根类目
+//   - position: 同级中的目标位置，小于 0 或超出范围时追加到末尾
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func moveCategoryTo(c echo.Context, cat *model.Category, newParentID int64, position int) error {
+	if newParentID == cat.ID {
+		return fmt.Errorf("父类目不能设置为自身")
+	}
+
+	catID := strconv.FormatInt(cat.ID, 10)
+	newPath := ""
+	if newParentID != 0 {
+		parent, err := mapper.GetCategoryByID(c, newParentID)
+		if err != nil {
+			return fmt.Errorf("获取父类目「%d」失败: %w", newParentID, err)
 		}
 
-		if err := mapper.UpdateCategory(c, child); err != nil {
-			return fmt.Errorf("更新子类目路径失败: %w", err)
+		// 新父类目的祖先链中包含自身，说明目标是自身的后代
+		for _, ancestor := range strings.Split(parent.Path, "/") {
+			if ancestor == catID {
+				return fmt.Errorf("检测到循环引用: 无法将类目「%d」移动到其子类目「%d」下", cat.ID, newParentID)
+			}
 		}
 
-		if err := recursivelyUpdateChildrenPaths(c, child); err != nil {
-			return err
+		newPath = fmt.Sprintf("%s/%d", parent.Path, newParentID)
+	}
+
+	oldParentID := cat.ParentID
+	oldChildrenPath := fmt.Sprintf("%s/%d", cat.Path, cat.ID)
+
+	siblings, err := mapper.GetCategoriesByParentID(c, newParentID)
+	if err != nil {
+		return fmt.Errorf("获取同级类目失败: %w", err)
+	}
+
+	ordered := make([]*model.Category, 0, len(siblings)+1)
+	for _, sibling := range siblings {
+		if sibling.ID != cat.ID {
+			ordered = append(ordered, sibling)
 		}
 	}
+	if position < 0 || position > len(ordered) {
+		position = len(ordered)
+	}
+	ordered = append(ordered[:position], append([]*model.Category{cat}, ordered[position:]...)...)
+
+	positions := make(map[int64]int)
+	for i, sibling := range ordered {
+		if sibling.ID != cat.ID && sibling.Position != i {
+			positions[sibling.ID] = i
+		}
+	}
+
+	// 跨父类目移动时压缩原父类目下的同级顺序
+	if oldParentID != newParentID {
+		oldSiblings, err := mapper.GetCategoriesByParentID(c, oldParentID)
+		if err != nil {
+			return fmt.Errorf("获取原同级类目失败: %w", err)
+		}
+
+		i := 0
+		for _, sibling := range oldSiblings {
+			if sibling.ID == cat.ID {
+				continue
+			}
+			if sibling.Position != i {
+				positions[sibling.ID] = i
+			}
+			i++
+		}
+	}
+
+	if err := mapper.UpdateCategoryPositions(c, positions); err != nil {
+		return fmt.Errorf("更新同级类目排序失败: %w", err)
+	}
+
+	cat.ParentID = newParentID
+	cat.Path = newPath
+	cat.Position = position
+	if err := mapper.UpdateCategory(c, cat); err != nil {
+		return fmt.Errorf("更新类目失败: %w", err)
+	}
+
+	if err := mapper.UpdateDescendantCategoryPaths(c, oldChildrenPath, fmt.Sprintf("%s/%d", newPath, cat.ID)); err != nil {
+		return fmt.Errorf("更新子类目路径失败: %w", err)
+	}
 
 	return nil
 }
 
+// compactCategoryPositions 压缩父类目下同级类目的排序位置，消除删除或移出后留下的空位
+// 参数：
+//   - c: Echo 上下文
+//   - parentID: 父类目 ID，0 表示根类目
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func compactCategoryPositions(c echo.Context, parentID int64) error {
+	siblings, err := mapper.GetCategoriesByParentID(c, parentID)
+	if err != nil {
+		return fmt.Errorf("获取同级类目失败: %w", err)
+	}
+
+	positions := make(map[int64]int)
+	for i, sibling := range siblings {
+		if sibling.Position != i {
+			positions[sibling.ID] = i
+		}
+	}
+
+	return mapper.UpdateCategoryPositions(c, positions)
+}
+
+// getCategoryPostCounts 批量统计各类目的直接文章数量，无权编辑任意文章的请求仅统计可见文章
+// 参数：
+//   - c: Echo 上下文
+//
+// 返回值：
+//   - map[int64]int64: 类目 ID 到文章数量的映射
+//   - error: 操作过程中的错误
+func getCategoryPostCounts(c echo.Context) (map[int64]int64, error) {
+	return mapper.CountPostsGroupByCategory(c, !utils.HasPermission(c, utils.PERMISSION_POST_EDIT_ANY))
+}
+
+// fillCategoryPostCounts 递归填充类目树的直接文章数量与含后代的文章总数
+// 参数：
+//   - categoriesVO: 类目树视图对象列表
+//   - counts: 类目 ID 到直接文章数量的映射
+//
+// 返回值：
+//   - int64: 传入类目树的文章总数
+func fillCategoryPostCounts(categoriesVO []*category.CategoriesVO, counts map[int64]int64) int64 {
+	var total int64
+	for _, catVO := range categoriesVO {
+		id, _ := strconv.ParseInt(catVO.ID, 10, 64)
+		catVO.PostCount = counts[id]
+		catVO.TotalPostCount = catVO.PostCount + fillCategoryPostCounts(catVO.Children, counts)
+		total += catVO.TotalPostCount
+	}
+	return total
+}
+
 // buildCategoryVOTree 构建类目树 VO
 // 参数：
 //   - c: Echo 上下文
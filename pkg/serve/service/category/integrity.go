@@ -0,0 +1,250 @@
+// Package service 提供业务逻辑处理，处理类目树完整性检查与修复
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	model "jank.com/jank_blog/internal/model/category"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/category"
+)
+
+// 类目树完整性问题类型
+const (
+	ISSUE_PATH_MISMATCH          = "path_mismatch"          // 路径与父类目链不一致
+	ISSUE_ORPHAN                 = "orphan"                 // 父类目已删除或不存在
+	ISSUE_CYCLE                  = "cycle"                  // 父类目链存在环
+	ISSUE_DANGLING_POST_CATEGORY = "dangling_post_category" // 文章关联指向已删除或不存在的类目
+)
+
+// CheckCategoryTree 检查类目树完整性，repair 为 true 时在事务中修复检测到的问题
+// 修复规则：孤儿类目与环上 ID 最小的类目提升为根类目，路径按父类目链重写，失效的文章-类目关联被删除
+// 参数：
+//   - c: Echo 上下文
+//   - repair: 是否修复
+//
+// 返回值：
+//   - *category.CategoryTreeReportVO: 检查报告
+//   - error: 操作过程中的错误
+func CheckCategoryTree(c echo.Context, repair bool) (*category.CategoryTreeReportVO, error) {
+	report := &category.CategoryTreeReportVO{
+		Issues:   make([]*category.CategoryTreeIssueVO, 0),
+		Repaired: repair,
+	}
+
+	check := func(tx error) error {
+		categories, err := mapper.GetAllActivatedCategories(c)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取类目失败: %v", err)
+			return fmt.Errorf("获取类目失败: %w", err)
+		}
+
+		categoryMap := make(map[int64]*model.Category, len(categories))
+		for _, cat := range categories {
+			categoryMap[cat.ID] = cat
+		}
+
+		// parents 为修复后的父类目关系，检查在其上进行，不直接修改类目对象
+		parents := make(map[int64]int64, len(categories))
+		for _, cat := range categories {
+			parents[cat.ID] = cat.ParentID
+		}
+
+		detached := make(map[int64]bool)
+		for _, cat := range categories {
+			if cat.ParentID == 0 {
+				continue
+			}
+			if _, exists := categoryMap[cat.ParentID]; !exists {
+				report.Issues = append(report.Issues, &category.CategoryTreeIssueVO{
+					Type:       ISSUE_ORPHAN,
+					CategoryID: strconv.FormatInt(cat.ID, 10),
+					Actual:     strconv.FormatInt(cat.ParentID, 10),
+					Detail:     fmt.Sprintf("类目「%d」的父类目「%d」已删除或不存在", cat.ID, cat.ParentID),
+				})
+				parents[cat.ID] = 0
+				detached[cat.ID] = true
+			}
+		}
+
+		for _, cycle := range findCategoryCycles(categories, parents) {
+			breakID := cycle[0]
+			for _, id := range cycle {
+				if id < breakID {
+					breakID = id
+				}
+			}
+			report.Issues = append(report.Issues, &category.CategoryTreeIssueVO{
+				Type:       ISSUE_CYCLE,
+				CategoryID: strconv.FormatInt(breakID, 10),
+				Actual:     formatCategoryIDs(cycle),
+				Detail:     fmt.Sprintf("类目父子关系存在环: %s", formatCategoryIDs(cycle)),
+			})
+			parents[breakID] = 0
+			detached[breakID] = true
+		}
+
+		// 按修复后的父类目关系计算期望路径
+		expectedPaths := make(map[int64]string, len(categories))
+		var expectedPath func(id int64) string
+		expectedPath = func(id int64) string {
+			if path, ok := expectedPaths[id]; ok {
+				return path
+			}
+			path := ""
+			if parentID := parents[id]; parentID != 0 {
+				path = fmt.Sprintf("%s/%d", expectedPath(parentID), parentID)
+			}
+			expectedPaths[id] = path
+			return path
+		}
+
+		var changed []*model.Category
+		for _, cat := range categories {
+			expected := expectedPath(cat.ID)
+			if cat.Path != expected && !detached[cat.ID] {
+				report.Issues = append(report.Issues, &category.CategoryTreeIssueVO{
+					Type:       ISSUE_PATH_MISMATCH,
+					CategoryID: strconv.FormatInt(cat.ID, 10),
+					Expected:   expected,
+					Actual:     cat.Path,
+					Detail:     fmt.Sprintf("类目「%d」的路径与父类目链不一致", cat.ID),
+				})
+			}
+			if cat.Path != expected || cat.ParentID != parents[cat.ID] {
+				changed = append(changed, cat)
+			}
+		}
+
+		danglings, err := mapper.GetDanglingPostCategories(c)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取失效的文章-类目关联失败: %v", err)
+			return fmt.Errorf("获取失效的文章-类目关联失败: %w", err)
+		}
+
+		danglingIDs := make([]int64, 0, len(danglings))
+		for _, dangling := range danglings {
+			report.Issues = append(report.Issues, &category.CategoryTreeIssueVO{
+				Type:       ISSUE_DANGLING_POST_CATEGORY,
+				CategoryID: strconv.FormatInt(dangling.CategoryID, 10),
+				PostID:     strconv.FormatInt(dangling.PostID, 10),
+				Detail:     fmt.Sprintf("文章「%d」关联的类目「%d」已删除或不存在", dangling.PostID, dangling.CategoryID),
+			})
+			danglingIDs = append(danglingIDs, dangling.ID)
+		}
+
+		if !repair {
+			return nil
+		}
+
+		// 提升为根类目的类目追加到根类目末尾
+		position, err := mapper.GetNextCategoryPosition(c, 0)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取类目排序位置失败: %v", err)
+			return fmt.Errorf("获取类目排序位置失败: %w", err)
+		}
+
+		for _, cat := range changed {
+			if detached[cat.ID] {
+				cat.Position = position
+				position++
+			}
+			cat.ParentID = parents[cat.ID]
+			cat.Path = expectedPaths[cat.ID]
+			if err := mapper.UpdateCategory(c, cat); err != nil {
+				utils.BizLogger(c).Errorf("修复类目「%d」失败: %v", cat.ID, err)
+				return fmt.Errorf("修复类目「%d」失败: %w", cat.ID, err)
+			}
+		}
+
+		if err := mapper.DeletePostCategoriesByIDs(c, danglingIDs); err != nil {
+			utils.BizLogger(c).Errorf("删除失效的文章-类目关联失败: %v", err)
+			return fmt.Errorf("删除失效的文章-类目关联失败: %w", err)
+		}
+
+		return nil
+	}
+
+	var err error
+	if repair {
+		err = utils.RunDBTransaction(c, check)
+	} else {
+		err = check(nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// findCategoryCycles 沿父类目链查找所有环
+// 参数：
+//   - categories: 类目列表
+//   - parents: 类目 ID 到父类目 ID 的映射
+//
+// 返回值：
+//   - [][]int64: 每个环上的类目 ID 列表
+func findCategoryCycles(categories []*model.Category, parents map[int64]int64) [][]int64 {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[int64]int, len(categories))
+	var cycles [][]int64
+
+	for _, cat := range categories {
+		var stack []int64
+		id := cat.ID
+		for id != 0 && state[id] == unvisited {
+			if _, exists := parents[id]; !exists {
+				break
+			}
+			state[id] = visiting
+			stack = append(stack, id)
+			id = parents[id]
+		}
+
+		if id != 0 && state[id] == visiting {
+			for i, stacked := range stack {
+				if stacked == id {
+					cycles = append(cycles, append([]int64(nil), stack[i:]...))
+					break
+				}
+			}
+		}
+
+		for _, stacked := range stack {
+			state[stacked] = visited
+		}
+	}
+
+	return cycles
+}
+
+// formatCategoryIDs 将类目 ID 列表格式化为升序的可读字符串
+// 参数：
+//   - ids: 类目 ID 列表
+//
+// 返回值：
+//   - string: 形如 1,2,3 的字符串
+func formatCategoryIDs(ids []int64) string {
+	sorted := append([]int64(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	parts := make([]string, 0, len(sorted))
+	for _, id := range sorted {
+		parts = append(parts, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(parts, ",")
+}
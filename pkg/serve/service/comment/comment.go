@@ -4,18 +4,113 @@
 package service
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
+	"jank.com/jank_blog/configs"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/global"
+	auditModel "jank.com/jank_blog/internal/model/audit"
 	model "jank.com/jank_blog/internal/model/comment"
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/comment/dto"
 	"jank.com/jank_blog/pkg/serve/mapper"
+	audit "jank.com/jank_blog/pkg/serve/service/audit"
+	notification "jank.com/jank_blog/pkg/serve/service/notification"
+	"jank.com/jank_blog/pkg/serve/service/spam"
 	"jank.com/jank_blog/pkg/vo/comment"
+	"jank.com/jank_blog/pkg/vo/webmention"
 )
 
-// CreateComment 创建评论
+const (
+	GUEST_COMMENT_RATE_LIMIT_SCOPE = "comment"           // 游客评论限流场景
+	COMMENT_REACTION_SCOPE         = "comment_reaction"  // 评论互动去重场景
+	COMMENT_REACTION_WINDOW        = 30 * 24 * time.Hour // 评论互动去重窗口
+	DEFAULT_MODERATION_PAGE_SIZE   = 20                  // 审核队列默认每页条数
+	DEFAULT_COMMENT_PAGE_SIZE      = 10                  // 评论列表默认每页顶层评论条数
+	EDIT_TOKEN_BYTES               = 24                  // 游客编辑凭证字节数
+	SPAM_LEARN_QUEUE_SIZE          = 64                  // 垃圾评论学习队列容量
+)
+
+// spamLearnJob 垃圾评论学习任务
+type spamLearnJob struct {
+	ids   []int64 // 已审核的评论 ID 列表
+	label string  // 训练标签
+}
+
+var (
+	spamLearnQueue      = make(chan spamLearnJob, SPAM_LEARN_QUEUE_SIZE) // 垃圾评论学习队列
+	spamLearnWorkerOnce sync.Once                                        // 确保学习协程只启动一次
+)
+
+// commentStatusRanks 审核状态的严格程度，用于编辑后只收紧不放宽审核状态
+var commentStatusRanks = map[string]int{
+	model.COMMENT_STATUS_APPROVED: 0,
+	model.COMMENT_STATUS_PENDING:  1,
+	model.COMMENT_STATUS_REJECTED: 2,
+	model.COMMENT_STATUS_SPAM:     3,
+}
+
+// 评论审核策略
+const (
+	MODERATION_POLICY_NONE  = "none"  // 直接通过
+	MODERATION_POLICY_LINKS = "links" // 含链接时待审
+	MODERATION_POLICY_ALL   = "all"   // 全部待审
+)
+
+// commentLinkPattern 匹配评论内容中的链接
+var commentLinkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+
+// CheckGuestComment 校验游客评论的开关、必填信息与单 IP 限流
+// 参数：
+//   - c: Echo 上下文
+//   - req: 创建评论请求
+//
+// 返回值：
+//   - error: 校验失败时返回业务错误
+func CheckGuestComment(c echo.Context, req *dto.CreateCommentRequest) error {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载评论配置失败: %v", err)
+		return bizErr.New(bizErr.SERVER_ERR, "加载评论配置失败")
+	}
+
+	if !cfg.AppConfig.Comment.GuestCommentEnabled {
+		return bizErr.New(bizErr.BAD_REQUEST, "游客评论已关闭，请登录后评论")
+	}
+
+	if req.Nickname == "" || req.Email == "" || req.ImgVerificationCode == "" {
+		return bizErr.New(bizErr.BAD_REQUEST, "游客评论需填写昵称、邮箱与图形验证码")
+	}
+
+	window := time.Duration(cfg.AppConfig.Comment.GuestRateWindow) * time.Second
+	allowed, err := utils.AllowRequest(c, GUEST_COMMENT_RATE_LIMIT_SCOPE, c.RealIP(), cfg.AppConfig.Comment.GuestRateLimit, window)
+	if err != nil {
+		utils.BizLogger(c).Errorf("游客评论限流失败: %v", err)
+		return bizErr.New(bizErr.SERVER_ERR, "游客评论限流失败")
+	}
+	if !allowed {
+		utils.BizLogger(c).Warnf("IP「%s」游客评论过于频繁", c.RealIP())
+		return bizErr.New(bizErr.TOO_MANY_REQUESTS)
+	}
+
+	return nil
+}
+
+// CreateComment 创建评论，审核员与文章作者的评论标记为博主回复，其余账户与游客的评论须经审核与垃圾评论检测
 // 参数：
 //   - c: Echo 上下文
 //   - req: 创建评论请求
@@ -24,27 +119,59 @@ import (
 //   - *comment.CommentsVO: 创建后的评论视图对象
 //   - error: 操作过程中的错误
 func CreateComment(c echo.Context, req *dto.CreateCommentRequest) (*comment.CommentsVO, error) {
-	accountID, err := utils.ParseAccountFromJWT(c.Request().Header.Get("Authorization"))
+	com := &model.Comment{
+		Content:          req.Content,
+		PostId:           req.PostId,
+		ReplyToCommentId: req.ReplyToCommentId,
+		AuthorIP:         c.RealIP(),
+	}
+
+	accountID, authenticated := utils.GetAuthAccountID(c)
+	privileged, err := checkCommentTarget(c, req)
 	if err != nil {
-		utils.BizLogger(c).Errorf("access_token 解析失败: %v", err)
-		return nil, fmt.Errorf("access_token 解析失败: %w", err)
+		return nil, err
+	}
+
+	if authenticated {
+		acc, err := mapper.GetAccountByAccountID(c, accountID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("「%d」用户不存在: %v", accountID, err)
+			return nil, fmt.Errorf("「%d」用户不存在: %w", accountID, err)
+		}
+
+		com.AccountId = acc.ID
+		com.AuthorName = acc.Nickname
+		com.AuthorEmail = acc.Email
+		com.IsAuthorReply = privileged
+	} else {
+		com.AuthorName = req.Nickname
+		com.AuthorEmail = req.Email
+		com.AuthorWebsite = req.Website
 	}
 
-	acc, err := mapper.GetAccountByAccountID(c, accountID)
+	if err := renderCommentContent(c, com); err != nil {
+		return nil, err
+	}
+
+	status, err := evaluateCommentStatus(c, com)
 	if err != nil {
-		utils.BizLogger(c).Errorf("「%s」用户不存在: %v", acc.Email, err)
-		return nil, fmt.Errorf("「%s」用户不存在: %w", acc.Email, err)
+		return nil, err
 	}
+	com.Status = status
 
-	var commentVO *comment.CommentsVO
-	err = utils.RunDBTransaction(c, func(tx error) error {
-		com := &model.Comment{
-			Content:          req.Content,
-			AccountId:        accountID,
-			PostId:           req.PostId,
-			ReplyToCommentId: req.ReplyToCommentId,
+	// 游客凭编辑凭证编辑或删除自己的评论，凭证仅返回一次，库中只保存摘要
+	var editToken string
+	if !authenticated {
+		editToken, err = generateEditToken()
+		if err != nil {
+			utils.BizLogger(c).Errorf("生成评论编辑凭证失败: %v", err)
+			return nil, fmt.Errorf("生成评论编辑凭证失败: %w", err)
 		}
+		com.EditTokenHash = hashEditToken(editToken)
+	}
 
+	var commentVO *comment.CommentsVO
+	err = utils.RunDBTransaction(c, func(tx error) error {
 		if err := mapper.CreateComment(c, com); err != nil {
 			utils.BizLogger(c).Errorf("创建评论失败：%v", err)
 			return fmt.Errorf("创建评论失败：%w", err)
@@ -57,6 +184,7 @@ func CreateComment(c echo.Context, req *dto.CreateCommentRequest) (*comment.Comm
 		}
 
 		commentVO = vo.(*comment.CommentsVO)
+		commentVO.EditToken = editToken
 		return nil
 	})
 
@@ -64,10 +192,66 @@ func CreateComment(c echo.Context, req *dto.CreateCommentRequest) (*comment.Comm
 		return nil, err
 	}
 
+	recordCommentAudit(c, auditModel.AUDIT_ACTION_COMMENT_CREATE, com.ID, nil, commentAuditSummary(com))
+
+	if com.Status == model.COMMENT_STATUS_APPROVED && com.ReplyToCommentId != 0 {
+		notification.EnqueueReplyNotification(c, com.ID)
+	}
+	if !com.IsAuthorReply && com.Status != model.COMMENT_STATUS_SPAM {
+		notification.EnqueueNewCommentNotification(c, com.ID)
+	}
+
 	return commentVO, nil
 }
 
-// GetCommentWithReplies 根据 ID 获取评论及其所有回复
+// checkCommentTarget 校验评论的目标文章与回复对象：文章须存在且可见、评论未关闭，
+// 回复对象须属于同一文章且已通过审核；评论审核员与文章作者不受可见性与评论开关限制
+// 参数：
+//   - c: Echo 上下文
+//   - req: 创建评论请求
+//
+// 返回值：
+//   - bool: 当前账户是否为评论审核员或文章作者
+//   - error: 校验失败时返回业务错误
+func checkCommentTarget(c echo.Context, req *dto.CreateCommentRequest) (bool, error) {
+	accountID, authenticated := utils.GetAuthAccountID(c)
+
+	pos, err := mapper.GetPostByID(c, req.PostId)
+	if err != nil {
+		utils.BizLogger(c).Errorf("文章「%d」不存在: %v", req.PostId, err)
+		return false, bizErr.New(bizErr.BAD_REQUEST, fmt.Sprintf("文章「%d」不存在或不可见", req.PostId))
+	}
+	privileged := utils.HasPermission(c, utils.PERMISSION_COMMENT_MODERATE) ||
+		(authenticated && pos.AuthorID == accountID)
+
+	if !privileged {
+		if !pos.Visibility {
+			utils.BizLogger(c).Errorf("文章「%d」不可见", req.PostId)
+			return false, bizErr.New(bizErr.BAD_REQUEST, fmt.Sprintf("文章「%d」不存在或不可见", req.PostId))
+		}
+		if !pos.CommentsOpen(time.Now()) {
+			utils.BizLogger(c).Warnf("文章「%d」已关闭评论", req.PostId)
+			return false, bizErr.New(bizErr.FORBIDDEN, "该文章已关闭评论")
+		}
+		if !authenticated && pos.CommentsLoginRequired {
+			utils.BizLogger(c).Warnf("文章「%d」仅允许登录用户评论", req.PostId)
+			return false, bizErr.New(bizErr.FORBIDDEN, "该文章仅允许登录用户评论")
+		}
+	}
+
+	if req.ReplyToCommentId != 0 {
+		target, err := mapper.GetCommentByID(c, req.ReplyToCommentId)
+		if err != nil || target.PostId != req.PostId ||
+			(!privileged && target.Status != model.COMMENT_STATUS_APPROVED) {
+			utils.BizLogger(c).Errorf("回复的评论「%d」不存在: %v", req.ReplyToCommentId, err)
+			return false, bizErr.New(bizErr.BAD_REQUEST, fmt.Sprintf("回复的评论「%d」不存在", req.ReplyToCommentId))
+		}
+	}
+
+	return privileged, nil
+}
+
+// GetCommentWithReplies 根据 ID 获取评论及其第一页直接回复
 // 参数：
 //   - c: Echo 上下文
 //   - req: 获取评论请求
@@ -76,103 +260,362 @@ func CreateComment(c echo.Context, req *dto.CreateCommentRequest) (*comment.Comm
 //   - *comment.CommentsVO: 评论及其回复的视图对象
 //   - error: 操作过程中的错误
 func GetCommentWithReplies(c echo.Context, req *dto.GetOneCommentRequest) (*comment.CommentsVO, error) {
+	moderator := utils.HasPermission(c, utils.PERMISSION_COMMENT_MODERATE)
+
 	com, err := mapper.GetCommentByID(c, req.ID)
 	if err != nil {
 		utils.BizLogger(c).Errorf("获取评论失败：%v", err)
 		return nil, fmt.Errorf("获取评论失败：%w", err)
 	}
 
-	replies, err := mapper.GetReplyByCommentID(c, req.ID)
+	if !moderator && com.Status != model.COMMENT_STATUS_APPROVED {
+		utils.BizLogger(c).Errorf("评论「%d」未通过审核", req.ID)
+		return nil, fmt.Errorf("获取评论失败：评论「%d」不存在", req.ID)
+	}
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载评论配置失败：%v", err)
+		return nil, fmt.Errorf("加载评论配置失败：%w", err)
+	}
+
+	threads, err := buildCommentThreads(c, []*model.Comment{com}, 1, cfg.AppConfig.Comment.ReplyPageSize, !moderator)
 	if err != nil {
-		utils.BizLogger(c).Errorf("获取子评论失败：%v", err)
-		return nil, fmt.Errorf("获取子评论失败：%w", err)
+		return nil, err
 	}
 
-	com.Replies = replies
+	return threads[0], nil
+}
 
-	commentVO, err := utils.MapModelToVO(com, &comment.CommentsVO{})
+// GetCommentGraphByPostID 分页获取文章的顶层评论，并按配置的层级内联回复，同时附带校验通过的 Webmention，匿名访问仅包含审核通过的评论
+// 参数：
+//   - c: Echo 上下文
+//   - req: 获取评论请求
+//
+// 返回值：
+//   - *comment.CommentPageVO: 分页的评论列表
+//   - error: 操作过程中的错误
+func GetCommentGraphByPostID(c echo.Context, req *dto.GetCommentGraphRequest) (*comment.CommentPageVO, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载评论配置失败：%v", err)
+		return nil, fmt.Errorf("加载评论配置失败：%w", err)
+	}
+
+	sort := req.Sort
+	if sort == "" {
+		sort = model.COMMENT_SORT_NEWEST
+	}
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = DEFAULT_COMMENT_PAGE_SIZE
+	}
+	depth := cfg.AppConfig.Comment.MaxReplyDepth
+	if req.Depth > 0 && req.Depth < depth {
+		depth = req.Depth
+	}
+
+	// 评论审核员以外仅展示审核通过的评论
+	moderator := utils.HasPermission(c, utils.PERMISSION_COMMENT_MODERATE)
+	roots, total, err := mapper.GetRootCommentsByPostIDWithPaging(c, req.PostID, sort, !moderator, page, pageSize)
 	if err != nil {
-		utils.BizLogger(c).Errorf("获取评论时映射 VO 失败：%v", err)
-		return nil, fmt.Errorf("获取评论时映射 VO 失败：%w", err)
+		utils.BizLogger(c).Errorf("获取评论列表失败：%v", err)
+		return nil, fmt.Errorf("获取评论列表失败：%w", err)
 	}
 
-	return commentVO.(*comment.CommentsVO), nil
+	threads, err := buildCommentThreads(c, roots, depth, cfg.AppConfig.Comment.ReplyPageSize, !moderator)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions, err := getVerifiedWebmentions(c, req.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment.CommentPageVO{
+		Comments:    threads,
+		Webmentions: mentions,
+		Total:       total,
+		TotalPages:  int(math.Ceil(float64(total) / float64(pageSize))),
+		CurrentPage: page,
+	}, nil
 }
 
-// GetCommentGraphByPostID 根据文章 ID 获取评论图结构
+// getVerifiedWebmentions 获取文章下校验通过的 Webmention，与评论一同展示
 // 参数：
 //   - c: Echo 上下文
-//   - req: 获取评论图请求
+//   - postID: 文章 ID
 //
 // 返回值：
-//   - []*comment.CommentsVO: 评论图结构列表
+//   - []*webmention.WebmentionsVO: Webmention 视图对象列表
 //   - error: 操作过程中的错误
-func GetCommentGraphByPostID(c echo.Context, req *dto.GetCommentGraphRequest) ([]*comment.CommentsVO, error) {
-	comments, err := mapper.GetCommentsByPostID(c, req.PostID)
+func getVerifiedWebmentions(c echo.Context, postID int64) ([]*webmention.WebmentionsVO, error) {
+	mentions, err := mapper.GetVerifiedWebmentionsByPostID(c, postID)
 	if err != nil {
-		utils.BizLogger(c).Errorf("获取评论图失败：%v", err)
-		return nil, fmt.Errorf("获取评论图失败：%w", err)
+		utils.BizLogger(c).Errorf("获取文章 Webmention 失败：%v", err)
+		return nil, fmt.Errorf("获取文章 Webmention 失败：%w", err)
 	}
 
-	commentMap := make(map[int64]*comment.CommentsVO)
-	var rootCommentsVO []*comment.CommentsVO
+	mentionsVO := make([]*webmention.WebmentionsVO, 0, len(mentions))
+	for _, mention := range mentions {
+		vo, err := utils.MapModelToVO(mention, &webmention.WebmentionsVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取 Webmention 时映射 VO 失败：%v", err)
+			return nil, fmt.Errorf("获取 Webmention 时映射 VO 失败：%w", err)
+		}
+		mentionsVO = append(mentionsVO, vo.(*webmention.WebmentionsVO))
+	}
+	return mentionsVO, nil
+}
 
-	for _, com := range comments {
-		commentVO, err := utils.MapModelToVO(com, &comment.CommentsVO{})
+// GetCommentReplies 按游标加载评论的直接回复，用于展开超出内联层级或条数的回复
+// 参数：
+//   - c: Echo 上下文
+//   - req: 获取评论回复请求
+//
+// 返回值：
+//   - *comment.CommentRepliesVO: 回复列表及下一页游标
+//   - error: 操作过程中的错误
+func GetCommentReplies(c echo.Context, req *dto.GetCommentRepliesRequest) (*comment.CommentRepliesVO, error) {
+	moderator := utils.HasPermission(c, utils.PERMISSION_COMMENT_MODERATE)
+
+	parent, err := mapper.GetCommentByID(c, req.CommentID)
+	if err != nil || (!moderator && parent.Status != model.COMMENT_STATUS_APPROVED) {
+		utils.BizLogger(c).Errorf("评论「%d」不存在: %v", req.CommentID, err)
+		return nil, fmt.Errorf("评论「%d」不存在", req.CommentID)
+	}
+
+	afterCreate, afterID, err := decodeReplyCursor(req.Cursor)
+	if err != nil {
+		utils.BizLogger(c).Errorf("回复游标「%s」格式错误: %v", req.Cursor, err)
+		return nil, fmt.Errorf("回复游标格式错误")
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		cfg, err := configs.LoadConfig()
 		if err != nil {
-			utils.BizLogger(c).Errorf("获取评论图时映射 VO 失败：%v", err)
-			return nil, fmt.Errorf("获取评论图时映射 VO 失败：%w", err)
+			utils.BizLogger(c).Errorf("加载评论配置失败：%v", err)
+			return nil, fmt.Errorf("加载评论配置失败：%w", err)
 		}
-		vo := commentVO.(*comment.CommentsVO)
-		vo.Replies = make([]*comment.CommentsVO, 0)
-		commentMap[com.ID] = vo
+		limit = cfg.AppConfig.Comment.ReplyPageSize
+	}
+
+	// 多取一条用于判断是否还有下一页
+	replies, err := mapper.GetRepliesAfterCursor(c, req.CommentID, !moderator, afterCreate, afterID, limit+1)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取评论回复失败：%v", err)
+		return nil, fmt.Errorf("获取评论回复失败：%w", err)
+	}
+
+	repliesVO := &comment.CommentRepliesVO{HasMore: len(replies) > limit}
+	if repliesVO.HasMore {
+		replies = replies[:limit]
+	}
+	if len(replies) > 0 && repliesVO.HasMore {
+		last := replies[len(replies)-1]
+		repliesVO.NextCursor = encodeReplyCursor(last.GmtCreate, last.ID)
+	}
+
+	repliesVO.Replies, err = buildCommentThreads(c, replies, 0, 0, !moderator)
+	if err != nil {
+		return nil, err
+	}
+
+	return repliesVO, nil
+}
+
+// ReactToComment 为评论点赞，同一访客对同一评论在统计窗口内仅计一次
+// 参数：
+//   - c: Echo 上下文
+//   - req: 评论互动请求
+//
+// 返回值：
+//   - *comment.CommentReactionVO: 互动结果
+//   - error: 操作过程中的错误
+func ReactToComment(c echo.Context, req *dto.ReactCommentRequest) (*comment.CommentReactionVO, error) {
+	accountID, authenticated := utils.GetAuthAccountID(c)
+
+	com, err := mapper.GetCommentByID(c, req.ID)
+	if err != nil || com.Status != model.COMMENT_STATUS_APPROVED {
+		utils.BizLogger(c).Errorf("评论「%d」不存在: %v", req.ID, err)
+		return nil, fmt.Errorf("评论「%d」不存在", req.ID)
+	}
 
-		if com.ReplyToCommentId == 0 {
-			rootCommentsVO = append(rootCommentsVO, vo)
+	visitor := c.RealIP()
+	if authenticated {
+		visitor = "account:" + strconv.FormatInt(accountID, 10)
+	}
+
+	counted, err := utils.AllowRequest(c, COMMENT_REACTION_SCOPE, fmt.Sprintf("%d:%s", com.ID, visitor), 1, COMMENT_REACTION_WINDOW)
+	if err != nil {
+		utils.BizLogger(c).Errorf("记录评论互动失败: %v", err)
+		return nil, fmt.Errorf("记录评论互动失败: %w", err)
+	}
+
+	if counted {
+		if err := mapper.IncrCommentReactionCount(c, com.ID); err != nil {
+			utils.BizLogger(c).Errorf("更新评论互动次数失败: %v", err)
+			return nil, fmt.Errorf("更新评论互动次数失败: %w", err)
 		}
+		com.ReactionCount++
 	}
 
+	return &comment.CommentReactionVO{
+		ID:            strconv.FormatInt(com.ID, 10),
+		ReactionCount: com.ReactionCount,
+		Counted:       counted,
+	}, nil
+}
+
+// GetModerationQueue 按审核状态分页获取评论审核队列
+// 参数：
+//   - c: Echo 上下文
+//   - req: 获取审核队列请求
+//
+// 返回值：
+//   - *comment.ModerationQueueVO: 审核队列
+//   - error: 操作过程中的错误
+func GetModerationQueue(c echo.Context, req *dto.GetModerationQueueRequest) (*comment.ModerationQueueVO, error) {
+	status := req.Status
+	if status == "" {
+		status = model.COMMENT_STATUS_PENDING
+	}
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = DEFAULT_MODERATION_PAGE_SIZE
+	}
+
+	comments, total, err := mapper.GetCommentsByStatusWithPaging(c, status, page, pageSize)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取评论审核队列失败：%v", err)
+		return nil, fmt.Errorf("获取评论审核队列失败：%w", err)
+	}
+
+	queueVO := &comment.ModerationQueueVO{
+		Comments:    make([]*comment.ModerationCommentVO, 0, len(comments)),
+		Total:       total,
+		TotalPages:  int(math.Ceil(float64(total) / float64(pageSize))),
+		CurrentPage: page,
+	}
 	for _, com := range comments {
-		if com.ReplyToCommentId != 0 {
-			if parentVO, exists := commentMap[com.ReplyToCommentId]; exists {
-				parentVO.Replies = append(parentVO.Replies, commentMap[com.ID])
-			}
+		ensureCommentHTML(c, com)
+		vo, err := utils.MapModelToVO(com, &comment.ModerationCommentVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取评论审核队列时映射 VO 失败：%v", err)
+			return nil, fmt.Errorf("获取评论审核队列时映射 VO 失败：%w", err)
+		}
+		queueVO.Comments = append(queueVO.Comments, vo.(*comment.ModerationCommentVO))
+	}
+
+	return queueVO, nil
+}
+
+// ModerateComments 批量审核评论，通过与标记垃圾的结果会异步反馈给垃圾评论检测器用于学习
+// 参数：
+//   - c: Echo 上下文
+//   - req: 批量审核请求
+//
+// 返回值：
+//   - *comment.ModerateCommentsVO: 审核结果
+//   - error: 操作过程中的错误
+func ModerateComments(c echo.Context, req *dto.ModerateCommentsRequest) (*comment.ModerateCommentsVO, error) {
+	statuses := map[string]string{
+		dto.MODERATE_ACTION_APPROVE: model.COMMENT_STATUS_APPROVED,
+		dto.MODERATE_ACTION_REJECT:  model.COMMENT_STATUS_REJECTED,
+		dto.MODERATE_ACTION_SPAM:    model.COMMENT_STATUS_SPAM,
+	}
+	status, ok := statuses[req.Action]
+	if !ok {
+		utils.BizLogger(c).Errorf("不支持的审核操作「%s」", req.Action)
+		return nil, fmt.Errorf("不支持的审核操作「%s」", req.Action)
+	}
+
+	ids := make([]int64, 0, len(req.IDs))
+	for _, rawID := range req.IDs {
+		id, err := strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			utils.BizLogger(c).Errorf("评论ID「%s」格式错误", rawID)
+			return nil, fmt.Errorf("评论ID「%s」格式错误", rawID)
 		}
+		ids = append(ids, id)
 	}
 
-	processed := make(map[string]bool)
-	var processComment func(*comment.CommentsVO) *comment.CommentsVO
-	processComment = func(vo *comment.CommentsVO) *comment.CommentsVO {
-		if processed[vo.ID] {
-			newVO := *vo
-			newVO.Replies = make([]*comment.CommentsVO, 0)
-			return &newVO
+	// 通过审核时通知此前未公开的回复所回复的评论者
+	var newlyApprovedReplies []int64
+	resultVO := &comment.ModerateCommentsVO{Status: status}
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		if status == model.COMMENT_STATUS_APPROVED {
+			comments, err := mapper.GetCommentsByIDs(c, ids)
+			if err != nil {
+				utils.BizLogger(c).Errorf("获取待审核评论失败：%v", err)
+				return fmt.Errorf("获取待审核评论失败：%w", err)
+			}
+			for _, com := range comments {
+				if com.Status != model.COMMENT_STATUS_APPROVED && com.ReplyToCommentId != 0 {
+					newlyApprovedReplies = append(newlyApprovedReplies, com.ID)
+				}
+			}
 		}
-		processed[vo.ID] = true
 
-		for i, reply := range vo.Replies {
-			vo.Replies[i] = processComment(reply)
+		affected, err := mapper.UpdateCommentsStatus(c, ids, status)
+		if err != nil {
+			utils.BizLogger(c).Errorf("批量审核评论失败：%v", err)
+			return fmt.Errorf("批量审核评论失败：%w", err)
 		}
-		return vo
+		resultVO.AffectedCount = affected
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_COMMENT_MODERATE,
+		TargetType: auditModel.AUDIT_TARGET_COMMENT,
+		TargetID:   strings.Join(req.IDs, ","),
+		After: map[string]interface{}{
+			"status":         status,
+			"affected_count": resultVO.AffectedCount,
+		},
+	})
+
+	for _, id := range newlyApprovedReplies {
+		notification.EnqueueReplyNotification(c, id)
 	}
 
-	for i, rootVO := range rootCommentsVO {
-		rootCommentsVO[i] = processComment(rootVO)
+	labels := map[string]string{
+		dto.MODERATE_ACTION_APPROVE: spam.LABEL_HAM,
+		dto.MODERATE_ACTION_SPAM:    spam.LABEL_SPAM,
+	}
+	if label, ok := labels[req.Action]; ok {
+		enqueueSpamLearning(c, ids, label)
 	}
 
-	return rootCommentsVO, nil
+	return resultVO, nil
 }
 
-// DeleteComment 软删除评论
+// DeleteComment 软删除评论，仅评论作者或博主可删除
 // 参数：
 //   - c: Echo 上下文
 //   - req: 删除评论请求
 //
 // 返回值：
 //   - *comment.CommentsVO: 被删除的评论视图对象
-//   - error: 操作过程中的错误
+//   - error: 操作过程中的错误，无权删除时返回业务错误
 func DeleteComment(c echo.Context, req *dto.DeleteCommentRequest) (*comment.CommentsVO, error) {
 	var commentVO *comment.CommentsVO
+	var before, after map[string]interface{}
 
 	err := utils.RunDBTransaction(c, func(tx error) error {
 		com, err := mapper.GetCommentByID(c, req.ID)
@@ -181,11 +624,18 @@ func DeleteComment(c echo.Context, req *dto.DeleteCommentRequest) (*comment.Comm
 			return fmt.Errorf("评论不存在：%w", err)
 		}
 
+		if !utils.HasPermission(c, utils.PERMISSION_COMMENT_MODERATE) && !isCommentAuthor(c, com, req.EditToken) {
+			utils.BizLogger(c).Warnf("无权删除评论「%d」", req.ID)
+			return bizErr.New(bizErr.FORBIDDEN, "仅评论作者或评论审核员可删除评论")
+		}
+
+		before = commentAuditSummary(com)
 		com.Deleted = true
 		if err := mapper.UpdateComment(c, com); err != nil {
 			utils.BizLogger(c).Errorf("软删除评论失败：%v", err)
 			return fmt.Errorf("软删除评论失败：%w", err)
 		}
+		after = commentAuditSummary(com)
 
 		vo, err := utils.MapModelToVO(com, &comment.CommentsVO{})
 		if err != nil {
@@ -201,5 +651,565 @@ func DeleteComment(c echo.Context, req *dto.DeleteCommentRequest) (*comment.Comm
 		return nil, err
 	}
 
+	recordCommentAudit(c, auditModel.AUDIT_ACTION_COMMENT_DELETE, req.ID, before, after)
 	return commentVO, nil
 }
+
+// UpdateComment 作者在编辑时间窗口内编辑评论，编辑前的内容保存为历史版本，编辑后的内容重新审核
+// 参数：
+//   - c: Echo 上下文
+//   - req: 编辑评论请求
+//
+// 返回值：
+//   - *comment.CommentsVO: 编辑后的评论视图对象
+//   - error: 操作过程中的错误，无权编辑或超过编辑时间窗口时返回业务错误
+func UpdateComment(c echo.Context, req *dto.UpdateCommentRequest) (*comment.CommentsVO, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载评论配置失败: %v", err)
+		return nil, fmt.Errorf("加载评论配置失败: %w", err)
+	}
+	editWindow := int64(cfg.AppConfig.Comment.EditWindow)
+
+	com, err := mapper.GetCommentByID(c, req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取评论失败：%v", err)
+		return nil, fmt.Errorf("评论不存在：%w", err)
+	}
+	if err := checkCommentEditable(c, com, req.EditToken, editWindow); err != nil {
+		return nil, err
+	}
+
+	// 内容未变化的编辑不产生变更，不记录审计日志
+	if req.Content == com.Content {
+		vo, err := utils.MapModelToVO(com, &comment.CommentsVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("编辑评论时映射 VO 失败：%v", err)
+			return nil, fmt.Errorf("编辑评论时映射 VO 失败：%w", err)
+		}
+		return vo.(*comment.CommentsVO), nil
+	}
+
+	// 垃圾评论检测可能请求外部服务，在事务外完成渲染与重新审核，避免事务长时间占用连接
+	edited := *com
+	edited.Ext = nil
+	edited.Content = req.Content
+	if err := renderCommentContent(c, &edited); err != nil {
+		return nil, err
+	}
+	status, err := evaluateCommentStatus(c, &edited)
+	if err != nil {
+		return nil, err
+	}
+
+	var commentVO *comment.CommentsVO
+	var before, after map[string]interface{}
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		// 重新读取评论，以事务内的最新状态为准
+		com, err := mapper.GetCommentByID(c, req.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取评论失败：%v", err)
+			return fmt.Errorf("评论不存在：%w", err)
+		}
+		if err := checkCommentEditable(c, com, req.EditToken, editWindow); err != nil {
+			return err
+		}
+
+		before = commentAuditSummary(com)
+		accountID, _ := utils.GetAuthAccountID(c)
+		if err := mapper.CreateCommentRevision(c, &model.CommentRevision{
+			CommentId:       com.ID,
+			Content:         com.Content,
+			EditorAccountId: accountID,
+			EditorIP:        c.RealIP(),
+		}); err != nil {
+			utils.BizLogger(c).Errorf("保存评论历史版本失败：%v", err)
+			return fmt.Errorf("保存评论历史版本失败：%w", err)
+		}
+
+		com.Content = edited.Content
+		com.ContentHTML = edited.ContentHTML
+		com.IsEdited = true
+		com.EditedAt = time.Now().Unix()
+
+		// 编辑后的内容重新审核，审核状态只会收紧，不会因编辑而自动通过
+		if commentStatusRanks[status] > commentStatusRanks[com.Status] {
+			com.Status = status
+		}
+		if status == model.COMMENT_STATUS_SPAM {
+			if com.Ext == nil {
+				com.Ext = map[string]interface{}{}
+			}
+			for key, value := range edited.Ext {
+				com.Ext[key] = value
+			}
+		}
+
+		if err := mapper.UpdateComment(c, com); err != nil {
+			utils.BizLogger(c).Errorf("编辑评论失败：%v", err)
+			return fmt.Errorf("编辑评论失败：%w", err)
+		}
+		after = commentAuditSummary(com)
+
+		vo, err := utils.MapModelToVO(com, &comment.CommentsVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("编辑评论时映射 VO 失败：%v", err)
+			return fmt.Errorf("编辑评论时映射 VO 失败：%w", err)
+		}
+
+		commentVO = vo.(*comment.CommentsVO)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	recordCommentAudit(c, auditModel.AUDIT_ACTION_COMMENT_UPDATE, req.ID, before, after)
+	return commentVO, nil
+}
+
+// GetCommentRevisions 获取评论的编辑历史，最新的在前
+// 参数：
+//   - c: Echo 上下文
+//   - req: 获取评论编辑历史请求
+//
+// 返回值：
+//   - []*comment.CommentRevisionVO: 历史版本列表
+//   - error: 操作过程中的错误
+func GetCommentRevisions(c echo.Context, req *dto.GetCommentRevisionsRequest) ([]*comment.CommentRevisionVO, error) {
+	revisions, err := mapper.GetCommentRevisionsByCommentID(c, req.CommentID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取评论编辑历史失败：%v", err)
+		return nil, fmt.Errorf("获取评论编辑历史失败：%w", err)
+	}
+
+	revisionsVO := make([]*comment.CommentRevisionVO, 0, len(revisions))
+	for _, revision := range revisions {
+		vo, err := utils.MapModelToVO(revision, &comment.CommentRevisionVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取评论编辑历史时映射 VO 失败：%v", err)
+			return nil, fmt.Errorf("获取评论编辑历史时映射 VO 失败：%w", err)
+		}
+		revisionsVO = append(revisionsVO, vo.(*comment.CommentRevisionVO))
+	}
+
+	return revisionsVO, nil
+}
+
+// recordCommentAudit 记录评论变更的审计日志
+// 参数：
+//   - c: Echo 上下文
+//   - action: 审计事件
+//   - commentID: 评论ID
+//   - before: 变更前摘要
+//   - after: 变更后摘要
+func recordCommentAudit(c echo.Context, action string, commentID int64, before, after map[string]interface{}) {
+	audit.Record(c, &audit.Event{
+		Action:     action,
+		TargetType: auditModel.AUDIT_TARGET_COMMENT,
+		TargetID:   strconv.FormatInt(commentID, 10),
+		Before:     before,
+		After:      after,
+	})
+}
+
+// commentAuditSummary 生成评论的审计摘要，正文只记录长度与摘要
+// 参数：
+//   - com: 评论
+//
+// 返回值：
+//   - map[string]interface{}: 审计摘要
+func commentAuditSummary(com *model.Comment) map[string]interface{} {
+	return map[string]interface{}{
+		"post_id":             strconv.FormatInt(com.PostId, 10),
+		"reply_to_comment_id": strconv.FormatInt(com.ReplyToCommentId, 10),
+		"account_id":          strconv.FormatInt(com.AccountId, 10),
+		"author_name":         com.AuthorName,
+		"status":              com.Status,
+		"deleted":             com.Deleted,
+		"content_length":      len(com.Content),
+		"content_digest":      audit.ContentDigest(com.Content),
+	}
+}
+
+// evaluateCommentStatus 按审核策略与垃圾评论检测判定评论的审核状态，判定为垃圾评论时在扩展字段中记录原因
+// 参数：
+//   - c: Echo 上下文
+//   - com: 评论
+//
+// 返回值：
+//   - string: 审核状态
+//   - error: 操作过程中的错误
+func evaluateCommentStatus(c echo.Context, com *model.Comment) (string, error) {
+	status, err := decideCommentStatus(c, com)
+	if err != nil {
+		utils.BizLogger(c).Errorf("判定评论审核状态失败: %v", err)
+		return "", fmt.Errorf("判定评论审核状态失败: %w", err)
+	}
+	if com.IsAuthorReply {
+		return status, nil
+	}
+
+	input := newSpamInput(c, com)
+	input.UserAgent = c.Request().UserAgent()
+	input.Referrer = c.Request().Referer()
+
+	result, err := spam.Check(c, input)
+	if err != nil {
+		utils.BizLogger(c).Errorf("垃圾评论检测失败: %v", err)
+		return "", fmt.Errorf("垃圾评论检测失败: %w", err)
+	}
+	if result.Spam {
+		utils.BizLogger(c).Warnf("评论被检测器「%s」判定为垃圾评论: %s", result.Checker, result.Reason)
+		if com.Ext == nil {
+			com.Ext = map[string]interface{}{}
+		}
+		com.Ext["spam_checker"] = result.Checker
+		com.Ext["spam_reason"] = result.Reason
+		com.Ext["spam_score"] = result.Score
+		return model.COMMENT_STATUS_SPAM, nil
+	}
+
+	return status, nil
+}
+
+// checkCommentEditable 校验当前请求是否为评论作者且评论仍在可编辑时间内
+// 参数：
+//   - c: Echo 上下文
+//   - com: 评论
+//   - editToken: 游客编辑凭证
+//   - editWindow: 可编辑时长，单位秒
+//
+// 返回值：
+//   - error: 无权编辑或超过可编辑时间时返回业务错误
+func checkCommentEditable(c echo.Context, com *model.Comment, editToken string, editWindow int64) error {
+	if !isCommentAuthor(c, com, editToken) {
+		utils.BizLogger(c).Warnf("无权编辑评论「%d」", com.ID)
+		return bizErr.New(bizErr.FORBIDDEN, "仅评论作者可编辑评论")
+	}
+	if editWindow <= 0 || time.Now().Unix()-com.GmtCreate > editWindow {
+		utils.BizLogger(c).Warnf("评论「%d」已超过可编辑时间", com.ID)
+		return bizErr.New(bizErr.FORBIDDEN, "已超过评论可编辑时间")
+	}
+	return nil
+}
+
+// isCommentAuthor 判断当前请求是否来自评论作者：登录账户匹配评论所属账户，或游客提供了正确的编辑凭证
+// 参数：
+//   - c: Echo 上下文
+//   - com: 评论
+//   - editToken: 游客编辑凭证
+//
+// 返回值：
+//   - bool: 是否为评论作者
+func isCommentAuthor(c echo.Context, com *model.Comment, editToken string) bool {
+	if accountID, authenticated := utils.GetAuthAccountID(c); authenticated && com.AccountId != 0 {
+		return com.AccountId == accountID
+	}
+
+	if com.EditTokenHash == "" || editToken == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashEditToken(editToken)), []byte(com.EditTokenHash)) == 1
+}
+
+// generateEditToken 生成随机的游客编辑凭证
+// 返回值：
+//   - string: 编辑凭证
+//   - error: 操作过程中的错误
+func generateEditToken() (string, error) {
+	buf := make([]byte, EDIT_TOKEN_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashEditToken 计算编辑凭证的 SHA-256 摘要
+// 参数：
+//   - token: 编辑凭证
+//
+// 返回值：
+//   - string: 十六进制摘要
+func hashEditToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// decideCommentStatus 按审核策略判定新评论的审核状态
+// 参数：
+//   - c: Echo 上下文
+//   - com: 待创建的评论
+//
+// 返回值：
+//   - string: 审核状态
+//   - error: 操作过程中的错误
+func decideCommentStatus(c echo.Context, com *model.Comment) (string, error) {
+	if com.IsAuthorReply {
+		return model.COMMENT_STATUS_APPROVED, nil
+	}
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return "", fmt.Errorf("加载评论配置失败: %w", err)
+	}
+
+	hold := false
+	switch cfg.AppConfig.Comment.ModerationPolicy {
+	case MODERATION_POLICY_ALL:
+		hold = true
+	case MODERATION_POLICY_LINKS:
+		hold = commentLinkPattern.MatchString(com.Content)
+	}
+	if !hold {
+		return model.COMMENT_STATUS_APPROVED, nil
+	}
+
+	// 游客填写的邮箱未经验证，仅按登录账户识别已有评论通过审核的评论者
+	if cfg.AppConfig.Comment.AutoApproveKnown && com.AccountId != 0 {
+		approved, err := mapper.CountApprovedCommentsByAccountID(c, com.AccountId, com.ID)
+		if err != nil {
+			return "", err
+		}
+		if approved > 0 {
+			return model.COMMENT_STATUS_APPROVED, nil
+		}
+	}
+
+	return model.COMMENT_STATUS_PENDING, nil
+}
+
+// enqueueSpamLearning 将审核结果放入学习队列，由后台协程反馈给垃圾评论检测器，避免批量审核等待外部服务
+// 参数：
+//   - c: Echo 上下文
+//   - ids: 评论 ID 列表
+//   - label: 训练标签
+func enqueueSpamLearning(c echo.Context, ids []int64, label string) {
+	spamLearnWorkerOnce.Do(startSpamLearnWorker)
+
+	select {
+	case spamLearnQueue <- spamLearnJob{ids: ids, label: label}:
+	default:
+		utils.BizLogger(c).Warnf("垃圾评论学习队列已满，丢弃 %d 条评论的 %s 学习任务", len(ids), label)
+	}
+}
+
+// startSpamLearnWorker 启动垃圾评论学习协程
+func startSpamLearnWorker() {
+	go func() {
+		for job := range spamLearnQueue {
+			learnFromModeration(job)
+		}
+	}()
+}
+
+// learnFromModeration 将审核结果反馈给垃圾评论检测器，学习失败仅记录日志，不影响审核结果
+// 参数：
+//   - job: 学习任务
+func learnFromModeration(job spamLearnJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			global.SysLog.Errorf("垃圾评论学习时发生 panic: %v", r)
+		}
+	}()
+
+	c := utils.NewDetachedContext()
+	comments, err := mapper.GetCommentsByIDs(c, job.ids)
+	if err != nil {
+		global.SysLog.Errorf("获取待学习的评论失败：%v", err)
+		return
+	}
+
+	for _, com := range comments {
+		if com.IsAuthorReply || com.SpamLabel == job.label {
+			continue
+		}
+
+		if err := spam.Learn(c, newSpamInput(c, com), job.label, com.SpamLabel); err != nil {
+			global.SysLog.Errorf("评论「%d」学习失败：%v", com.ID, err)
+			continue
+		}
+		if err := mapper.UpdateCommentSpamLabel(c, com.ID, job.label); err != nil {
+			global.SysLog.Errorf("评论「%d」更新训练标签失败：%v", com.ID, err)
+		}
+	}
+}
+
+// newSpamInput 根据评论构造垃圾评论检测输入，不含请求相关的 User-Agent 与来源页
+// 参数：
+//   - c: Echo 上下文
+//   - com: 评论
+//
+// 返回值：
+//   - *spam.Input: 检测输入
+func newSpamInput(c echo.Context, com *model.Comment) *spam.Input {
+	permalink, err := utils.BuildPostURL(com.PostId)
+	if err != nil {
+		utils.BizLogger(c).Warnf("生成文章「%d」访问地址失败: %v", com.PostId, err)
+	}
+
+	return &spam.Input{
+		Content:       com.Content,
+		AuthorName:    com.AuthorName,
+		AuthorEmail:   com.AuthorEmail,
+		AuthorWebsite: com.AuthorWebsite,
+		AuthorIP:      com.AuthorIP,
+		Permalink:     permalink,
+	}
+}
+
+// renderCommentContent 将评论的 Markdown 源文本渲染为 HTML
+// 参数：
+//   - c: Echo 上下文
+//   - com: 评论
+//
+// 返回值：
+//   - error: 渲染过程中的错误
+func renderCommentContent(c echo.Context, com *model.Comment) error {
+	contentHTML, err := utils.RenderCommentMarkdown([]byte(com.Content))
+	if err != nil {
+		utils.BizLogger(c).Errorf("渲染评论 Markdown 失败: %v", err)
+		return fmt.Errorf("渲染评论 Markdown 失败: %w", err)
+	}
+	com.ContentHTML = contentHTML
+	return nil
+}
+
+// ensureCommentHTML 为尚未保存 HTML 的历史评论即时渲染内容，渲染失败时回退为转义后的源文本
+// 参数：
+//   - c: Echo 上下文
+//   - com: 评论
+func ensureCommentHTML(c echo.Context, com *model.Comment) {
+	if com.ContentHTML != "" || com.Content == "" {
+		return
+	}
+	if err := renderCommentContent(c, com); err != nil {
+		com.ContentHTML = html.EscapeString(com.Content)
+	}
+}
+
+// buildCommentThreads 将评论映射为视图对象，逐层批量加载至多 depth 层回复，并填充每条评论的直接回复数
+// 参数：
+//   - c: Echo 上下文
+//   - comments: 顶层评论列表
+//   - depth: 内联的回复层级，为 0 时不加载回复
+//   - replyLimit: 每条评论内联的回复条数上限
+//   - approvedOnly: 是否仅包含审核通过的评论
+//
+// 返回值：
+//   - []*comment.CommentsVO: 评论视图对象列表
+//   - error: 操作过程中的错误
+func buildCommentThreads(c echo.Context, comments []*model.Comment, depth, replyLimit int, approvedOnly bool) ([]*comment.CommentsVO, error) {
+	vos := make(map[int64]*comment.CommentsVO)
+	toVO := func(com *model.Comment) (*comment.CommentsVO, error) {
+		ensureCommentHTML(c, com)
+		vo, err := utils.MapModelToVO(com, &comment.CommentsVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("评论映射 VO 失败：%v", err)
+			return nil, fmt.Errorf("评论映射 VO 失败：%w", err)
+		}
+		commentVO := vo.(*comment.CommentsVO)
+		commentVO.Replies = make([]*comment.CommentsVO, 0)
+		vos[com.ID] = commentVO
+		return commentVO, nil
+	}
+
+	threads := make([]*comment.CommentsVO, 0, len(comments))
+	level := make([]int64, 0, len(comments))
+	for _, com := range comments {
+		vo, err := toVO(com)
+		if err != nil {
+			return nil, err
+		}
+		threads = append(threads, vo)
+		level = append(level, com.ID)
+	}
+
+	// lastReplies 记录每条评论最后一条内联回复，用于生成继续加载的游标
+	lastReplies := make(map[int64]*model.Comment)
+	for d := 0; d < depth && len(level) > 0; d++ {
+		replies, err := mapper.GetRepliesByParentIDs(c, level, approvedOnly, replyLimit)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取评论回复失败：%v", err)
+			return nil, fmt.Errorf("获取评论回复失败：%w", err)
+		}
+
+		level = level[:0]
+		for _, reply := range replies {
+			vo, err := toVO(reply)
+			if err != nil {
+				return nil, err
+			}
+			parentVO := vos[reply.ReplyToCommentId]
+			parentVO.Replies = append(parentVO.Replies, vo)
+			lastReplies[reply.ReplyToCommentId] = reply
+			level = append(level, reply.ID)
+		}
+	}
+
+	ids := make([]int64, 0, len(vos))
+	for id := range vos {
+		ids = append(ids, id)
+	}
+	counts, err := mapper.CountRepliesGroupByParent(c, ids, approvedOnly)
+	if err != nil {
+		utils.BizLogger(c).Errorf("统计评论回复数失败：%v", err)
+		return nil, fmt.Errorf("统计评论回复数失败：%w", err)
+	}
+
+	for id, vo := range vos {
+		vo.ReplyCount = counts[id]
+		if vo.ReplyCount > int64(len(vo.Replies)) {
+			if last, ok := lastReplies[id]; ok {
+				vo.RepliesCursor = encodeReplyCursor(last.GmtCreate, last.ID)
+			}
+		}
+	}
+
+	return threads, nil
+}
+
+// encodeReplyCursor 将回复的创建时间与 ID 编码为游标
+// 参数：
+//   - gmtCreate: 创建时间
+//   - id: 评论 ID
+//
+// 返回值：
+//   - string: 游标
+func encodeReplyCursor(gmtCreate, id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", gmtCreate, id)))
+}
+
+// decodeReplyCursor 解析回复游标，空游标表示从第一条回复开始
+// 参数：
+//   - cursor: 游标
+//
+// 返回值：
+//   - int64: 创建时间
+//   - int64: 评论 ID
+//   - error: 解析过程中的错误
+func decodeReplyCursor(cursor string) (int64, int64, error) {
+	if cursor == "" {
+		return 0, 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("游标缺少分隔符")
+	}
+	gmtCreate, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return gmtCreate, id, nil
+}
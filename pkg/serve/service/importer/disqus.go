@@ -0,0 +1,119 @@
+// Package importer 提供 Disqus XML 导出文件的解析
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	model "jank.com/jank_blog/internal/model/comment"
+)
+
+// disqusExport Disqus 导出文件根节点
+type disqusExport struct {
+	Threads []disqusThread `xml:"thread"`
+	Posts   []disqusPost   `xml:"post"`
+}
+
+// disqusThread Disqus 讨论串，对应一篇文章
+type disqusThread struct {
+	ID         string `xml:"id,attr"` // dsq:id
+	Identifier string `xml:"id"`      // 站点侧的讨论串标识
+	Link       string `xml:"link"`
+	Title      string `xml:"title"`
+}
+
+// disqusRef Disqus 中通过 dsq:id 引用的节点
+type disqusRef struct {
+	ID string `xml:"id,attr"`
+}
+
+// disqusPost Disqus 评论
+type disqusPost struct {
+	ID        string `xml:"id,attr"` // dsq:id
+	Message   string `xml:"message"`
+	CreatedAt string `xml:"createdAt"`
+	IsDeleted bool   `xml:"isDeleted"`
+	IsSpam    bool   `xml:"isSpam"`
+	Author    struct {
+		Name     string `xml:"name"`
+		Email    string `xml:"email"`
+		Username string `xml:"username"`
+	} `xml:"author"`
+	IPAddress string     `xml:"ipAddress"`
+	Thread    disqusRef  `xml:"thread"`
+	Parent    *disqusRef `xml:"parent"`
+}
+
+// parseDisqus 解析 Disqus XML 导出文件，评论按所属讨论串分组
+// 参数：
+//   - r: 导出文件内容
+//
+// 返回值：
+//   - []*importedThread: 讨论串列表
+//   - error: 解析过程中的错误
+func parseDisqus(r io.Reader) ([]*importedThread, error) {
+	var export disqusExport
+	if err := xml.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("解析 Disqus XML 失败: %w", err)
+	}
+
+	threads := make([]*importedThread, 0, len(export.Threads))
+	byID := make(map[string]*importedThread, len(export.Threads))
+	for _, t := range export.Threads {
+		thread := &importedThread{
+			ID:    t.ID,
+			Title: strings.TrimSpace(t.Title),
+			Link:  strings.TrimSpace(t.Link),
+		}
+		// WordPress 插件生成的标识形如 "123 https://example.com/?p=123"，不能作为别名
+		if identifier := strings.TrimSpace(t.Identifier); identifier != "" && !strings.ContainsAny(identifier, " /") {
+			thread.Slugs = append(thread.Slugs, identifier)
+		}
+		threads = append(threads, thread)
+		byID[t.ID] = thread
+	}
+
+	for _, p := range export.Posts {
+		thread, ok := byID[p.Thread.ID]
+		if !ok {
+			continue
+		}
+
+		ic := &importedComment{
+			ID:          p.ID,
+			AuthorName:  strings.TrimSpace(p.Author.Name),
+			AuthorEmail: strings.TrimSpace(p.Author.Email),
+			AuthorIP:    strings.TrimSpace(p.IPAddress),
+			ContentHTML: p.Message,
+			Status:      model.COMMENT_STATUS_APPROVED,
+		}
+		if ic.AuthorName == "" {
+			ic.AuthorName = strings.TrimSpace(p.Author.Username)
+		}
+		if p.Parent != nil {
+			ic.ParentID = p.Parent.ID
+		}
+		if createdAt, err := time.Parse(time.RFC3339, strings.TrimSpace(p.CreatedAt)); err == nil {
+			ic.CreatedAt = createdAt
+		}
+
+		switch {
+		case p.IsDeleted:
+			ic.SkipReason = "评论在 Disqus 中已删除，已跳过"
+		case p.IsSpam:
+			ic.Status = model.COMMENT_STATUS_SPAM
+		}
+
+		thread.Comments = append(thread.Comments, ic)
+	}
+
+	for _, thread := range threads {
+		sortCommentsByTime(thread.Comments)
+	}
+	return threads, nil
+}
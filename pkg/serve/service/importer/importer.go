@@ -0,0 +1,538 @@
+// Package importer 提供从 Disqus 与 WordPress 导出文件导入评论的功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package importer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+	"github.com/microcosm-cc/bluemonday"
+
+	model "jank.com/jank_blog/internal/model/comment"
+	post "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/comment"
+)
+
+// 导入来源
+const (
+	IMPORT_SOURCE_DISQUS    = "disqus"    // Disqus XML 导出文件
+	IMPORT_SOURCE_WORDPRESS = "wordpress" // WordPress WXR 导出文件
+)
+
+// importedThread 来源中的一个讨论串，对应一篇文章
+type importedThread struct {
+	ID       string             // 来源讨论串 ID
+	Title    string             // 来源文章标题
+	Link     string             // 来源文章地址
+	Slugs    []string           // 来源提供的其他别名（如 WordPress post_name、Disqus identifier）
+	Comments []*importedComment // 讨论串中的评论
+}
+
+// importedComment 来源中的一条评论
+type importedComment struct {
+	ID            string    // 来源评论 ID
+	ParentID      string    // 来源父评论 ID，顶层评论为空
+	AuthorName    string    // 评论者昵称
+	AuthorEmail   string    // 评论者邮箱
+	AuthorWebsite string    // 评论者个人网站
+	AuthorIP      string    // 评论者 IP
+	ContentHTML   string    // 来源中的评论内容（HTML）
+	CreatedAt     time.Time // 原始创建时间
+	Status        string    // 映射后的审核状态
+	SkipReason    string    // 不导入的原因，为空表示导入
+}
+
+// ImportOptions 评论导入选项
+type ImportOptions struct {
+	DryRun       bool   // 为 true 时只生成报告，不写入数据库
+	OwnerEmail   string // 博主在来源平台使用的邮箱，该邮箱的评论关联到博主账户并标记为博主回复，为空时不关联任何账户
+	OwnerAccount string // 关联的本站账户邮箱，为空时与 OwnerEmail 相同
+}
+
+// importOwner 博主在来源平台的邮箱与关联的本站账户
+type importOwner struct {
+	email     string // 小写的来源平台邮箱
+	accountID int64  // 本站账户 ID
+}
+
+// ImportComments 导入评论导出文件：按地址或别名将讨论串匹配到文章，保留回复结构、原始时间与评论者信息；
+// 已导入的评论按来源评论 ID 跳过，重复执行不会产生重复评论。
+// 来源平台的评论者邮箱未经验证，仅显式指定的博主邮箱的评论会关联到本站账户
+// 参数：
+//   - c: Echo 上下文
+//   - source: 导入来源，disqus 或 wordpress
+//   - r: 导出文件内容
+//   - opts: 导入选项
+//
+// 返回值：
+//   - *comment.CommentImportReportVO: 导入报告
+//   - error: 操作过程中的错误
+func ImportComments(c echo.Context, source string, r io.Reader, opts *ImportOptions) (*comment.CommentImportReportVO, error) {
+	owner, err := resolveImportOwner(c, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var threads []*importedThread
+	switch source {
+	case IMPORT_SOURCE_DISQUS:
+		threads, err = parseDisqus(r)
+	case IMPORT_SOURCE_WORDPRESS:
+		threads, err = parseWordPress(r)
+	default:
+		return nil, fmt.Errorf("不支持的导入来源: %s", source)
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 %s 导出文件失败: %v", source, err)
+		return nil, fmt.Errorf("解析 %s 导出文件失败: %w", source, err)
+	}
+
+	posts, err := mapper.GetAllPosts(c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章列表失败: %v", err)
+		return nil, fmt.Errorf("获取文章列表失败: %w", err)
+	}
+	matcher := newPostMatcher(posts)
+
+	report := &comment.CommentImportReportVO{
+		Source:           source,
+		DryRun:           opts.DryRun,
+		UnmatchedThreads: make([]*comment.UnmatchedThreadVO, 0),
+		Issues:           make([]*comment.CommentImportIssueVO, 0),
+	}
+
+	for _, thread := range threads {
+		if len(thread.Comments) == 0 {
+			continue
+		}
+		report.Threads++
+
+		postID, reason := matcher.match(thread)
+		if postID == 0 {
+			report.UnmatchedThreads = append(report.UnmatchedThreads, &comment.UnmatchedThreadVO{
+				ThreadID:     thread.ID,
+				Title:        thread.Title,
+				Link:         thread.Link,
+				CommentCount: len(thread.Comments),
+				Reason:       reason,
+			})
+			continue
+		}
+		report.MatchedThreads++
+
+		if err := importThread(c, source, postID, thread, owner, opts.DryRun, report); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// resolveImportOwner 查找导入选项中指定的博主账户
+// 参数：
+//   - c: Echo 上下文
+//   - opts: 导入选项
+//
+// 返回值：
+//   - *importOwner: 博主身份，未指定博主邮箱时为 nil
+//   - error: 账户不存在时返回错误
+func resolveImportOwner(c echo.Context, opts *ImportOptions) (*importOwner, error) {
+	email := strings.ToLower(strings.TrimSpace(opts.OwnerEmail))
+	if email == "" {
+		return nil, nil
+	}
+
+	accountEmail := strings.TrimSpace(opts.OwnerAccount)
+	if accountEmail == "" {
+		accountEmail = email
+	}
+	acc, err := mapper.GetAccountByEmail(c, accountEmail)
+	if err != nil || acc == nil {
+		utils.BizLogger(c).Errorf("博主账户「%s」不存在: %v", accountEmail, err)
+		return nil, fmt.Errorf("博主账户「%s」不存在", accountEmail)
+	}
+
+	return &importOwner{email: email, accountID: acc.ID}, nil
+}
+
+// importThread 在一个事务中导入讨论串的评论，父评论先于子评论写入
+// 参数：
+//   - c: Echo 上下文
+//   - source: 导入来源
+//   - postID: 匹配到的文章 ID
+//   - thread: 讨论串
+//   - owner: 博主身份，为 nil 时不关联任何账户
+//   - dryRun: 是否仅生成报告
+//   - report: 导入报告
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func importThread(c echo.Context, source string, postID int64, thread *importedThread, owner *importOwner, dryRun bool, report *comment.CommentImportReportVO) error {
+	byID := make(map[string]*importedComment, len(thread.Comments))
+	lookupIDs := make([]string, 0, len(thread.Comments))
+	for _, ic := range thread.Comments {
+		byID[ic.ID] = ic
+		lookupIDs = append(lookupIDs, ic.ID)
+		if ic.ParentID != "" {
+			lookupIDs = append(lookupIDs, ic.ParentID)
+		}
+	}
+
+	existing, err := mapper.GetCommentsByImportIDs(c, source, lookupIDs)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取已导入评论失败: %v", err)
+		return fmt.Errorf("获取已导入评论失败: %w", err)
+	}
+
+	// 来源 ID 到站内评论 ID 的映射，预演时用 -1 占位
+	imported := make(map[string]int64, len(existing))
+	for id, com := range existing {
+		imported[id] = com.ID
+	}
+
+	addIssue := func(ic *importedComment, reason string) {
+		report.Issues = append(report.Issues, &comment.CommentImportIssueVO{
+			ThreadID:  thread.ID,
+			CommentID: ic.ID,
+			Reason:    reason,
+		})
+	}
+
+	var ensure func(ic *importedComment, visiting map[string]bool) (int64, error)
+	ensure = func(ic *importedComment, visiting map[string]bool) (int64, error) {
+		if id, ok := imported[ic.ID]; ok {
+			return id, nil
+		}
+		visiting[ic.ID] = true
+
+		// 父评论被跳过时挂到最近的可导入祖先下，避免丢失回复结构
+		var parentID int64
+		for pid := ic.ParentID; pid != ""; {
+			if id, ok := imported[pid]; ok {
+				parentID = id
+				break
+			}
+			parent, ok := byID[pid]
+			if !ok {
+				addIssue(ic, fmt.Sprintf("父评论「%s」不在导出文件中，已作为顶层评论导入", pid))
+				break
+			}
+			if visiting[pid] {
+				addIssue(ic, "回复关系存在循环，已作为顶层评论导入")
+				break
+			}
+			if parent.SkipReason != "" {
+				pid = parent.ParentID
+				continue
+			}
+			id, err := ensure(parent, visiting)
+			if err != nil {
+				return 0, err
+			}
+			parentID = id
+			break
+		}
+
+		id := int64(-1)
+		if !dryRun {
+			com, created, err := newComment(c, source, postID, parentID, ic, owner)
+			if err != nil {
+				return 0, err
+			}
+			if !created {
+				// 同时运行的其他导入任务已写入该评论
+				imported[ic.ID] = com.ID
+				report.Existing++
+				return com.ID, nil
+			}
+			id = com.ID
+		}
+		imported[ic.ID] = id
+		report.Imported++
+		if owner != nil && strings.EqualFold(strings.TrimSpace(ic.AuthorEmail), owner.email) {
+			report.OwnerComments++
+		}
+		return id, nil
+	}
+
+	return utils.RunDBTransaction(c, func(tx error) error {
+		for _, ic := range thread.Comments {
+			if _, ok := existing[ic.ID]; ok {
+				report.Existing++
+				continue
+			}
+			if ic.SkipReason != "" {
+				report.Skipped++
+				addIssue(ic, ic.SkipReason)
+				continue
+			}
+			if _, err := ensure(ic, make(map[string]bool)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// newComment 写入一条导入的评论并恢复其原始创建时间，来源评论已被其他导入任务写入时返回已存在的评论
+// 参数：
+//   - c: Echo 上下文
+//   - source: 导入来源
+//   - postID: 文章 ID
+//   - parentID: 父评论 ID，顶层评论为 0
+//   - ic: 来源评论
+//   - owner: 博主身份，为 nil 时不关联任何账户
+//
+// 返回值：
+//   - *model.Comment: 写入或已存在的评论
+//   - bool: 是否由本次导入写入
+//   - error: 操作过程中的错误
+func newComment(c echo.Context, source string, postID, parentID int64, ic *importedComment, owner *importOwner) (*model.Comment, bool, error) {
+	content := htmlToMarkdown(ic.ContentHTML)
+	contentHTML, err := utils.RenderCommentMarkdown([]byte(content))
+	if err != nil {
+		utils.BizLogger(c).Errorf("渲染评论「%s」失败: %v", ic.ID, err)
+		return nil, false, fmt.Errorf("渲染评论「%s」失败: %w", ic.ID, err)
+	}
+
+	importID := ic.ID
+	com := &model.Comment{
+		Content:          content,
+		ContentHTML:      contentHTML,
+		PostId:           postID,
+		ReplyToCommentId: parentID,
+		AuthorName:       truncateRunes(ic.AuthorName, 64),
+		AuthorEmail:      truncateRunes(ic.AuthorEmail, 255),
+		AuthorWebsite:    truncateRunes(ic.AuthorWebsite, 255),
+		AuthorIP:         truncateRunes(ic.AuthorIP, 64),
+		Status:           ic.Status,
+		ImportSource:     source,
+		ImportID:         &importID,
+	}
+
+	// 来源平台的邮箱未经验证，仅显式指定的博主邮箱的评论关联到博主账户并标记为博主回复
+	if owner != nil && strings.EqualFold(strings.TrimSpace(ic.AuthorEmail), owner.email) {
+		com.AccountId = owner.accountID
+		com.IsAuthorReply = true
+	}
+
+	created, err := mapper.CreateImportedComment(c, com)
+	if err != nil {
+		utils.BizLogger(c).Errorf("导入评论「%s」失败: %v", ic.ID, err)
+		return nil, false, fmt.Errorf("导入评论「%s」失败: %w", ic.ID, err)
+	}
+	if !created {
+		existing, err := mapper.GetCommentsByImportIDs(c, source, []string{ic.ID})
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取已导入评论「%s」失败: %v", ic.ID, err)
+			return nil, false, fmt.Errorf("获取已导入评论「%s」失败: %w", ic.ID, err)
+		}
+		if existing[ic.ID] == nil {
+			return nil, false, fmt.Errorf("导入评论「%s」冲突，但未找到已导入的评论", ic.ID)
+		}
+		return existing[ic.ID], false, nil
+	}
+
+	if !ic.CreatedAt.IsZero() {
+		if err := mapper.UpdateCommentGmtCreate(c, com.ID, ic.CreatedAt.Unix()); err != nil {
+			utils.BizLogger(c).Errorf("恢复评论「%s」创建时间失败: %v", ic.ID, err)
+			return nil, false, fmt.Errorf("恢复评论「%s」创建时间失败: %w", ic.ID, err)
+		}
+	}
+
+	return com, true, nil
+}
+
+// postMatcher 按地址、别名或标题将讨论串匹配到文章
+type postMatcher struct {
+	posts   map[int64]*post.Post
+	bySlug  map[string][]int64 // 文章别名索引
+	byTitle map[string][]int64 // 文章标题生成的别名索引
+}
+
+// newPostMatcher 为文章列表建立匹配索引
+// 参数：
+//   - posts: 文章列表
+//
+// 返回值：
+//   - *postMatcher: 文章匹配器
+func newPostMatcher(posts []*post.Post) *postMatcher {
+	m := &postMatcher{
+		posts:   make(map[int64]*post.Post, len(posts)),
+		bySlug:  make(map[string][]int64),
+		byTitle: make(map[string][]int64),
+	}
+	for _, pos := range posts {
+		m.posts[pos.ID] = pos
+		if slug := slugify(pos.Slug); slug != "" {
+			m.bySlug[slug] = append(m.bySlug[slug], pos.ID)
+		}
+		if slug := slugify(pos.Title); slug != "" {
+			m.byTitle[slug] = append(m.byTitle[slug], pos.ID)
+		}
+	}
+	return m
+}
+
+// match 依次按本站文章地址、文章别名、文章标题匹配讨论串
+// 参数：
+//   - thread: 讨论串
+//
+// 返回值：
+//   - int64: 匹配到的文章 ID，未匹配时为 0
+//   - string: 未匹配的原因
+func (m *postMatcher) match(thread *importedThread) (int64, string) {
+	if thread.Link != "" {
+		if id, err := utils.ParsePostIDFromURL(thread.Link); err == nil {
+			if _, ok := m.posts[id]; ok {
+				return id, ""
+			}
+		}
+	}
+
+	candidates := make([]string, 0, len(thread.Slugs)+2)
+	for _, slug := range thread.Slugs {
+		candidates = append(candidates, slugify(slug))
+	}
+	candidates = append(candidates, slugFromURL(thread.Link), slugify(thread.Title))
+
+	ambiguous := false
+	for _, index := range []map[string][]int64{m.bySlug, m.byTitle} {
+		for _, slug := range candidates {
+			if slug == "" {
+				continue
+			}
+			switch ids := index[slug]; len(ids) {
+			case 0:
+			case 1:
+				return ids[0], ""
+			default:
+				ambiguous = true
+			}
+		}
+	}
+
+	if ambiguous {
+		return 0, "匹配到多篇文章，请为文章设置唯一别名"
+	}
+	return 0, "未找到地址或别名匹配的文章"
+}
+
+// slugFromURL 取 URL 路径的最后一段作为别名
+// 参数：
+//   - rawURL: 文章地址
+//
+// 返回值：
+//   - string: 别名，无法解析时为空
+func slugFromURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return ""
+	}
+
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+	if len(segments) == 0 {
+		return ""
+	}
+	last := segments[len(segments)-1]
+	for _, ext := range []string{".html", ".htm", ".php"} {
+		last = strings.TrimSuffix(last, ext)
+	}
+	if unescaped, err := url.PathUnescape(last); err == nil {
+		last = unescaped
+	}
+	return slugify(last)
+}
+
+// slugify 将文本规范化为别名：字母与数字转为小写保留，其余连续字符折叠为一个连字符
+// 参数：
+//   - s: 原始文本
+//
+// 返回值：
+//   - string: 别名
+func slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if b.Len() > 0 && !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+var (
+	importCodeBlockPattern = regexp.MustCompile(`(?is)<pre[^>]*>\s*(?:<code[^>]*>)?(.*?)(?:</code>)?\s*</pre>`)
+	importCodePattern      = regexp.MustCompile(`(?is)<code[^>]*>(.*?)</code>`)
+	importAnchorPattern    = regexp.MustCompile(`(?is)<a\s[^>]*?href\s*=\s*["']([^"']+)["'][^>]*>(.*?)</a>`)
+	importParagraphPattern = regexp.MustCompile(`(?i)</p>|</div>|</blockquote>`)
+	importBreakPattern     = regexp.MustCompile(`(?i)<br\s*/?>|</li>`)
+	importBlankLinePattern = regexp.MustCompile(`\n{3,}`)
+	importTextPolicy       = bluemonday.StrictPolicy()
+)
+
+// htmlToMarkdown 将来源中的评论 HTML 转为评论 Markdown：保留链接、代码与换行，去除其余标签
+// 参数：
+//   - s: 评论 HTML
+//
+// 返回值：
+//   - string: Markdown 文本
+func htmlToMarkdown(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = importCodeBlockPattern.ReplaceAllString(s, "\n\n```\n$1\n```\n\n")
+	s = importCodePattern.ReplaceAllString(s, "`$1`")
+	s = importAnchorPattern.ReplaceAllStringFunc(s, func(m string) string {
+		parts := importAnchorPattern.FindStringSubmatch(m)
+		href := html.UnescapeString(parts[1])
+		text := strings.TrimSpace(html.UnescapeString(importTextPolicy.Sanitize(parts[2])))
+		if text == "" || text == href {
+			return href
+		}
+		return fmt.Sprintf("[%s](%s)", text, href)
+	})
+	s = importParagraphPattern.ReplaceAllString(s, "\n\n")
+	s = importBreakPattern.ReplaceAllString(s, "\n")
+	s = html.UnescapeString(importTextPolicy.Sanitize(s))
+	s = importBlankLinePattern.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
+
+// truncateRunes 按字符数截断字符串
+// 参数：
+//   - s: 原始字符串
+//   - n: 最大字符数
+//
+// 返回值：
+//   - string: 截断后的字符串
+func truncateRunes(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// sortCommentsByTime 按创建时间升序排列评论，保证顶层评论与回复的先后顺序稳定
+// 参数：
+//   - comments: 评论列表
+func sortCommentsByTime(comments []*importedComment) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+}
@@ -0,0 +1,130 @@
+// Package importer 提供 WordPress WXR 导出文件的解析
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	model "jank.com/jank_blog/internal/model/comment"
+)
+
+// WordPress 导出文件中的时间格式
+const wxrTimeLayout = "2006-01-02 15:04:05"
+
+// wxrExport WordPress WXR 导出文件根节点
+type wxrExport struct {
+	Items []wxrItem `xml:"channel>item"`
+}
+
+// wxrItem WordPress 文章或页面
+type wxrItem struct {
+	Title    string       `xml:"title"`
+	Link     string       `xml:"link"`
+	PostID   string       `xml:"post_id"`
+	PostName string       `xml:"post_name"`
+	Comments []wxrComment `xml:"comment"`
+}
+
+// wxrComment WordPress 评论
+type wxrComment struct {
+	ID          string `xml:"comment_id"`
+	Author      string `xml:"comment_author"`
+	AuthorEmail string `xml:"comment_author_email"`
+	AuthorURL   string `xml:"comment_author_url"`
+	AuthorIP    string `xml:"comment_author_IP"`
+	Date        string `xml:"comment_date"`
+	DateGMT     string `xml:"comment_date_gmt"`
+	Content     string `xml:"comment_content"`
+	Approved    string `xml:"comment_approved"`
+	Type        string `xml:"comment_type"`
+	Parent      string `xml:"comment_parent"`
+}
+
+// parseWordPress 解析 WordPress WXR 导出文件，每篇文章的评论作为一个讨论串
+// 参数：
+//   - r: 导出文件内容
+//
+// 返回值：
+//   - []*importedThread: 讨论串列表
+//   - error: 解析过程中的错误
+func parseWordPress(r io.Reader) ([]*importedThread, error) {
+	var export wxrExport
+	decoder := xml.NewDecoder(r)
+	// 部分导出文件在标题等字段中直接使用 HTML 实体，放宽解析
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	if err := decoder.Decode(&export); err != nil {
+		return nil, fmt.Errorf("解析 WordPress WXR 失败: %w", err)
+	}
+
+	threads := make([]*importedThread, 0, len(export.Items))
+	for _, item := range export.Items {
+		thread := &importedThread{
+			ID:    strings.TrimSpace(item.PostID),
+			Title: strings.TrimSpace(item.Title),
+			Link:  strings.TrimSpace(item.Link),
+		}
+		if postName := strings.TrimSpace(item.PostName); postName != "" {
+			thread.Slugs = append(thread.Slugs, postName)
+		}
+
+		for _, wc := range item.Comments {
+			ic := &importedComment{
+				ID:            strings.TrimSpace(wc.ID),
+				AuthorName:    strings.TrimSpace(wc.Author),
+				AuthorEmail:   strings.TrimSpace(wc.AuthorEmail),
+				AuthorWebsite: strings.TrimSpace(wc.AuthorURL),
+				AuthorIP:      strings.TrimSpace(wc.AuthorIP),
+				ContentHTML:   wc.Content,
+				CreatedAt:     parseWXRTime(wc.DateGMT, wc.Date),
+			}
+			if parent := strings.TrimSpace(wc.Parent); parent != "" && parent != "0" {
+				ic.ParentID = parent
+			}
+
+			switch strings.TrimSpace(wc.Approved) {
+			case "1":
+				ic.Status = model.COMMENT_STATUS_APPROVED
+			case "0":
+				ic.Status = model.COMMENT_STATUS_PENDING
+			case "spam":
+				ic.Status = model.COMMENT_STATUS_SPAM
+			default:
+				ic.SkipReason = "评论在 WordPress 中已移入回收站，已跳过"
+			}
+
+			switch commentType := strings.TrimSpace(wc.Type); commentType {
+			case "pingback", "trackback":
+				ic.SkipReason = fmt.Sprintf("WordPress %s 不属于评论，已跳过", commentType)
+			}
+
+			thread.Comments = append(thread.Comments, ic)
+		}
+
+		sortCommentsByTime(thread.Comments)
+		threads = append(threads, thread)
+	}
+	return threads, nil
+}
+
+// parseWXRTime 解析评论时间，优先使用 GMT 时间，缺失时按本地时区解析站点时间
+// 参数：
+//   - gmt: comment_date_gmt
+//   - local: comment_date
+//
+// 返回值：
+//   - time.Time: 评论时间，均无法解析时为零值
+func parseWXRTime(gmt, local string) time.Time {
+	if t, err := time.ParseInLocation(wxrTimeLayout, strings.TrimSpace(gmt), time.UTC); err == nil {
+		return t
+	}
+	if t, err := time.ParseInLocation(wxrTimeLayout, strings.TrimSpace(local), time.Local); err == nil {
+		return t
+	}
+	return time.Time{}
+}
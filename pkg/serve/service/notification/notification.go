@@ -0,0 +1,442 @@
+// Package service 提供业务逻辑处理，处理评论邮件通知与退订
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/global"
+	commentModel "jank.com/jank_blog/internal/model/comment"
+	model "jank.com/jank_blog/internal/model/notification"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/notification/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/notification"
+)
+
+const (
+	NOTIFICATION_QUEUE_SIZE = 256                                // 通知队列容量
+	UNSUBSCRIBE_PATH        = "/api/v1/notification/unsubscribe" // 退订接口路径
+)
+
+// 通知类型
+const (
+	NOTIFY_KIND_REPLY       = "reply"       // 评论收到回复，通知原评论者
+	NOTIFY_KIND_NEW_COMMENT = "new_comment" // 文章收到新评论，通知博主
+)
+
+// notificationJob 通知任务
+type notificationJob struct {
+	kind      string // 通知类型
+	commentID int64  // 触发通知的评论 ID
+}
+
+var (
+	notificationQueue      = make(chan notificationJob, NOTIFICATION_QUEUE_SIZE) // 通知队列
+	notificationWorkerOnce sync.Once                                             // 确保通知协程只启动一次
+)
+
+// EnqueueReplyNotification 异步通知被回复的评论者，仅在回复审核通过后调用
+// 参数：
+//   - c: Echo 上下文
+//   - commentID: 回复评论 ID
+func EnqueueReplyNotification(c echo.Context, commentID int64) {
+	enqueue(c, notificationJob{kind: NOTIFY_KIND_REPLY, commentID: commentID})
+}
+
+// EnqueueNewCommentNotification 异步通知博主文章收到新评论
+// 参数：
+//   - c: Echo 上下文
+//   - commentID: 新评论 ID
+func EnqueueNewCommentNotification(c echo.Context, commentID int64) {
+	enqueue(c, notificationJob{kind: NOTIFY_KIND_NEW_COMMENT, commentID: commentID})
+}
+
+// GetUnsubscribePreference 校验退订签名并返回当前的邮件通知偏好，仅用于展示退订确认页，不修改偏好
+// 邮件安全扫描与链接预取会自动访问 GET 链接，因此退订须通过 POST 确认
+// 参数：
+//   - c: Echo 上下文
+//   - req: 退订请求
+//
+// 返回值：
+//   - *notification.EmailPreferenceVO: 当前的邮件通知偏好
+//   - error: 签名无效时返回业务错误
+func GetUnsubscribePreference(c echo.Context, req *dto.UnsubscribeRequest) (*notification.EmailPreferenceVO, error) {
+	pref, err := loadSignedPreference(c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &notification.EmailPreferenceVO{
+		Email:          pref.Email,
+		NotifyReplies:  pref.NotifyReplies,
+		NotifyComments: pref.NotifyComments,
+	}, nil
+}
+
+// Unsubscribe 校验退订签名并更新邮件通知偏好
+// 参数：
+//   - c: Echo 上下文
+//   - req: 退订请求
+//
+// 返回值：
+//   - *notification.EmailPreferenceVO: 更新后的邮件通知偏好
+//   - error: 签名无效时返回业务错误
+func Unsubscribe(c echo.Context, req *dto.UnsubscribeRequest) (*notification.EmailPreferenceVO, error) {
+	pref, err := loadSignedPreference(c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.Kind {
+	case dto.UNSUBSCRIBE_KIND_REPLIES:
+		pref.NotifyReplies = false
+	case dto.UNSUBSCRIBE_KIND_COMMENTS:
+		pref.NotifyComments = false
+	case dto.UNSUBSCRIBE_KIND_ALL:
+		pref.NotifyReplies = false
+		pref.NotifyComments = false
+	}
+
+	if err := mapper.SaveEmailPreference(c, pref); err != nil {
+		utils.BizLogger(c).Errorf("保存邮件通知偏好失败: %v", err)
+		return nil, bizErr.New(bizErr.SERVER_ERR, "保存邮件通知偏好失败")
+	}
+
+	return &notification.EmailPreferenceVO{
+		Email:          pref.Email,
+		NotifyReplies:  pref.NotifyReplies,
+		NotifyComments: pref.NotifyComments,
+	}, nil
+}
+
+// RenderUnsubscribePage 渲染退订确认页或退订结果页
+// 参数：
+//   - req: 退订请求
+//   - pref: 邮件通知偏好
+//   - done: 是否已完成退订
+//
+// 返回值：
+//   - string: 页面 HTML
+//   - error: 渲染过程中的错误
+func RenderUnsubscribePage(req *dto.UnsubscribeRequest, pref *notification.EmailPreferenceVO, done bool) (string, error) {
+	query := url.Values{"email": {req.Email}, "kind": {req.Kind}, "sig": {req.Sig}}
+	return renderUnsubscribePage(&unsubscribePage{
+		ActionURL:      UNSUBSCRIBE_PATH + "?" + query.Encode(),
+		Email:          pref.Email,
+		KindText:       unsubscribeKindTexts[req.Kind],
+		NotifyReplies:  pref.NotifyReplies,
+		NotifyComments: pref.NotifyComments,
+		Done:           done,
+	})
+}
+
+// loadSignedPreference 校验退订签名并获取邮件通知偏好，不存在时返回默认偏好
+// 参数：
+//   - c: Echo 上下文
+//   - req: 退订请求
+//
+// 返回值：
+//   - *model.EmailPreference: 邮件通知偏好
+//   - error: 签名无效时返回业务错误
+func loadSignedPreference(c echo.Context, req *dto.UnsubscribeRequest) (*model.EmailPreference, error) {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
+	expected, err := signUnsubscribe(email, req.Kind)
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成退订签名失败: %v", err)
+		return nil, bizErr.New(bizErr.SERVER_ERR, "生成退订签名失败")
+	}
+	if !hmac.Equal([]byte(expected), []byte(req.Sig)) {
+		utils.BizLogger(c).Warnf("邮箱「%s」的退订签名无效", email)
+		return nil, bizErr.New(bizErr.BAD_REQUEST, "退订链接无效")
+	}
+
+	pref, err := mapper.GetEmailPreferenceByEmail(c, email)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取邮件通知偏好失败: %v", err)
+		return nil, bizErr.New(bizErr.SERVER_ERR, "获取邮件通知偏好失败")
+	}
+	if pref == nil {
+		pref = &model.EmailPreference{Email: email, NotifyReplies: true, NotifyComments: true}
+	}
+	return pref, nil
+}
+
+// enqueue 将通知任务加入队列，队列已满时丢弃并记录日志，不阻塞评论请求
+// 参数：
+//   - c: Echo 上下文
+//   - job: 通知任务
+func enqueue(c echo.Context, job notificationJob) {
+	notificationWorkerOnce.Do(startNotificationWorker)
+
+	select {
+	case notificationQueue <- job:
+	default:
+		utils.BizLogger(c).Warnf("邮件通知队列已满，丢弃评论「%d」的 %s 通知", job.commentID, job.kind)
+	}
+}
+
+// startNotificationWorker 启动邮件通知协程
+func startNotificationWorker() {
+	go func() {
+		for job := range notificationQueue {
+			processNotification(job)
+		}
+	}()
+}
+
+// processNotification 处理单个通知任务
+// 参数：
+//   - job: 通知任务
+func processNotification(job notificationJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			global.SysLog.Errorf("发送评论「%d」的 %s 通知时发生 panic: %v", job.commentID, job.kind, r)
+		}
+	}()
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		global.SysLog.Errorf("加载通知配置失败: %v", err)
+		return
+	}
+	if !cfg.AppConfig.Notification.NotificationEnabled {
+		return
+	}
+
+	c := utils.NewDetachedContext()
+	var mail *notificationMail
+	switch job.kind {
+	case NOTIFY_KIND_REPLY:
+		mail, err = buildReplyMail(c, job.commentID)
+	case NOTIFY_KIND_NEW_COMMENT:
+		mail, err = buildNewCommentMail(c, job.commentID, cfg.AppConfig.Notification.OwnerEmail)
+	default:
+		err = fmt.Errorf("不支持的通知类型「%s」", job.kind)
+	}
+	if err != nil {
+		global.SysLog.Errorf("生成评论「%d」的 %s 通知失败: %v", job.commentID, job.kind, err)
+		return
+	}
+	if mail == nil {
+		return
+	}
+
+	msg, err := renderNotificationMail(mail)
+	if err != nil {
+		global.SysLog.Errorf("渲染评论「%d」的 %s 通知失败: %v", job.commentID, job.kind, err)
+		return
+	}
+
+	if _, err := utils.SendEmailMessage(msg, []string{mail.To}); err != nil {
+		global.SysLog.Errorf("发送评论「%d」的 %s 通知失败: %v", job.commentID, job.kind, err)
+		return
+	}
+	global.SysLog.Infof("已发送评论「%d」的 %s 通知", job.commentID, job.kind)
+}
+
+// buildReplyMail 生成评论回复通知，被回复者未留邮箱、退订或回复自己时返回 nil
+// 参数：
+//   - c: Echo 上下文
+//   - commentID: 回复评论 ID
+//
+// 返回值：
+//   - *notificationMail: 通知邮件
+//   - error: 操作过程中的错误
+func buildReplyMail(c echo.Context, commentID int64) (*notificationMail, error) {
+	reply, err := mapper.GetCommentByID(c, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if reply.Status != commentModel.COMMENT_STATUS_APPROVED || reply.ReplyToCommentId == 0 {
+		return nil, nil
+	}
+
+	parent, err := mapper.GetCommentByID(c, reply.ReplyToCommentId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 博主的评论被回复时已通过新评论通知获知
+	to := strings.ToLower(parent.AuthorEmail)
+	if to == "" || parent.IsAuthorReply || strings.EqualFold(to, reply.AuthorEmail) {
+		return nil, nil
+	}
+
+	subscribed, err := isSubscribed(c, to, dto.UNSUBSCRIBE_KIND_REPLIES)
+	if err != nil || !subscribed {
+		return nil, err
+	}
+
+	postTitle, postURL := describePost(c, reply.PostId)
+	unsubscribeURL, err := buildUnsubscribeURL(to, dto.UNSUBSCRIBE_KIND_REPLIES)
+	if err != nil {
+		return nil, err
+	}
+
+	return &notificationMail{
+		To:             to,
+		Subject:        fmt.Sprintf("【Jank Blog】你在《%s》的评论有了新回复", postTitle),
+		Heading:        "你的评论有了新回复",
+		Intro:          fmt.Sprintf("%s，你在《%s》下的评论：", parent.AuthorName, postTitle),
+		Quote:          parent.Content,
+		AuthorName:     reply.AuthorName,
+		Content:        reply.Content,
+		ActionURL:      postURL,
+		ActionText:     "查看回复",
+		UnsubscribeURL: unsubscribeURL,
+	}, nil
+}
+
+// buildNewCommentMail 生成博主新评论通知，未配置博主邮箱、博主已退订或评论为垃圾评论时返回 nil
+// 参数：
+//   - c: Echo 上下文
+//   - commentID: 新评论 ID
+//   - ownerEmail: 博主邮箱
+//
+// 返回值：
+//   - *notificationMail: 通知邮件
+//   - error: 操作过程中的错误
+func buildNewCommentMail(c echo.Context, commentID int64, ownerEmail string) (*notificationMail, error) {
+	to := strings.ToLower(strings.TrimSpace(ownerEmail))
+	if to == "" {
+		return nil, nil
+	}
+
+	com, err := mapper.GetCommentByID(c, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if com.IsAuthorReply || com.Status == commentModel.COMMENT_STATUS_SPAM {
+		return nil, nil
+	}
+
+	subscribed, err := isSubscribed(c, to, dto.UNSUBSCRIBE_KIND_COMMENTS)
+	if err != nil || !subscribed {
+		return nil, err
+	}
+
+	postTitle, postURL := describePost(c, com.PostId)
+	unsubscribeURL, err := buildUnsubscribeURL(to, dto.UNSUBSCRIBE_KIND_COMMENTS)
+	if err != nil {
+		return nil, err
+	}
+
+	intro := fmt.Sprintf("《%s》收到一条新评论。", postTitle)
+	if com.Status == commentModel.COMMENT_STATUS_PENDING {
+		intro = fmt.Sprintf("《%s》收到一条新评论，正在等待审核。", postTitle)
+	}
+
+	return &notificationMail{
+		To:             to,
+		Subject:        fmt.Sprintf("【Jank Blog】《%s》收到新评论", postTitle),
+		Heading:        "文章收到新评论",
+		Intro:          intro,
+		AuthorName:     com.AuthorName,
+		Content:        com.Content,
+		ActionURL:      postURL,
+		ActionText:     "查看文章",
+		UnsubscribeURL: unsubscribeURL,
+	}, nil
+}
+
+// isSubscribed 检查邮箱是否订阅了指定类型的通知
+// 参数：
+//   - c: Echo 上下文
+//   - email: 小写的邮箱地址
+//   - kind: 通知类型，replies 或 comments
+//
+// 返回值：
+//   - bool: 是否订阅
+//   - error: 操作过程中的错误
+func isSubscribed(c echo.Context, email, kind string) (bool, error) {
+	pref, err := mapper.GetEmailPreferenceByEmail(c, email)
+	if err != nil || pref == nil {
+		return err == nil, err
+	}
+
+	if kind == dto.UNSUBSCRIBE_KIND_REPLIES {
+		return pref.NotifyReplies, nil
+	}
+	return pref.NotifyComments, nil
+}
+
+// describePost 获取文章标题与访问地址，失败时使用占位内容
+// 参数：
+//   - c: Echo 上下文
+//   - postID: 文章 ID
+//
+// 返回值：
+//   - string: 文章标题
+//   - string: 文章访问地址
+func describePost(c echo.Context, postID int64) (string, string) {
+	title := fmt.Sprintf("文章 %d", postID)
+	if pos, err := mapper.GetPostByID(c, postID); err == nil {
+		title = pos.Title
+	}
+
+	postURL, err := utils.BuildPostURL(postID)
+	if err != nil {
+		global.SysLog.Warnf("生成文章「%d」访问地址失败: %v", postID, err)
+	}
+	return title, postURL
+}
+
+// buildUnsubscribeURL 生成带签名的一键退订地址
+// 参数：
+//   - email: 小写的邮箱地址
+//   - kind: 退订类型
+//
+// 返回值：
+//   - string: 退订地址
+//   - error: 操作过程中的错误
+func buildUnsubscribeURL(email, kind string) (string, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return "", fmt.Errorf("加载站点配置失败: %w", err)
+	}
+
+	sig, err := signUnsubscribe(email, kind)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{"email": {email}, "kind": {kind}, "sig": {sig}}
+	return strings.TrimRight(cfg.AppConfig.Site.ApiURL, "/") + UNSUBSCRIBE_PATH + "?" + query.Encode(), nil
+}
+
+// signUnsubscribe 使用 HMAC-SHA256 对邮箱与退订类型签名
+// 参数：
+//   - email: 小写的邮箱地址
+//   - kind: 退订类型
+//
+// 返回值：
+//   - string: URL 安全的 Base64 签名
+//   - error: 未配置签名密钥时返回错误
+func signUnsubscribe(email, kind string) (string, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return "", fmt.Errorf("加载通知配置失败: %w", err)
+	}
+
+	secret := cfg.AppConfig.Notification.UnsubscribeSecret
+	if secret == "" {
+		return "", fmt.Errorf("未配置退订链接签名密钥")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(kind + ":" + email))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,141 @@
+// Package service 提供业务逻辑处理，处理评论通知邮件模板渲染
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"bytes"
+	"fmt"
+	htmlTemplate "html/template"
+	textTemplate "text/template"
+
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/notification/dto"
+)
+
+// unsubscribeKindTexts 退订类型对应的展示文字
+var unsubscribeKindTexts = map[string]string{
+	dto.UNSUBSCRIBE_KIND_REPLIES:  "评论回复通知",
+	dto.UNSUBSCRIBE_KIND_COMMENTS: "新评论通知",
+	dto.UNSUBSCRIBE_KIND_ALL:      "全部邮件通知",
+}
+
+// unsubscribePage 退订确认页内容
+type unsubscribePage struct {
+	ActionURL      string // 确认退订的 POST 地址
+	Email          string // 邮箱地址
+	KindText       string // 退订类型展示文字
+	NotifyReplies  bool   // 是否接收评论回复通知
+	NotifyComments bool   // 是否接收新评论通知
+	Done           bool   // 是否已完成退订
+}
+
+// notificationMail 通知邮件内容
+type notificationMail struct {
+	To             string // 收件人
+	Subject        string // 邮件主题
+	Heading        string // 正文标题
+	Intro          string // 引导语
+	Quote          string // 被回复的原评论，为空时不展示
+	AuthorName     string // 新评论作者
+	Content        string // 新评论内容
+	ActionURL      string // 查看地址
+	ActionText     string // 查看按钮文字
+	UnsubscribeURL string // 退订地址
+}
+
+// notificationHTML HTML 邮件模板，内容均经过转义
+var notificationHTML = htmlTemplate.Must(htmlTemplate.New("notification").Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head><meta charset="UTF-8"><title>{{.Subject}}</title></head>
+<body style="margin:0;padding:24px;background:#f5f5f5;font-family:-apple-system,'PingFang SC','Microsoft YaHei',sans-serif;color:#333;">
+  <div style="max-width:600px;margin:0 auto;background:#fff;border-radius:8px;padding:24px;">
+    <h2 style="margin:0 0 16px;font-size:20px;">{{.Heading}}</h2>
+    <p style="margin:0 0 12px;">{{.Intro}}</p>
+    {{if .Quote}}<blockquote style="margin:0 0 16px;padding:8px 12px;border-left:4px solid #ddd;color:#666;white-space:pre-wrap;">{{.Quote}}</blockquote>{{end}}
+    <p style="margin:0 0 8px;"><strong>{{.AuthorName}}</strong>：</p>
+    <div style="margin:0 0 24px;padding:12px;background:#fafafa;border-radius:4px;white-space:pre-wrap;">{{.Content}}</div>
+    {{if .ActionURL}}<p style="margin:0 0 24px;"><a href="{{.ActionURL}}" style="display:inline-block;padding:8px 16px;background:#333;color:#fff;border-radius:4px;text-decoration:none;">{{.ActionText}}</a></p>{{end}}
+    <p style="margin:0;font-size:12px;color:#999;">不想再收到此类邮件？<a href="{{.UnsubscribeURL}}" style="color:#999;">一键退订</a></p>
+  </div>
+</body>
+</html>
+`))
+
+// unsubscribeHTML 退订确认页模板，GET 访问仅展示当前偏好，点击按钮后以 POST 提交退订
+var unsubscribeHTML = htmlTemplate.Must(htmlTemplate.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head><meta charset="UTF-8"><meta name="robots" content="noindex"><title>退订邮件通知</title></head>
+<body style="margin:0;padding:24px;background:#f5f5f5;font-family:-apple-system,'PingFang SC','Microsoft YaHei',sans-serif;color:#333;">
+  <div style="max-width:480px;margin:0 auto;background:#fff;border-radius:8px;padding:24px;">
+    <h2 style="margin:0 0 16px;font-size:20px;">{{if .Done}}已退订{{.KindText}}{{else}}退订{{.KindText}}{{end}}</h2>
+    <p style="margin:0 0 12px;">邮箱：{{.Email}}</p>
+    <ul style="margin:0 0 24px;padding-left:20px;">
+      <li>评论回复通知：{{if .NotifyReplies}}接收{{else}}已退订{{end}}</li>
+      <li>新评论通知：{{if .NotifyComments}}接收{{else}}已退订{{end}}</li>
+    </ul>
+    {{if not .Done}}<form method="post" action="{{.ActionURL}}">
+      <input type="hidden" name="List-Unsubscribe" value="One-Click">
+      <button type="submit" style="padding:8px 16px;background:#333;color:#fff;border:0;border-radius:4px;cursor:pointer;">确认退订</button>
+    </form>{{end}}
+  </div>
+</body>
+</html>
+`))
+
+// notificationText 纯文本邮件模板
+var notificationText = textTemplate.Must(textTemplate.New("notification").Parse(`{{.Heading}}
+
+{{.Intro}}
+{{if .Quote}}
+> {{.Quote}}
+{{end}}
+{{.AuthorName}}：
+{{.Content}}
+{{if .ActionURL}}
+{{.ActionText}}：{{.ActionURL}}
+{{end}}
+退订此类邮件：{{.UnsubscribeURL}}
+`))
+
+// renderNotificationMail 渲染通知邮件，并附加一键退订邮件头
+// 参数：
+//   - mail: 通知邮件内容
+//
+// 返回值：
+//   - *utils.EmailMessage: 待发送的邮件
+//   - error: 渲染过程中的错误
+func renderNotificationMail(mail *notificationMail) (*utils.EmailMessage, error) {
+	var htmlBody, textBody bytes.Buffer
+	if err := notificationHTML.Execute(&htmlBody, mail); err != nil {
+		return nil, fmt.Errorf("渲染 HTML 邮件失败: %w", err)
+	}
+	if err := notificationText.Execute(&textBody, mail); err != nil {
+		return nil, fmt.Errorf("渲染纯文本邮件失败: %w", err)
+	}
+
+	return &utils.EmailMessage{
+		Subject:  mail.Subject,
+		TextBody: textBody.String(),
+		HTMLBody: htmlBody.String(),
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + mail.UnsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}, nil
+}
+
+// renderUnsubscribePage 渲染退订确认页
+// 参数：
+//   - page: 退订确认页内容
+//
+// 返回值：
+//   - string: 页面 HTML
+//   - error: 渲染过程中的错误
+func renderUnsubscribePage(page *unsubscribePage) (string, error) {
+	var body bytes.Buffer
+	if err := unsubscribeHTML.Execute(&body, page); err != nil {
+		return "", fmt.Errorf("渲染退订确认页失败: %w", err)
+	}
+	return body.String(), nil
+}
@@ -15,8 +15,12 @@ import (
 	"github.com/minio/minio-go/v7"
 
 	"jank.com/jank_blog/internal/global"
+	auditModel "jank.com/jank_blog/internal/model/audit"
+	model "jank.com/jank_blog/internal/model/oss"
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/oss/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	audit "jank.com/jank_blog/pkg/serve/service/audit"
 	"jank.com/jank_blog/pkg/vo/oss"
 )
 
@@ -80,6 +84,30 @@ func UploadOneFile(c echo.Context, req *dto.UploadOneFileRequest) (*oss.UploadVO
 		return nil, fmt.Errorf("上传到 MinIO 失败: %w", err)
 	}
 
+	// 文件已写入对象存储，记录失败只影响账户数据导出，不影响本次上传
+	accountID, _ := utils.GetAuthAccountID(c)
+	if err := mapper.CreateOssObject(c, &model.OssObject{
+		AccountID:   accountID,
+		BucketName:  req.BucketName,
+		ObjectName:  objectName,
+		FileName:    file.Filename,
+		Size:        file.Size,
+		ContentType: utils.GetMimeType(file.Filename),
+	}); err != nil {
+		utils.BizLogger(c).Errorf("保存「%s/%s」上传记录失败: %v", req.BucketName, objectName, err)
+	}
+
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_OSS_UPLOAD,
+		TargetType: auditModel.AUDIT_TARGET_OBJECT,
+		TargetID:   fmt.Sprintf("%s/%s", req.BucketName, objectName),
+		After: map[string]interface{}{
+			"file_name":    file.Filename,
+			"size":         file.Size,
+			"content_type": utils.GetMimeType(file.Filename),
+		},
+	})
+
 	return &oss.UploadVO{
 		ObjectPath: fmt.Sprintf("/%s/%s", req.BucketName, objectName),
 	}, nil
@@ -140,6 +168,16 @@ func DeleteOneFile(c echo.Context, req *dto.DeleteOneFileRequest) error {
 		utils.BizLogger(c).Errorf("从 MinIO 删除文件失败: %v", err)
 		return fmt.Errorf("从 MinIO 删除文件失败: %w", err)
 	}
+
+	if err := mapper.DeleteOssObject(c, req.BucketName, req.ObjectName); err != nil {
+		utils.BizLogger(c).Errorf("删除「%s/%s」上传记录失败: %v", req.BucketName, req.ObjectName, err)
+	}
+
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_OSS_DELETE,
+		TargetType: auditModel.AUDIT_TARGET_OBJECT,
+		TargetID:   fmt.Sprintf("%s/%s", req.BucketName, req.ObjectName),
+	})
 	return nil
 }
 
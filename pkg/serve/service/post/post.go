@@ -10,13 +10,18 @@ import (
 	"mime/multipart"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
+	bizErr "jank.com/jank_blog/internal/error"
+	auditModel "jank.com/jank_blog/internal/model/audit"
 	model "jank.com/jank_blog/internal/model/post"
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/post/dto"
 	"jank.com/jank_blog/pkg/serve/mapper"
+	audit "jank.com/jank_blog/pkg/serve/service/audit"
+	webmention "jank.com/jank_blog/pkg/serve/service/webmention"
 	"jank.com/jank_blog/pkg/vo/post"
 )
 
@@ -83,15 +88,24 @@ func CreateOnePost(c echo.Context, req *dto.CreateOnePostRequest) (*post.PostsVO
 		return nil, fmt.Errorf("渲染 Markdown 失败: %w", err)
 	}
 
+	authorID, _ := utils.GetAuthAccountID(c)
+
 	var postsVO *post.PostsVO
+	var newPost *model.Post
 
 	err = utils.RunDBTransaction(c, func(tx error) error {
-		newPost := &model.Post{
+		newPost = &model.Post{
+			AuthorID:        authorID,
 			Title:           req.Title,
+			Slug:            req.Slug,
 			Image:           req.Image,
 			Visibility:      req.Visibility,
 			ContentMarkdown: contentMarkdown,
 			ContentHTML:     contentHTML,
+
+			CommentsDisabled:       req.CommentsDisabled,
+			CommentsCloseAfterDays: req.CommentsCloseAfterDays,
+			CommentsLoginRequired:  req.CommentsLoginRequired,
 		}
 
 		if err := mapper.CreatePost(c, newPost); err != nil {
@@ -112,6 +126,7 @@ func CreateOnePost(c echo.Context, req *dto.CreateOnePostRequest) (*post.PostsVO
 
 		postsVO = vo.(*post.PostsVO)
 		postsVO.CategoryID = strconv.FormatInt(categoryID, 10)
+		postsVO.CommentsOpen = newPost.CommentsOpen(time.Now())
 
 		return nil
 	})
@@ -120,6 +135,18 @@ func CreateOnePost(c echo.Context, req *dto.CreateOnePostRequest) (*post.PostsVO
 		return nil, err
 	}
 
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_POST_CREATE,
+		TargetType: auditModel.AUDIT_TARGET_POST,
+		TargetID:   strconv.FormatInt(newPost.ID, 10),
+		After:      postAuditSummary(newPost, categoryID),
+	})
+
+	// 公开文章发布后向正文中的外部链接发送 Webmention
+	if req.Visibility {
+		webmention.SendWebmentionsForPost(newPost.ID, contentMarkdown)
+	}
+
 	return postsVO, nil
 }
 
@@ -149,6 +176,12 @@ func GetOnePostByID(c echo.Context, req *dto.GetOnePostRequest) (*post.PostsVO,
 	}
 
 	postsVO := vo.(*post.PostsVO)
+	fillPostCommentStats(c, []*model.Post{pos}, []*post.PostsVO{postsVO})
+
+	// 通过 Link 头声明 Webmention 接收端点
+	if endpoint, err := utils.WebmentionEndpointURL(); err == nil {
+		c.Response().Header().Set("Link", fmt.Sprintf("<%s>; rel=\"webmention\"", endpoint))
+	}
 
 	postCategory, err := mapper.GetPostCategory(c, pos.ID)
 	if err != nil {
@@ -205,6 +238,8 @@ func GetAllPostsWithPagingAndFormat(c echo.Context, page, pageSize int) (map[str
 		postResponse[i] = postVO
 	}
 
+	fillPostCommentStats(c, posts, postResponse)
+
 	return map[string]interface{}{
 		"posts":       &postResponse,
 		"totalPages":  int(math.Ceil(float64(total) / float64(pageSize))),
@@ -230,12 +265,25 @@ func UpdateOnePost(c echo.Context, req *dto.UpdateOnePostRequest) (*post.PostsVO
 		return nil, fmt.Errorf("获取文章失败: %w", err)
 	}
 
+	if err := checkPostOwnership(c, pos); err != nil {
+		return nil, err
+	}
+
+	var oldCategoryID int64
+	if postCategory, err := mapper.GetPostCategory(c, pos.ID); err == nil {
+		oldCategoryID = postCategory.CategoryID
+	}
+	before := postAuditSummary(pos, oldCategoryID)
+
 	contentType := c.Request().Header.Get("Content-Type")
 	switch {
 	case contentType == "application/json":
 		if req.Title != "" {
 			pos.Title = req.Title
 		}
+		if req.Slug != "" {
+			pos.Slug = req.Slug
+		}
 		if req.Image != "" {
 			pos.Image = req.Image
 		}
@@ -279,6 +327,9 @@ func UpdateOnePost(c echo.Context, req *dto.UpdateOnePostRequest) (*post.PostsVO
 		if title := c.FormValue("title"); title != "" {
 			pos.Title = title
 		}
+		if slug := c.FormValue("slug"); slug != "" {
+			pos.Slug = slug
+		}
 		if image := c.FormValue("image"); image != "" {
 			pos.Image = image
 		}
@@ -304,6 +355,16 @@ func UpdateOnePost(c echo.Context, req *dto.UpdateOnePostRequest) (*post.PostsVO
 		}
 	}
 
+	if req.CommentsDisabled != nil {
+		pos.CommentsDisabled = *req.CommentsDisabled
+	}
+	if req.CommentsCloseAfterDays != nil {
+		pos.CommentsCloseAfterDays = *req.CommentsCloseAfterDays
+	}
+	if req.CommentsLoginRequired != nil {
+		pos.CommentsLoginRequired = *req.CommentsLoginRequired
+	}
+
 	var postsVO *post.PostsVO
 
 	err = utils.RunDBTransaction(c, func(tx error) error {
@@ -312,6 +373,11 @@ func UpdateOnePost(c echo.Context, req *dto.UpdateOnePostRequest) (*post.PostsVO
 			return fmt.Errorf("更新文章失败: %w", err)
 		}
 
+		if err := mapper.UpdatePostCommentSettings(c, req.ID, pos); err != nil {
+			utils.BizLogger(c).Errorf("更新文章评论设置失败: %v", err)
+			return fmt.Errorf("更新文章评论设置失败: %w", err)
+		}
+
 		if err := mapper.UpdatePostCategory(c, req.ID, categoryID); err != nil {
 			utils.BizLogger(c).Errorf("更新文章-类目关联失败: %v", err)
 			return fmt.Errorf("更新文章-类目关联失败: %w", err)
@@ -333,6 +399,21 @@ func UpdateOnePost(c echo.Context, req *dto.UpdateOnePostRequest) (*post.PostsVO
 		return nil, err
 	}
 
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_POST_UPDATE,
+		TargetType: auditModel.AUDIT_TARGET_POST,
+		TargetID:   strconv.FormatInt(pos.ID, 10),
+		Before:     before,
+		After:      postAuditSummary(pos, categoryID),
+	})
+
+	fillPostCommentStats(c, []*model.Post{pos}, []*post.PostsVO{postsVO})
+
+	// 公开文章更新后重新向正文中的外部链接发送 Webmention
+	if pos.Visibility {
+		webmention.SendWebmentionsForPost(pos.ID, pos.ContentMarkdown)
+	}
+
 	return postsVO, nil
 }
 
@@ -344,7 +425,25 @@ func UpdateOnePost(c echo.Context, req *dto.UpdateOnePostRequest) (*post.PostsVO
 // 返回值：
 //   - error: 操作过程中的错误
 func DeleteOnePost(c echo.Context, req *dto.DeleteOnePostRequest) error {
-	return utils.RunDBTransaction(c, func(tx error) error {
+	var before map[string]interface{}
+
+	err := utils.RunDBTransaction(c, func(tx error) error {
+		pos, err := mapper.GetPostByID(c, req.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取文章失败: %v", err)
+			return fmt.Errorf("获取文章失败: %w", err)
+		}
+
+		if err := checkPostOwnership(c, pos); err != nil {
+			return err
+		}
+
+		var categoryID int64
+		if postCategory, err := mapper.GetPostCategory(c, pos.ID); err == nil {
+			categoryID = postCategory.CategoryID
+		}
+		before = postAuditSummary(pos, categoryID)
+
 		if err := mapper.DeleteOnePostByID(c, req.ID); err != nil {
 			utils.BizLogger(c).Errorf("删除文章失败: %v", err)
 			return fmt.Errorf("删除文章失败: %w", err)
@@ -357,4 +456,80 @@ func DeleteOnePost(c echo.Context, req *dto.DeleteOnePostRequest) error {
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	audit.Record(c, &audit.Event{
+		Action:     auditModel.AUDIT_ACTION_POST_DELETE,
+		TargetType: auditModel.AUDIT_TARGET_POST,
+		TargetID:   strconv.FormatInt(req.ID, 10),
+		Before:     before,
+	})
+	return nil
+}
+
+// checkPostOwnership 校验当前账户能否编辑、删除文章，未拥有编辑任意文章权限的账户只能操作自己的文章
+// 参数：
+//   - c: Echo 上下文
+//   - pos: 文章
+//
+// 返回值：
+//   - error: 无权操作时返回 FORBIDDEN 业务错误
+func checkPostOwnership(c echo.Context, pos *model.Post) error {
+	if utils.HasPermission(c, utils.PERMISSION_POST_EDIT_ANY) {
+		return nil
+	}
+
+	accountID, _ := utils.GetAuthAccountID(c)
+	if accountID == 0 || pos.AuthorID != accountID {
+		utils.BizLogger(c).Warnf("账户「%d」无权操作文章「%d」", accountID, pos.ID)
+		return bizErr.New(bizErr.FORBIDDEN, "只能编辑或删除自己的文章")
+	}
+	return nil
+}
+
+// postAuditSummary 生成文章的审计摘要，正文只记录长度与摘要
+// 参数：
+//   - pos: 文章
+//   - categoryID: 文章所属类目ID
+//
+// 返回值：
+//   - map[string]interface{}: 审计摘要
+func postAuditSummary(pos *model.Post, categoryID int64) map[string]interface{} {
+	return map[string]interface{}{
+		"title":                     pos.Title,
+		"slug":                      pos.Slug,
+		"author_id":                 strconv.FormatInt(pos.AuthorID, 10),
+		"category_id":               strconv.FormatInt(categoryID, 10),
+		"visibility":                pos.Visibility,
+		"content_length":            len(pos.ContentMarkdown),
+		"content_digest":            audit.ContentDigest(pos.ContentMarkdown),
+		"comments_disabled":         pos.CommentsDisabled,
+		"comments_close_after_days": pos.CommentsCloseAfterDays,
+		"comments_login_required":   pos.CommentsLoginRequired,
+	}
+}
+
+// fillPostCommentStats 批量填充文章的评论开放状态与评论数，统计失败时仅记录日志
+// 参数：
+//   - c: Echo 上下文
+//   - posts: 文章列表
+//   - vos: 与文章列表一一对应的视图对象列表
+func fillPostCommentStats(c echo.Context, posts []*model.Post, vos []*post.PostsVO) {
+	postIDs := make([]int64, len(posts))
+	for i, pos := range posts {
+		postIDs[i] = pos.ID
+	}
+
+	counts, err := mapper.CountApprovedCommentsGroupByPost(c, postIDs)
+	if err != nil {
+		utils.BizLogger(c).Errorf("统计文章评论数失败: %v", err)
+	}
+
+	now := time.Now()
+	for i, pos := range posts {
+		vos[i].CommentsOpen = pos.CommentsOpen(now)
+		vos[i].CommentCount = counts[pos.ID]
+	}
 }
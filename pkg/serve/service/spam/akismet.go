@@ -0,0 +1,154 @@
+// Package spam 提供 Akismet 兼容 HTTP 服务的垃圾评论检测适配器
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package spam
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/utils"
+)
+
+// Akismet 接口路径
+const (
+	AKISMET_COMMENT_CHECK = "comment-check" // 检测评论
+	AKISMET_SUBMIT_SPAM   = "submit-spam"   // 反馈漏判的垃圾评论
+	AKISMET_SUBMIT_HAM    = "submit-ham"    // 反馈误判的正常评论
+)
+
+// AkismetChecker Akismet 兼容服务适配器，服务地址可配置为本地替身服务以便调试
+type AkismetChecker struct {
+	endpoint string       // 服务地址，如 https://rest.akismet.com/1.1
+	apiKey   string       // API 密钥
+	blog     string       // 站点地址
+	client   *http.Client // HTTP 客户端
+}
+
+// NewAkismetChecker 创建 Akismet 适配器
+// 参数：
+//   - cfg: 垃圾评论检测配置
+//   - blog: 站点地址
+//
+// 返回值：
+//   - *AkismetChecker: Akismet 适配器
+func NewAkismetChecker(cfg configs.SpamConfig, blog string) *AkismetChecker {
+	return &AkismetChecker{
+		endpoint: strings.TrimRight(cfg.AkismetEndpoint, "/"),
+		apiKey:   cfg.AkismetAPIKey,
+		blog:     blog,
+		client:   &http.Client{Timeout: utils.HTTP_CLIENT_TIMEOUT},
+	}
+}
+
+// Name 返回检测器名称
+// 返回值：
+//   - string: 检测器名称
+func (a *AkismetChecker) Name() string {
+	return CHECKER_AKISMET
+}
+
+// Check 调用 comment-check 接口，响应体为 true 表示垃圾评论
+// 参数：
+//   - c: Echo 上下文
+//   - input: 待检测的评论信息
+//
+// 返回值：
+//   - *Result: 检测结果
+//   - error: 操作过程中的错误
+func (a *AkismetChecker) Check(c echo.Context, input *Input) (*Result, error) {
+	body, header, err := a.call(c, AKISMET_COMMENT_CHECK, input)
+	if err != nil {
+		return nil, err
+	}
+
+	switch body {
+	case "true":
+		reason := "Akismet 判定为垃圾评论"
+		if header.Get("X-akismet-pro-tip") == "discard" {
+			reason = "Akismet 判定为明显的垃圾评论"
+		}
+		return &Result{Spam: true, Score: 1, Checker: CHECKER_AKISMET, Reason: reason}, nil
+	case "false":
+		return &Result{Checker: CHECKER_AKISMET}, nil
+	default:
+		return nil, fmt.Errorf("Akismet 响应无效: %s %s", body, header.Get("X-akismet-debug-help"))
+	}
+}
+
+// Learn 通过 submit-spam 或 submit-ham 接口反馈审核结果
+// 参数：
+//   - c: Echo 上下文
+//   - input: 评论信息
+//   - label: 训练标签
+//   - previous: 此前已学习的标签，Akismet 无需撤销，忽略
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (a *AkismetChecker) Learn(c echo.Context, input *Input, label, previous string) error {
+	method := AKISMET_SUBMIT_HAM
+	if label == LABEL_SPAM {
+		method = AKISMET_SUBMIT_SPAM
+	}
+
+	_, _, err := a.call(c, method, input)
+	return err
+}
+
+// call 以表单方式调用 Akismet 接口
+// 参数：
+//   - c: Echo 上下文
+//   - method: 接口路径
+//   - input: 评论信息
+//
+// 返回值：
+//   - string: 去除首尾空白的响应体
+//   - http.Header: 响应头
+//   - error: 操作过程中的错误
+func (a *AkismetChecker) call(c echo.Context, method string, input *Input) (string, http.Header, error) {
+	if a.endpoint == "" || a.apiKey == "" {
+		return "", nil, fmt.Errorf("未配置 Akismet 服务地址或 API 密钥")
+	}
+
+	form := url.Values{
+		"api_key":              {a.apiKey},
+		"blog":                 {a.blog},
+		"blog_charset":         {"UTF-8"},
+		"user_ip":              {input.AuthorIP},
+		"user_agent":           {input.UserAgent},
+		"referrer":             {input.Referrer},
+		"permalink":            {input.Permalink},
+		"comment_type":         {"comment"},
+		"comment_author":       {input.AuthorName},
+		"comment_author_email": {input.AuthorEmail},
+		"comment_author_url":   {input.AuthorWebsite},
+		"comment_content":      {input.Content},
+	}
+
+	req, err := utils.NewOutgoingRequest(c.Request().Context(), http.MethodPost, a.endpoint+"/"+method, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", nil, fmt.Errorf("创建 Akismet 请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("请求 Akismet 失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := utils.ReadLimitedBody(resp.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("读取 Akismet 响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("Akismet 返回状态码 %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return strings.TrimSpace(string(body)), resp.Header, nil
+}
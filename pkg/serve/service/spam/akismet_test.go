@@ -0,0 +1,196 @@
+// Package spam Akismet 适配器测试，使用本地替身服务模拟 Akismet 接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package spam
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"jank.com/jank_blog/configs"
+)
+
+// akismetStub 本地 Akismet 替身服务，记录收到的请求
+type akismetStub struct {
+	mu       sync.Mutex
+	requests map[string][]url.Values // 按接口路径记录的表单
+	verdict  string                  // comment-check 的响应体
+	proTip   string                  // comment-check 的 X-akismet-pro-tip 响应头
+	status   int                     // 响应状态码，0 表示 200
+}
+
+// newAkismetStub 启动本地 Akismet 替身服务
+// 参数：
+//   - t: 测试对象
+//
+// 返回值：
+//   - *akismetStub: 替身服务状态
+//   - *AkismetChecker: 指向替身服务的适配器
+func newAkismetStub(t *testing.T) (*akismetStub, *AkismetChecker) {
+	t.Helper()
+
+	stub := &akismetStub{requests: make(map[string][]url.Values), verdict: "false"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		method := strings.TrimPrefix(r.URL.Path, "/1.1/")
+
+		stub.mu.Lock()
+		stub.requests[method] = append(stub.requests[method], r.PostForm)
+		verdict, proTip, status := stub.verdict, stub.proTip, stub.status
+		stub.mu.Unlock()
+
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		if r.PostForm.Get("api_key") != "test-key" {
+			w.Header().Set("X-akismet-debug-help", "Empty \"api_key\" value")
+			w.Write([]byte("invalid"))
+			return
+		}
+		switch method {
+		case AKISMET_COMMENT_CHECK:
+			if proTip != "" {
+				w.Header().Set("X-akismet-pro-tip", proTip)
+			}
+			w.Write([]byte(verdict))
+		case AKISMET_SUBMIT_SPAM, AKISMET_SUBMIT_HAM:
+			w.Write([]byte("Thanks for making the web a better place."))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	checker := NewAkismetChecker(configs.SpamConfig{AkismetEndpoint: srv.URL + "/1.1/", AkismetAPIKey: "test-key"}, "https://blog.example.com")
+	return stub, checker
+}
+
+// calls 返回指定接口收到的请求表单
+// 参数：
+//   - method: 接口路径
+//
+// 返回值：
+//   - []url.Values: 请求表单列表
+func (s *akismetStub) calls(method string) []url.Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[method]
+}
+
+func testAkismetInput() *Input {
+	return &Input{
+		Content:       "Buy cheap watches",
+		AuthorName:    "viagra-test-123",
+		AuthorEmail:   "spammer@example.com",
+		AuthorWebsite: "https://spam.example.com",
+		AuthorIP:      "203.0.113.7",
+		UserAgent:     "Mozilla/5.0",
+		Referrer:      "https://blog.example.com/posts/1",
+		Permalink:     "https://blog.example.com/posts/1",
+	}
+}
+
+func TestAkismetCheckHam(t *testing.T) {
+	stub, checker := newAkismetStub(t)
+
+	res, err := checker.Check(newTestContext(), testAkismetInput())
+	if err != nil {
+		t.Fatalf("检测失败: %v", err)
+	}
+	if res.Spam || res.Checker != CHECKER_AKISMET {
+		t.Fatalf("期望判定为正常评论，实际为 %+v", res)
+	}
+
+	calls := stub.calls(AKISMET_COMMENT_CHECK)
+	if len(calls) != 1 {
+		t.Fatalf("期望调用 comment-check 1 次，实际 %d 次", len(calls))
+	}
+	form := calls[0]
+	expected := map[string]string{
+		"blog":                 "https://blog.example.com",
+		"user_ip":              "203.0.113.7",
+		"user_agent":           "Mozilla/5.0",
+		"comment_type":         "comment",
+		"comment_author":       "viagra-test-123",
+		"comment_author_email": "spammer@example.com",
+		"comment_author_url":   "https://spam.example.com",
+		"comment_content":      "Buy cheap watches",
+		"permalink":            "https://blog.example.com/posts/1",
+	}
+	for key, value := range expected {
+		if got := form.Get(key); got != value {
+			t.Errorf("表单字段 %s 期望 %q，实际 %q", key, value, got)
+		}
+	}
+}
+
+func TestAkismetCheckSpam(t *testing.T) {
+	stub, checker := newAkismetStub(t)
+	stub.verdict = "true"
+
+	res, err := checker.Check(newTestContext(), testAkismetInput())
+	if err != nil {
+		t.Fatalf("检测失败: %v", err)
+	}
+	if !res.Spam || res.Score != 1 {
+		t.Fatalf("期望判定为垃圾评论，实际为 %+v", res)
+	}
+
+	stub.proTip = "discard"
+	res, err = checker.Check(newTestContext(), testAkismetInput())
+	if err != nil {
+		t.Fatalf("检测失败: %v", err)
+	}
+	if !res.Spam || !strings.Contains(res.Reason, "明显") {
+		t.Fatalf("期望 discard 提示标记为明显的垃圾评论，实际为 %+v", res)
+	}
+}
+
+func TestAkismetCheckErrors(t *testing.T) {
+	stub, checker := newAkismetStub(t)
+
+	checker.apiKey = "wrong-key"
+	if _, err := checker.Check(newTestContext(), testAkismetInput()); err == nil {
+		t.Fatal("期望无效响应返回错误")
+	}
+
+	checker.apiKey = "test-key"
+	stub.status = http.StatusInternalServerError
+	if _, err := checker.Check(newTestContext(), testAkismetInput()); err == nil {
+		t.Fatal("期望非 200 状态码返回错误")
+	}
+
+	unconfigured := NewAkismetChecker(configs.SpamConfig{}, "https://blog.example.com")
+	if _, err := unconfigured.Check(newTestContext(), testAkismetInput()); err == nil {
+		t.Fatal("期望未配置服务地址时返回错误")
+	}
+}
+
+func TestAkismetLearn(t *testing.T) {
+	stub, checker := newAkismetStub(t)
+
+	if err := checker.Learn(newTestContext(), testAkismetInput(), LABEL_SPAM, ""); err != nil {
+		t.Fatalf("反馈垃圾评论失败: %v", err)
+	}
+	if err := checker.Learn(newTestContext(), testAkismetInput(), LABEL_HAM, LABEL_SPAM); err != nil {
+		t.Fatalf("反馈正常评论失败: %v", err)
+	}
+
+	if got := len(stub.calls(AKISMET_SUBMIT_SPAM)); got != 1 {
+		t.Errorf("期望调用 submit-spam 1 次，实际 %d 次", got)
+	}
+	if got := len(stub.calls(AKISMET_SUBMIT_HAM)); got != 1 {
+		t.Errorf("期望调用 submit-ham 1 次，实际 %d 次", got)
+	}
+	if got := len(stub.calls(AKISMET_COMMENT_CHECK)); got != 0 {
+		t.Errorf("学习时不应调用 comment-check，实际 %d 次", got)
+	}
+}
@@ -0,0 +1,178 @@
+// Package spam 提供基于博主审核结果训练的本地朴素贝叶斯垃圾评论分类器
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package spam
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	model "jank.com/jank_blog/internal/model/spam"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+const (
+	BAYES_MAX_TOKENS       = 200 // 单条评论参与分类的最大词元数
+	BAYES_MAX_TOKEN_LENGTH = 32  // 单个词元的最大字符数，超出的词元被忽略
+)
+
+// BayesChecker 伯努利朴素贝叶斯分类器，词元计数为包含该词元的样本数
+type BayesChecker struct {
+	threshold     float64 // 判定为垃圾评论的概率阈值
+	minTrainCount int64   // 垃圾与正常样本各自的最小训练数
+}
+
+// NewBayesChecker 创建朴素贝叶斯分类器
+// 参数：
+//   - cfg: 垃圾评论检测配置
+//
+// 返回值：
+//   - *BayesChecker: 朴素贝叶斯分类器
+func NewBayesChecker(cfg configs.SpamConfig) *BayesChecker {
+	return &BayesChecker{
+		threshold:     cfg.BayesThreshold,
+		minTrainCount: cfg.BayesMinTrainCount,
+	}
+}
+
+// Name 返回检测器名称
+// 返回值：
+//   - string: 检测器名称
+func (b *BayesChecker) Name() string {
+	return CHECKER_BAYES
+}
+
+// Check 计算评论为垃圾评论的后验概率，训练样本不足时不做判定
+// 参数：
+//   - c: Echo 上下文
+//   - input: 待检测的评论信息
+//
+// 返回值：
+//   - *Result: 检测结果
+//   - error: 操作过程中的错误
+func (b *BayesChecker) Check(c echo.Context, input *Input) (*Result, error) {
+	tokens := tokenize(input)
+	stats, err := mapper.GetSpamTokensByTokens(c, append(tokens, model.SPAM_TOKEN_DOCUMENTS))
+	if err != nil {
+		return nil, err
+	}
+
+	var spamDocs, hamDocs float64
+	if documents, ok := stats[model.SPAM_TOKEN_DOCUMENTS]; ok {
+		spamDocs = float64(max(documents.SpamCount, 0))
+		hamDocs = float64(max(documents.HamCount, 0))
+	}
+	if spamDocs < float64(b.minTrainCount) || hamDocs < float64(b.minTrainCount) || spamDocs == 0 || hamDocs == 0 {
+		return &Result{Checker: CHECKER_BAYES, Score: 0.5, Reason: "训练样本不足"}, nil
+	}
+
+	// 拉普拉斯平滑，仅累计评论中出现的词元
+	logSpam := math.Log(spamDocs / (spamDocs + hamDocs))
+	logHam := math.Log(hamDocs / (spamDocs + hamDocs))
+	for _, token := range tokens {
+		var spamCount, hamCount float64
+		if stat, ok := stats[token]; ok {
+			spamCount = float64(max(stat.SpamCount, 0))
+			hamCount = float64(max(stat.HamCount, 0))
+		}
+		logSpam += math.Log((spamCount + 1) / (spamDocs + 2))
+		logHam += math.Log((hamCount + 1) / (hamDocs + 2))
+	}
+
+	score := 1 / (1 + math.Exp(logHam-logSpam))
+	result := &Result{Score: score, Checker: CHECKER_BAYES}
+	if score >= b.threshold {
+		result.Spam = true
+		result.Reason = fmt.Sprintf("垃圾评论概率 %.4f 超过阈值 %.2f", score, b.threshold)
+	}
+	return result, nil
+}
+
+// Learn 以 label 标签累加词元计数，previous 不为空时先撤销此前的学习结果
+// 参数：
+//   - c: Echo 上下文
+//   - input: 评论信息
+//   - label: 训练标签
+//   - previous: 此前已学习的标签
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (b *BayesChecker) Learn(c echo.Context, input *Input, label, previous string) error {
+	var spamDelta, hamDelta int64
+	switch label {
+	case LABEL_SPAM:
+		spamDelta++
+	case LABEL_HAM:
+		hamDelta++
+	}
+	switch previous {
+	case LABEL_SPAM:
+		spamDelta--
+	case LABEL_HAM:
+		hamDelta--
+	}
+
+	tokens := append(tokenize(input), model.SPAM_TOKEN_DOCUMENTS)
+	return mapper.IncrSpamTokens(c, tokens, spamDelta, hamDelta)
+}
+
+// tokenize 将评论切分为去重后的词元：字母数字按单词切分，汉字按相邻二字切分
+// 参数：
+//   - input: 评论信息
+//
+// 返回值：
+//   - []string: 词元列表
+func tokenize(input *Input) []string {
+	text := strings.ToLower(strings.Join([]string{input.AuthorName, input.AuthorWebsite, input.Content}, " "))
+
+	seen := make(map[string]bool)
+	tokens := make([]string, 0)
+	add := func(token string) {
+		if len(tokens) >= BAYES_MAX_TOKENS || seen[token] {
+			return
+		}
+		seen[token] = true
+		tokens = append(tokens, token)
+	}
+
+	var word, han []rune
+	flush := func() {
+		if len(word) >= 2 && len(word) <= BAYES_MAX_TOKEN_LENGTH {
+			add(string(word))
+		}
+		switch {
+		case len(han) == 1:
+			add(string(han))
+		case len(han) > 1:
+			for i := 0; i+1 < len(han); i++ {
+				add(string(han[i : i+2]))
+			}
+		}
+		word, han = word[:0], han[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			if len(word) > 0 {
+				flush()
+			}
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if len(han) > 0 {
+				flush()
+			}
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return tokens
+}
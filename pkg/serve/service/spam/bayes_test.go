@@ -0,0 +1,105 @@
+// Package spam 朴素贝叶斯分类器测试
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package spam
+
+import (
+	"reflect"
+	"testing"
+
+	"jank.com/jank_blog/configs"
+	model "jank.com/jank_blog/internal/model/spam"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens := tokenize(&Input{Content: "Hello, hello WORLD! 垃圾评论 a"})
+	expected := []string{"hello", "world", "垃圾", "圾评", "评论"}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Fatalf("期望词元 %v，实际 %v", expected, tokens)
+	}
+}
+
+func TestBayesTrainAndClassify(t *testing.T) {
+	setupTestDB(t)
+	c := newTestContext()
+	checker := NewBayesChecker(configs.SpamConfig{BayesThreshold: 0.9, BayesMinTrainCount: 2})
+
+	spamSamples := []string{
+		"cheap pills casino bonus click here",
+		"casino jackpot free bonus pills",
+		"buy cheap pills online casino",
+	}
+	hamSamples := []string{
+		"great article about golang generics",
+		"thanks for the detailed golang tutorial",
+		"the article on database indexes was helpful",
+	}
+
+	// 样本不足时不做判定
+	if err := checker.Learn(c, &Input{Content: spamSamples[0]}, LABEL_SPAM, ""); err != nil {
+		t.Fatalf("学习失败: %v", err)
+	}
+	res, err := checker.Check(c, &Input{Content: "casino pills"})
+	if err != nil {
+		t.Fatalf("分类失败: %v", err)
+	}
+	if res.Spam || res.Score != 0.5 {
+		t.Fatalf("期望样本不足时不做判定，实际为 %+v", res)
+	}
+
+	for _, content := range spamSamples[1:] {
+		if err := checker.Learn(c, &Input{Content: content}, LABEL_SPAM, ""); err != nil {
+			t.Fatalf("学习失败: %v", err)
+		}
+	}
+	for _, content := range hamSamples {
+		if err := checker.Learn(c, &Input{Content: content}, LABEL_HAM, ""); err != nil {
+			t.Fatalf("学习失败: %v", err)
+		}
+	}
+
+	res, err = checker.Check(c, &Input{Content: "free casino pills bonus"})
+	if err != nil {
+		t.Fatalf("分类失败: %v", err)
+	}
+	if !res.Spam || res.Checker != CHECKER_BAYES {
+		t.Fatalf("期望判定为垃圾评论，实际为 %+v", res)
+	}
+
+	res, err = checker.Check(c, &Input{Content: "helpful golang article"})
+	if err != nil {
+		t.Fatalf("分类失败: %v", err)
+	}
+	if res.Spam || res.Score >= 0.5 {
+		t.Fatalf("期望判定为正常评论，实际为 %+v", res)
+	}
+}
+
+func TestBayesRelearnReplacesPreviousLabel(t *testing.T) {
+	setupTestDB(t)
+	c := newTestContext()
+	checker := NewBayesChecker(configs.SpamConfig{BayesThreshold: 0.9})
+	input := &Input{Content: "borderline message"}
+
+	if err := checker.Learn(c, input, LABEL_SPAM, ""); err != nil {
+		t.Fatalf("学习失败: %v", err)
+	}
+	if err := checker.Learn(c, input, LABEL_HAM, LABEL_SPAM); err != nil {
+		t.Fatalf("重新学习失败: %v", err)
+	}
+
+	stats, err := mapper.GetSpamTokensByTokens(c, []string{"borderline", model.SPAM_TOKEN_DOCUMENTS})
+	if err != nil {
+		t.Fatalf("获取词元统计失败: %v", err)
+	}
+	for _, token := range []string{"borderline", model.SPAM_TOKEN_DOCUMENTS} {
+		stat, ok := stats[token]
+		if !ok {
+			t.Fatalf("词元「%s」未记录", token)
+		}
+		if stat.SpamCount != 0 || stat.HamCount != 1 {
+			t.Errorf("词元「%s」期望 spam=0 ham=1，实际 spam=%d ham=%d", token, stat.SpamCount, stat.HamCount)
+		}
+	}
+}
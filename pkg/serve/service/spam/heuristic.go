@@ -0,0 +1,102 @@
+// Package spam 提供启发式垃圾评论检测：链接数、屏蔽关键词与发帖频率
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package spam
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// linkPattern 匹配评论内容中的链接
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+
+// HeuristicChecker 基于规则的垃圾评论检测器
+type HeuristicChecker struct {
+	maxLinks     int           // 允许的最大链接数，0 表示不限制
+	blockedWords []string      // 小写的屏蔽关键词
+	rateLimit    int           // 统计窗口内允许的评论数，0 表示不限制
+	rateWindow   time.Duration // 发帖频率统计窗口
+}
+
+// NewHeuristicChecker 创建启发式检测器
+// 参数：
+//   - cfg: 垃圾评论检测配置
+//
+// 返回值：
+//   - *HeuristicChecker: 启发式检测器
+func NewHeuristicChecker(cfg configs.SpamConfig) *HeuristicChecker {
+	blockedWords := make([]string, 0, len(cfg.SpamBlockedWords))
+	for _, word := range cfg.SpamBlockedWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			blockedWords = append(blockedWords, word)
+		}
+	}
+
+	return &HeuristicChecker{
+		maxLinks:     cfg.SpamMaxLinks,
+		blockedWords: blockedWords,
+		rateLimit:    cfg.SpamRateLimit,
+		rateWindow:   time.Duration(cfg.SpamRateWindow) * time.Second,
+	}
+}
+
+// Name 返回检测器名称
+// 返回值：
+//   - string: 检测器名称
+func (h *HeuristicChecker) Name() string {
+	return CHECKER_HEURISTIC
+}
+
+// Check 依次检查链接数、屏蔽关键词与同一 IP 的发帖频率
+// 参数：
+//   - c: Echo 上下文
+//   - input: 待检测的评论信息
+//
+// 返回值：
+//   - *Result: 检测结果
+//   - error: 操作过程中的错误
+func (h *HeuristicChecker) Check(c echo.Context, input *Input) (*Result, error) {
+	if h.maxLinks > 0 {
+		if links := len(linkPattern.FindAllString(input.Content, -1)); links > h.maxLinks {
+			return h.spam(fmt.Sprintf("包含 %d 个链接，超过上限 %d", links, h.maxLinks)), nil
+		}
+	}
+
+	text := strings.ToLower(strings.Join([]string{input.Content, input.AuthorName, input.AuthorEmail, input.AuthorWebsite}, "\n"))
+	for _, word := range h.blockedWords {
+		if strings.Contains(text, word) {
+			return h.spam(fmt.Sprintf("包含屏蔽关键词「%s」", word)), nil
+		}
+	}
+
+	if h.rateLimit > 0 && h.rateWindow > 0 && input.AuthorIP != "" {
+		since := time.Now().Add(-h.rateWindow).Unix()
+		count, err := mapper.CountCommentsByIPSince(c, input.AuthorIP, since)
+		if err != nil {
+			return nil, err
+		}
+		if count >= int64(h.rateLimit) {
+			return h.spam(fmt.Sprintf("IP 在 %s 内已发表 %d 条评论", h.rateWindow, count)), nil
+		}
+	}
+
+	return &Result{Checker: CHECKER_HEURISTIC}, nil
+}
+
+// spam 构造判定为垃圾评论的结果
+// 参数：
+//   - reason: 判定原因
+//
+// 返回值：
+//   - *Result: 检测结果
+func (h *HeuristicChecker) spam(reason string) *Result {
+	return &Result{Spam: true, Score: 1, Checker: CHECKER_HEURISTIC, Reason: reason}
+}
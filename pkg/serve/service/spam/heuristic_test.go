@@ -0,0 +1,77 @@
+// Package spam 启发式检测器测试
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package spam
+
+import (
+	"strings"
+	"testing"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	commentModel "jank.com/jank_blog/internal/model/comment"
+)
+
+func TestHeuristicLinksAndBlockedWords(t *testing.T) {
+	checker := NewHeuristicChecker(configs.SpamConfig{
+		SpamMaxLinks:     2,
+		SpamBlockedWords: []string{" Casino ", ""},
+	})
+	c := newTestContext()
+
+	cases := []struct {
+		name  string
+		input *Input
+		spam  bool
+	}{
+		{"普通评论", &Input{Content: "nice post, see https://a.example.com"}, false},
+		{"链接过多", &Input{Content: "https://a.example.com http://b.example.com www.c.example.com"}, true},
+		{"内容含屏蔽词", &Input{Content: "Visit our CASINO today"}, true},
+		{"网站含屏蔽词", &Input{Content: "hello", AuthorWebsite: "https://casino.example.com"}, true},
+	}
+	for _, tc := range cases {
+		res, err := checker.Check(c, tc.input)
+		if err != nil {
+			t.Fatalf("%s: 检测失败: %v", tc.name, err)
+		}
+		if res.Spam != tc.spam {
+			t.Errorf("%s: 期望 Spam=%v，实际为 %+v", tc.name, tc.spam, res)
+		}
+	}
+}
+
+func TestHeuristicRateLimit(t *testing.T) {
+	setupTestDB(t)
+	checker := NewHeuristicChecker(configs.SpamConfig{SpamRateLimit: 2, SpamRateWindow: 60})
+	c := newTestContext()
+	input := &Input{Content: "hello", AuthorIP: "198.51.100.9"}
+
+	for i := 0; i < 2; i++ {
+		res, err := checker.Check(c, input)
+		if err != nil {
+			t.Fatalf("检测失败: %v", err)
+		}
+		if res.Spam {
+			t.Fatalf("第 %d 条评论不应判定为垃圾评论: %+v", i+1, res)
+		}
+		if err := global.DB.Create(&commentModel.Comment{Content: "hello", AuthorIP: input.AuthorIP}).Error; err != nil {
+			t.Fatalf("创建评论失败: %v", err)
+		}
+	}
+
+	res, err := checker.Check(c, input)
+	if err != nil {
+		t.Fatalf("检测失败: %v", err)
+	}
+	if !res.Spam || !strings.Contains(res.Reason, "IP") {
+		t.Fatalf("期望超过频率限制时判定为垃圾评论，实际为 %+v", res)
+	}
+
+	res, err = checker.Check(c, &Input{Content: "hello", AuthorIP: "198.51.100.10"})
+	if err != nil {
+		t.Fatalf("检测失败: %v", err)
+	}
+	if res.Spam {
+		t.Fatalf("其他 IP 不应受影响: %+v", res)
+	}
+}
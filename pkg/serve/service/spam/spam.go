@@ -0,0 +1,156 @@
+// Package spam 提供可插拔的垃圾评论检测，新评论依次经过配置启用的检测器
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package spam
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/utils"
+)
+
+// 检测器名称，对应配置项 SPAM_CHECKERS
+const (
+	CHECKER_HEURISTIC = "heuristic" // 链接数、屏蔽关键词与发帖频率启发式规则
+	CHECKER_BAYES     = "bayes"     // 本地朴素贝叶斯分类器
+	CHECKER_AKISMET   = "akismet"   // Akismet 兼容的 HTTP 服务
+)
+
+// 训练标签
+const (
+	LABEL_SPAM = "spam" // 垃圾评论
+	LABEL_HAM  = "ham"  // 正常评论
+)
+
+// Input 待检测的评论信息
+type Input struct {
+	Content       string // 评论内容
+	AuthorName    string // 评论者昵称
+	AuthorEmail   string // 评论者邮箱
+	AuthorWebsite string // 评论者个人网站
+	AuthorIP      string // 评论者 IP
+	UserAgent     string // 评论者 User-Agent
+	Referrer      string // 评论请求来源页
+	Permalink     string // 所属文章地址
+}
+
+// Result 检测结果
+type Result struct {
+	Spam    bool    // 是否判定为垃圾评论
+	Score   float64 // 垃圾评论概率，取值 0~1
+	Checker string  // 给出结果的检测器名称
+	Reason  string  // 判定原因
+}
+
+// Checker 垃圾评论检测器
+type Checker interface {
+	// Name 返回检测器名称
+	Name() string
+	// Check 检测评论是否为垃圾评论
+	Check(c echo.Context, input *Input) (*Result, error)
+}
+
+// Learner 可根据博主审核结果学习的检测器
+type Learner interface {
+	// Learn 以 label 标签学习评论，previous 为该评论此前已学习的标签，为空表示未学习过
+	Learn(c echo.Context, input *Input, label, previous string) error
+}
+
+// Check 依次执行配置启用的检测器，任一检测器判定为垃圾评论即返回
+// 单个检测器出错时记录日志并跳过，不阻塞评论发表
+// 参数：
+//   - c: Echo 上下文
+//   - input: 待检测的评论信息
+//
+// 返回值：
+//   - *Result: 检测结果，未判定为垃圾评论时 Spam 为 false
+//   - error: 加载配置失败时返回错误
+func Check(c echo.Context, input *Input) (*Result, error) {
+	checkers, err := enabledCheckers()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	for _, checker := range checkers {
+		res, err := checker.Check(c, input)
+		if err != nil {
+			utils.BizLogger(c).Warnf("垃圾评论检测器「%s」执行失败: %v", checker.Name(), err)
+			continue
+		}
+		if res.Spam {
+			return res, nil
+		}
+		if res.Score > result.Score {
+			result = res
+		}
+	}
+
+	return result, nil
+}
+
+// Learn 将博主的审核结果反馈给支持学习的检测器
+// 参数：
+//   - c: Echo 上下文
+//   - input: 评论信息
+//   - label: 训练标签
+//   - previous: 此前已学习的标签，为空表示未学习过
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func Learn(c echo.Context, input *Input, label, previous string) error {
+	if label != LABEL_SPAM && label != LABEL_HAM {
+		return fmt.Errorf("不支持的训练标签「%s」", label)
+	}
+	if label == previous {
+		return nil
+	}
+
+	checkers, err := enabledCheckers()
+	if err != nil {
+		return err
+	}
+
+	for _, checker := range checkers {
+		learner, ok := checker.(Learner)
+		if !ok {
+			continue
+		}
+		if err := learner.Learn(c, input, label, previous); err != nil {
+			return fmt.Errorf("垃圾评论检测器「%s」学习失败: %w", checker.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// enabledCheckers 按配置顺序构建启用的检测器
+// 返回值：
+//   - []Checker: 检测器列表
+//   - error: 操作过程中的错误
+func enabledCheckers() ([]Checker, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("加载垃圾评论检测配置失败: %w", err)
+	}
+
+	spamCfg := cfg.AppConfig.Spam
+	checkers := make([]Checker, 0, len(spamCfg.SpamCheckers))
+	for _, name := range spamCfg.SpamCheckers {
+		switch name {
+		case CHECKER_HEURISTIC:
+			checkers = append(checkers, NewHeuristicChecker(spamCfg))
+		case CHECKER_BAYES:
+			checkers = append(checkers, NewBayesChecker(spamCfg))
+		case CHECKER_AKISMET:
+			checkers = append(checkers, NewAkismetChecker(spamCfg, cfg.AppConfig.Site.SiteURL))
+		default:
+			return nil, fmt.Errorf("不支持的垃圾评论检测器「%s」", name)
+		}
+	}
+
+	return checkers, nil
+}
@@ -0,0 +1,61 @@
+// Package spam 垃圾评论检测测试的公共辅助函数
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package spam
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"jank.com/jank_blog/internal/global"
+	commentModel "jank.com/jank_blog/internal/model/comment"
+	model "jank.com/jank_blog/internal/model/spam"
+)
+
+// setupTestDB 使用内存 SQLite 替换全局数据库连接
+// 参数：
+//   - t: 测试对象
+func setupTestDB(t *testing.T) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("打开 SQLite 失败: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("获取 SQLite 连接失败: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&model.SpamToken{}, &commentModel.Comment{}); err != nil {
+		t.Fatalf("迁移测试表失败: %v", err)
+	}
+
+	previous := global.DB
+	global.DB = db
+	t.Cleanup(func() {
+		global.DB = previous
+		sqlDB.Close()
+	})
+
+	if global.SysLog == nil {
+		log := logrus.New()
+		log.SetLevel(logrus.PanicLevel)
+		global.SysLog = log
+	}
+}
+
+// newTestContext 创建测试用的 Echo 上下文
+// 返回值：
+//   - echo.Context: Echo 上下文
+func newTestContext() echo.Context {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
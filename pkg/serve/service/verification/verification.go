@@ -5,8 +5,11 @@ package verification
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -15,6 +18,7 @@ import (
 	"jank.com/jank_blog/internal/global"
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/verification/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
 	"jank.com/jank_blog/pkg/vo/verification"
 )
 
@@ -23,6 +27,16 @@ const (
 	IMG_VERIFICATION_CODE_CACHE_EXPIRATION   = 3 * time.Minute // 图形验证码缓存过期时间
 )
 
+const (
+	PASSWORD_RESET_CODE_CACHE_EXPIRATION = 15 * time.Minute // 找回密码验证码缓存过期时间
+	EMAIL_CHANGE_CODE_CACHE_EXPIRATION   = 15 * time.Minute // 修改邮箱验证码缓存过期时间
+)
+
+const (
+	PASSWORD_RESET_EMAIL_SUBJECT = "【Jank Blog】找回密码验证码" // 找回密码验证码邮件主题
+	EMAIL_CHANGE_EMAIL_SUBJECT   = "【Jank Blog】修改邮箱验证码" // 修改邮箱验证码邮件主题
+)
+
 // GenerateImgVerificationCode 生成图形验证码
 // 参数：
 //   - c: Echo 上下文
@@ -90,3 +104,151 @@ func SendEmailVerificationCode(c echo.Context, req *dto.GetOneVerificationCode)
 
 	return nil
 }
+
+// SendPasswordResetCode 向账户邮箱发送一次性的找回密码验证码，邮箱未注册或验证码未过期时不发送；
+// 邮件异步发送，使各种情况下的返回内容与耗时一致，避免泄露邮箱是否注册
+// 参数：
+//   - c: Echo 上下文
+//   - req: 发送找回密码验证码请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func SendPasswordResetCode(c echo.Context, req *dto.SendPasswordResetCodeRequest) error {
+	if _, err := mapper.GetAccountByEmail(c, req.Email); err != nil {
+		utils.BizLogger(c).Warnf("「%s」用户不存在，不发送找回密码验证码", req.Email)
+		return nil
+	}
+
+	key := utils.PASSWORD_RESET_CODE_CACHE_KEY_PREFIX + req.Email
+
+	// 仅当不存在未过期的验证码时才写入，避免重复发送覆盖旧验证码
+	code, err := generateOneTimeCode()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成找回密码验证码失败: %v", err)
+		return err
+	}
+	created, err := global.RedisClient.SetNX(context.Background(), key, code, PASSWORD_RESET_CODE_CACHE_EXPIRATION).Result()
+	if err != nil {
+		utils.BizLogger(c).Errorf("找回密码验证码写入缓存失败: %v", err)
+		return err
+	}
+	if !created {
+		utils.BizLogger(c).Warnf("「%s」找回密码验证码未过期，不重复发送", req.Email)
+		return nil
+	}
+
+	sendPasswordResetEmail(req.Email, key, code)
+	return nil
+}
+
+// sendPasswordResetEmail 异步发送找回密码验证码邮件，发送失败时作废验证码以便重新获取
+// 参数：
+//   - email: 账户邮箱
+//   - key: 验证码缓存键
+//   - code: 验证码
+func sendPasswordResetEmail(email, key, code string) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				global.SysLog.Errorf("发送「%s」找回密码验证码时发生 panic: %v", email, r)
+			}
+		}()
+
+		expirationInMinutes := int(PASSWORD_RESET_CODE_CACHE_EXPIRATION.Round(time.Minute).Minutes())
+		msg := &utils.EmailMessage{
+			Subject:  PASSWORD_RESET_EMAIL_SUBJECT,
+			TextBody: fmt.Sprintf("您正在找回密码，验证码是: %s , 有效期为 %d 分钟，仅可使用一次。如非本人操作，请忽略此邮件。", code, expirationInMinutes),
+		}
+		if _, err := utils.SendEmailMessage(msg, []string{email}); err != nil {
+			global.SysLog.Errorf("找回密码验证码发送失败，邮箱地址: %s, 错误: %v", email, err)
+			global.RedisClient.Del(context.Background(), key)
+		}
+	}()
+}
+
+// SendEmailChangeCode 修改账户邮箱前，分别向当前邮箱与新邮箱发送一次性验证码，两个验证码均与当前账户绑定
+// 参数：
+//   - c: Echo 上下文
+//   - req: 发送修改邮箱验证码请求
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func SendEmailChangeCode(c echo.Context, req *dto.SendEmailChangeCodeRequest) error {
+	accountID, _ := utils.GetAuthAccountID(c)
+	acc, err := mapper.GetAccountByAccountID(c, accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%d」用户不存在: %v", accountID, err)
+		return fmt.Errorf("「%d」用户不存在: %w", accountID, err)
+	}
+	if strings.EqualFold(acc.Email, req.NewEmail) {
+		return bizErr.New(bizErr.BAD_REQUEST, "新邮箱不能与当前邮箱相同")
+	}
+	if existing, _ := mapper.GetAccountByEmail(c, req.NewEmail); existing != nil {
+		utils.BizLogger(c).Warnf("「%d」用户修改邮箱时，「%s」邮箱已被注册", accountID, req.NewEmail)
+		return bizErr.New(bizErr.BAD_REQUEST, "该邮箱已被注册")
+	}
+
+	oldCode, err := generateOneTimeCode()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成修改邮箱验证码失败: %v", err)
+		return err
+	}
+	newCode, err := generateOneTimeCode()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成修改邮箱验证码失败: %v", err)
+		return err
+	}
+
+	// 以当前邮箱的验证码控制发送频率，未过期时不重复发送
+	ctx := context.Background()
+	oldKey := utils.EMAIL_CHANGE_CODE_CACHE_KEY_PREFIX + utils.EmailChangeCodeIdentity(acc.ID, acc.Email)
+	newKey := utils.EMAIL_CHANGE_CODE_CACHE_KEY_PREFIX + utils.EmailChangeCodeIdentity(acc.ID, req.NewEmail)
+	created, err := global.RedisClient.SetNX(ctx, oldKey, oldCode, EMAIL_CHANGE_CODE_CACHE_EXPIRATION).Result()
+	if err != nil {
+		utils.BizLogger(c).Errorf("修改邮箱验证码写入缓存失败: %v", err)
+		return err
+	}
+	if !created {
+		utils.BizLogger(c).Warnf("「%s」修改邮箱验证码未过期，不重复发送", acc.Email)
+		return bizErr.New(bizErr.TOO_MANY_REQUESTS, "修改邮箱验证码已发送，请查收邮件或稍后再试")
+	}
+	if err := global.RedisClient.Set(ctx, newKey, newCode, EMAIL_CHANGE_CODE_CACHE_EXPIRATION).Err(); err != nil {
+		utils.BizLogger(c).Errorf("修改邮箱验证码写入缓存失败: %v", err)
+		global.RedisClient.Del(ctx, oldKey)
+		return err
+	}
+
+	expirationInMinutes := int(EMAIL_CHANGE_CODE_CACHE_EXPIRATION.Round(time.Minute).Minutes())
+	messages := []struct {
+		email   string
+		content string
+	}{
+		{acc.Email, fmt.Sprintf("您正在将账户邮箱修改为 %s，当前邮箱的验证码是: %s , 有效期为 %d 分钟，仅可使用一次。如非本人操作，请立即修改密码。", req.NewEmail, oldCode, expirationInMinutes)},
+		{req.NewEmail, fmt.Sprintf("您正在将此邮箱设置为账户登录邮箱，新邮箱的验证码是: %s , 有效期为 %d 分钟，仅可使用一次。如非本人操作，请忽略此邮件。", newCode, expirationInMinutes)},
+	}
+	for _, msg := range messages {
+		success, err := utils.SendEmailMessage(&utils.EmailMessage{
+			Subject:  EMAIL_CHANGE_EMAIL_SUBJECT,
+			TextBody: msg.content,
+		}, []string{msg.email})
+		if !success {
+			utils.BizLogger(c).Errorf("修改邮箱验证码发送失败，邮箱地址: %s, 错误: %v", msg.email, err)
+			global.RedisClient.Del(ctx, oldKey, newKey)
+			return bizErr.New(bizErr.SEND_EMAIL_VERIFICATION_CODE_FAIL, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// generateOneTimeCode 使用密码学安全的随机数生成 6 位一次性验证码
+// 返回值：
+//   - string: 验证码
+//   - error: 操作过程中的错误
+func generateOneTimeCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(900000))
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(n.Int64()+100000, 10), nil
+}
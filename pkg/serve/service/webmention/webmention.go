@@ -0,0 +1,370 @@
+// Package service 提供业务逻辑处理，处理 Webmention 相关业务
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/webmention"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/webmention/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/webmention"
+)
+
+const (
+	WEBMENTION_QUEUE_SIZE     = 256              // 待校验队列容量
+	WEBMENTION_VERIFY_TIMEOUT = 30 * time.Second // 单条 Webmention 校验超时时间
+	WEBMENTION_SEND_TIMEOUT   = 30 * time.Second // 单个链接发送超时时间
+	WEBMENTION_RECEIVE_SCOPE  = "webmention"     // 接收 Webmention 限流场景
+	WEBMENTION_RECEIVE_LIMIT  = 10               // 单个 IP 在统计窗口内允许提交的次数
+	WEBMENTION_RECEIVE_WINDOW = 10 * time.Minute // 接收 Webmention 次数统计窗口
+	WEBMENTION_MAX_ATTEMPTS   = 5                // 来源页面暂时无法访问时的最大校验次数
+	WEBMENTION_RETRY_DELAY    = time.Minute      // 首次重试的等待时间，之后每次翻倍
+)
+
+var (
+	verifyQueue      = make(chan int64, WEBMENTION_QUEUE_SIZE) // 待校验 Webmention ID 队列
+	verifyWorkerOnce sync.Once                                 // 确保校验协程只启动一次
+)
+
+// ReceiveWebmention 接收 Webmention，按 IP 限流并记录后异步校验来源页面
+// 参数：
+//   - c: Echo 上下文
+//   - req: 接收 Webmention 请求
+//
+// 返回值：
+//   - *webmention.WebmentionsVO: 待校验的 Webmention 视图对象
+//   - error: 操作过程中的错误
+func ReceiveWebmention(c echo.Context, req *dto.ReceiveWebmentionRequest) (*webmention.WebmentionsVO, error) {
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载 Webmention 配置失败: %v", err)
+		return nil, fmt.Errorf("加载 Webmention 配置失败: %w", err)
+	}
+	if !cfg.AppConfig.Webmention.WebmentionEnabled {
+		return nil, bizErr.New(bizErr.BAD_REQUEST, "Webmention 未启用")
+	}
+
+	// 每条 Webmention 都会触发一次对外抓取，限制单个 IP 的提交频率，避免被用作抓取代理
+	allowed, err := utils.AllowRequest(c, WEBMENTION_RECEIVE_SCOPE, c.RealIP(), WEBMENTION_RECEIVE_LIMIT, WEBMENTION_RECEIVE_WINDOW)
+	if err != nil {
+		utils.BizLogger(c).Errorf("Webmention 限流失败: %v", err)
+		return nil, fmt.Errorf("Webmention 限流失败: %w", err)
+	}
+	if !allowed {
+		utils.BizLogger(c).Warnf("IP「%s」提交 Webmention 过于频繁", c.RealIP())
+		return nil, bizErr.New(bizErr.TOO_MANY_REQUESTS)
+	}
+
+	if !isHTTPURL(req.Source) || !isHTTPURL(req.Target) {
+		return nil, bizErr.New(bizErr.BAD_REQUEST, "source 与 target 必须为 http(s) 地址")
+	}
+	if req.Source == req.Target {
+		return nil, bizErr.New(bizErr.BAD_REQUEST, "source 与 target 不能相同")
+	}
+
+	postID, err := utils.ParsePostIDFromURL(req.Target)
+	if err != nil {
+		utils.BizLogger(c).Errorf("Webmention 目标地址无效: %v", err)
+		return nil, bizErr.New(bizErr.BAD_REQUEST, "target 不是本站文章地址")
+	}
+
+	pos, err := mapper.GetPostByID(c, postID)
+	if err != nil || !pos.Visibility {
+		utils.BizLogger(c).Errorf("Webmention 目标文章「%d」不存在或不可见: %v", postID, err)
+		return nil, bizErr.New(bizErr.BAD_REQUEST, "target 对应的文章不存在")
+	}
+
+	var mentionVO *webmention.WebmentionsVO
+	var mentionID int64
+	err = utils.RunDBTransaction(c, func(tx error) error {
+		// 同一 source 与 target 重复发送时视为更新通知，重新校验
+		mention, err := mapper.GetWebmentionBySourceAndTarget(c, req.Source, req.Target)
+		if err != nil {
+			mention = &model.Webmention{
+				Source: req.Source,
+				Target: req.Target,
+				PostId: postID,
+				Type:   utils.WEBMENTION_TYPE_MENTION,
+				Status: model.WEBMENTION_STATUS_PENDING,
+			}
+			if err := mapper.CreateWebmention(c, mention); err != nil {
+				utils.BizLogger(c).Errorf("保存 Webmention 失败: %v", err)
+				return fmt.Errorf("保存 Webmention 失败: %w", err)
+			}
+		} else {
+			mention.PostId = postID
+			mention.Status = model.WEBMENTION_STATUS_PENDING
+			mention.Attempts = 0
+			mention.Deleted = false
+			if err := mapper.UpdateWebmention(c, mention); err != nil {
+				utils.BizLogger(c).Errorf("更新 Webmention 失败: %v", err)
+				return fmt.Errorf("更新 Webmention 失败: %w", err)
+			}
+		}
+
+		vo, err := utils.MapModelToVO(mention, &webmention.WebmentionsVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("接收 Webmention 时映射 VO 失败: %v", err)
+			return fmt.Errorf("接收 Webmention 时映射 VO 失败: %w", err)
+		}
+
+		mentionVO = vo.(*webmention.WebmentionsVO)
+		mentionID = mention.ID
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	enqueueVerification(c, mentionID)
+	return mentionVO, nil
+}
+
+// GetWebmentionsByPostID 获取文章下校验通过的 Webmention 列表
+// 参数：
+//   - c: Echo 上下文
+//   - req: 获取 Webmention 列表请求
+//
+// 返回值：
+//   - []*webmention.WebmentionsVO: Webmention 视图对象列表
+//   - error: 操作过程中的错误
+func GetWebmentionsByPostID(c echo.Context, req *dto.GetWebmentionsRequest) ([]*webmention.WebmentionsVO, error) {
+	mentions, err := mapper.GetVerifiedWebmentionsByPostID(c, req.PostID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章 Webmention 失败: %v", err)
+		return nil, fmt.Errorf("获取文章 Webmention 失败: %w", err)
+	}
+
+	mentionsVO := make([]*webmention.WebmentionsVO, 0, len(mentions))
+	for _, mention := range mentions {
+		vo, err := utils.MapModelToVO(mention, &webmention.WebmentionsVO{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取 Webmention 时映射 VO 失败: %v", err)
+			return nil, fmt.Errorf("获取 Webmention 时映射 VO 失败: %w", err)
+		}
+		mentionsVO = append(mentionsVO, vo.(*webmention.WebmentionsVO))
+	}
+
+	return mentionsVO, nil
+}
+
+// SendWebmentionsForPost 异步向文章 Markdown 中的所有外部链接发送 Webmention
+// 参数：
+//   - postID: 文章 ID
+//   - contentMarkdown: 文章 Markdown 内容
+func SendWebmentionsForPost(postID int64, contentMarkdown string) {
+	cfg, err := configs.LoadConfig()
+	if err != nil || !cfg.AppConfig.Webmention.WebmentionEnabled {
+		return
+	}
+
+	source, err := utils.BuildPostURL(postID)
+	if err != nil {
+		global.SysLog.Errorf("生成文章「%d」地址失败，跳过发送 Webmention: %v", postID, err)
+		return
+	}
+
+	var targets []string
+	for _, link := range utils.ExtractMarkdownLinks([]byte(contentMarkdown)) {
+		if isHTTPURL(link) && !utils.IsSiteURL(link) {
+			targets = append(targets, link)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	allowPrivate := cfg.AppConfig.Webmention.WebmentionAllowPrivate
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				global.SysLog.Errorf("发送 Webmention 时发生 panic: %v", r)
+			}
+		}()
+
+		client := utils.NewSafeHTTPClient(allowPrivate)
+		for _, target := range targets {
+			ctx, cancel := context.WithTimeout(context.Background(), WEBMENTION_SEND_TIMEOUT)
+
+			endpoint, err := utils.DiscoverWebmentionEndpoint(ctx, client, target)
+			switch {
+			case err != nil:
+				global.SysLog.Warnf("发现「%s」的 Webmention 端点失败: %v", target, err)
+			case endpoint == "":
+				global.SysLog.Debugf("「%s」未声明 Webmention 端点", target)
+			default:
+				if err := utils.SendWebmention(ctx, client, endpoint, source, target); err != nil {
+					global.SysLog.Warnf("向「%s」发送 Webmention 失败: %v", target, err)
+				} else {
+					global.SysLog.Infof("已向「%s」发送 Webmention，来源: %s", target, source)
+				}
+			}
+
+			cancel()
+		}
+	}()
+}
+
+// Start 启动 Webmention 校验协程并将遗留的待校验记录重新入队，启动时调用一次
+func Start() {
+	verifyWorkerOnce.Do(func() { startVerifyWorker(0) })
+}
+
+// enqueueVerification 将 Webmention 加入校验队列，队列已满时保留待校验状态，待下次启动时重新入队
+// 参数：
+//   - c: Echo 上下文
+//   - mentionID: Webmention ID
+func enqueueVerification(c echo.Context, mentionID int64) {
+	// 未通过 Start 启动时在首次接收时启动，重新入队时跳过本次接收的记录，避免重复校验
+	verifyWorkerOnce.Do(func() { startVerifyWorker(mentionID) })
+
+	select {
+	case verifyQueue <- mentionID:
+	default:
+		utils.BizLogger(c).Warnf("Webmention 校验队列已满，ID「%d」将保持待校验状态", mentionID)
+	}
+}
+
+// scheduleRetry 在等待指定时间后将 Webmention 重新加入校验队列，队列已满时保留待校验状态，待下次启动时重新入队
+// 参数：
+//   - mentionID: Webmention ID
+//   - delay: 等待时间
+func scheduleRetry(mentionID int64, delay time.Duration) {
+	time.AfterFunc(delay, func() {
+		select {
+		case verifyQueue <- mentionID:
+		default:
+			global.SysLog.Warnf("Webmention 校验队列已满，ID「%d」将保持待校验状态", mentionID)
+		}
+	})
+}
+
+// startVerifyWorker 启动 Webmention 校验协程，并将遗留的待校验记录重新入队
+// 参数：
+//   - skipID: 重新入队时跳过的 Webmention ID，为 0 时不跳过
+func startVerifyWorker(skipID int64) {
+	go func() {
+		c := utils.NewDetachedContext()
+		ids, err := mapper.GetPendingWebmentionIDs(c)
+		if err != nil {
+			global.SysLog.Errorf("加载待校验 Webmention 失败: %v", err)
+			return
+		}
+		for _, id := range ids {
+			if id != skipID {
+				verifyQueue <- id
+			}
+		}
+	}()
+
+	go func() {
+		for id := range verifyQueue {
+			verifyWebmention(id)
+		}
+	}()
+}
+
+// verifyWebmention 校验单条 Webmention：来源页面包含目标链接则通过，来源页面已删除（410）则移除，
+// 来源页面暂时无法访问时保持待校验状态并延迟重试，超过最大校验次数后拒绝
+// 参数：
+//   - id: Webmention ID
+func verifyWebmention(id int64) {
+	defer func() {
+		if r := recover(); r != nil {
+			global.SysLog.Errorf("校验 Webmention「%d」时发生 panic: %v", id, r)
+		}
+	}()
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		global.SysLog.Errorf("加载 Webmention 配置失败: %v", err)
+		return
+	}
+
+	c := utils.NewDetachedContext()
+	mention, err := mapper.GetWebmentionByID(c, id)
+	if err != nil {
+		global.SysLog.Errorf("获取待校验 Webmention「%d」失败: %v", id, err)
+		return
+	}
+	if mention.Status != model.WEBMENTION_STATUS_PENDING {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), WEBMENTION_VERIFY_TIMEOUT)
+	defer cancel()
+
+	client := utils.NewSafeHTTPClient(cfg.AppConfig.Webmention.WebmentionAllowPrivate)
+	result, err := utils.VerifyWebmentionSource(ctx, client, mention.Source, mention.Target)
+
+	mention.VerifiedAt = time.Now().Unix()
+	retry := false
+	switch {
+	case err != nil:
+		mention.Attempts++
+		if mention.Attempts >= WEBMENTION_MAX_ATTEMPTS {
+			global.SysLog.Warnf("校验 Webmention「%d」失败 %d 次，已拒绝: %v", id, mention.Attempts, err)
+			mention.Status = model.WEBMENTION_STATUS_REJECTED
+		} else {
+			global.SysLog.Warnf("校验 Webmention「%d」失败（第 %d 次），稍后重试: %v", id, mention.Attempts, err)
+			retry = true
+		}
+	case result.Gone:
+		mention.Deleted = true
+	case !result.Linked:
+		mention.Status = model.WEBMENTION_STATUS_REJECTED
+	default:
+		mention.Status = model.WEBMENTION_STATUS_VERIFIED
+		mention.Type = result.Type
+		mention.Title = truncate(result.Title, 255)
+		mention.Author = truncate(result.Author, 255)
+	}
+
+	if err := mapper.UpdateWebmention(c, mention); err != nil {
+		global.SysLog.Errorf("保存 Webmention「%d」校验结果失败: %v", id, err)
+		return
+	}
+
+	if retry {
+		scheduleRetry(id, WEBMENTION_RETRY_DELAY<<(mention.Attempts-1))
+	}
+}
+
+// isHTTPURL 判断地址是否为 http(s) 绝对地址
+// 参数：
+//   - rawURL: 地址
+//
+// 返回值：
+//   - bool: 是返回 true
+func isHTTPURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// truncate 按字符数截断字符串
+// 参数：
+//   - s: 原字符串
+//   - max: 最大字符数
+//
+// 返回值：
+//   - string: 截断后的字符串
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
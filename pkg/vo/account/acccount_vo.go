@@ -9,30 +9,39 @@ package account
 // @Property			nickname	body	string	true	"用户昵称"
 // @Property			phone	    body	string	true	"用户手机号"
 // @Property			avatar	    body	string	true	"用户头像"
+// @Property			role	    body	string	true	"用户角色"
+// @Property			totp_enabled	body	bool	true	"是否已启用双因素认证"
 type GetAccountVO struct {
-	Nickname string `json:"nickname"`
-	Email    string `json:"email"`
-	Phone    string `json:"phone"`
-	Avatar   string `json:"avatar"`
+	Nickname    string `json:"nickname"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	Avatar      string `json:"avatar"`
+	Role        string `json:"role"`
+	TotpEnabled bool   `json:"totp_enabled"`
 }
 
 // LoginVO           返回给前端的登录信息
-// @Description	登录成功后返回的访问令牌和刷新令牌
+// @Description	登录成功后返回的访问令牌和刷新令牌；启用双因素认证时仅返回登录挑战令牌
 // @Property			access_token	body	string	true	"访问令牌"
 // @Property			refresh_token	body	string	true	"刷新令牌"
+// @Property			two_factor_required	body	bool	true	"是否需要双因素认证"
+// @Property			challenge_token	body	string	false	"登录挑战令牌，用于换取访问令牌"
 type LoginVO struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken       string `json:"access_token"`
+	RefreshToken      string `json:"refresh_token"`
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
 }
 
 // RegisterAccountVO     获取账户信息请求体
 // @Description	请求获取账户信息时所需参数
 // @Property			email	    body	string	true	"用户邮箱"
 // @Property			nickname	body	string	true	"用户昵称"
-// @Property			role_code	body	string	true	"用户角色编码"
+// @Property			role		body	string	true	"用户角色"
 type RegisterAccountVO struct {
 	Nickname string `json:"nickname"`
 	Email    string `json:"email"`
+	Role     string `json:"role"`
 }
 
 // UpdateAccountVO     更新账户信息响应体
@@ -47,3 +56,239 @@ type UpdateAccountVO struct {
 	Phone    string `json:"phone"`
 	Avatar   string `json:"avatar"`
 }
+
+// TotpEnrollmentVO     双因素认证绑定信息
+// @Description	开始绑定双因素认证时返回的密钥与二维码
+// @Property			secret		body	string	true	"Base32 编码的 TOTP 密钥，用于手动输入"
+// @Property			otpauth_uri	body	string	true	"otpauth URI"
+// @Property			qr_code		body	string	true	"otpauth URI 的二维码，Base64 编码的 PNG 图片"
+type TotpEnrollmentVO struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+	QRCode     string `json:"qr_code"`
+}
+
+// TotpRecoveryCodesVO     双因素认证恢复码
+// @Description	启用双因素认证或重新生成恢复码时返回的恢复码，仅展示一次
+// @Property			recovery_codes	body	[]string	true	"一次性恢复码"
+type TotpRecoveryCodesVO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// SessionVO     登录会话
+// @Description	账户在各设备上的登录会话
+// @Property			session_id		body	string	true	"会话ID"
+// @Property			user_agent		body	string	true	"登录设备的 User-Agent"
+// @Property			ip				body	string	true	"最近一次访问的 IP"
+// @Property			created_at		body	string	true	"登录时间（格式化时间）"
+// @Property			last_seen_at	body	string	true	"最近活跃时间（格式化时间）"
+// @Property			current			body	bool	true	"是否为当前请求所用的会话"
+type SessionVO struct {
+	SessionID  string `json:"session_id"`
+	UserAgent  string `json:"user_agent"`
+	IP         string `json:"ip"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
+	Current    bool   `json:"current"`
+}
+
+// JWKVO         JSON Web Key
+// @Description	用于校验令牌签名的公钥，字段含义见 RFC 7517 与 RFC 8037
+// @Property			kty	body	string	true	"密钥类型：RSA 或 OKP"
+// @Property			kid	body	string	true	"密钥标识，对应令牌头部的 kid"
+// @Property			use	body	string	true	"密钥用途，固定为 sig"
+// @Property			alg	body	string	true	"签名算法：RS256 或 EdDSA"
+// @Property			n	body	string	false	"RSA 模数，Base64URL 编码"
+// @Property			e	body	string	false	"RSA 公钥指数，Base64URL 编码"
+// @Property			crv	body	string	false	"OKP 曲线，固定为 Ed25519"
+// @Property			x	body	string	false	"Ed25519 公钥，Base64URL 编码"
+type JWKVO struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSVO        JSON Web Key Set
+// @Description	全部可用于校验令牌签名的公钥集合
+// @Property			keys	body	[]JWKVO	true	"公钥列表"
+type JWKSVO struct {
+	Keys []JWKVO `json:"keys"`
+}
+
+// AccessTokenVO     个人访问令牌
+// @Description	个人访问令牌信息，不包含令牌明文
+// @Property			id				body	string		true	"令牌ID"
+// @Property			name			body	string		true	"令牌名称"
+// @Property			token_hint		body	string		true	"令牌末尾字符"
+// @Property			scopes			body	[]string	true	"授权范围"
+// @Property			expires_at		body	string		true	"过期时间，为空表示永不过期"
+// @Property			last_used_at	body	string		true	"最近使用时间，为空表示从未使用"
+// @Property			gmt_create		body	string		true	"创建时间"
+type AccessTokenVO struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	TokenHint  string   `json:"token_hint"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  string   `json:"expires_at"`
+	LastUsedAt string   `json:"last_used_at"`
+	GmtCreate  string   `json:"gmt_create"`
+}
+
+// CreateAccessTokenVO   创建个人访问令牌结果
+// @Description	令牌明文仅在创建时返回一次，请妥善保存
+// @Property			token		body	string			true	"令牌明文"
+// @Property			access_token	body	AccessTokenVO	true	"令牌信息"
+type CreateAccessTokenVO struct {
+	Token       string         `json:"token"`
+	AccessToken *AccessTokenVO `json:"access_token"`
+}
+
+// ExportProfileVO     导出的账户资料
+// @Description	账户数据导出中的个人资料
+// @Property			id				body	string	true	"账户ID"
+// @Property			email			body	string	true	"用户邮箱"
+// @Property			nickname		body	string	true	"用户昵称"
+// @Property			phone			body	string	true	"用户手机号"
+// @Property			avatar			body	string	true	"用户头像"
+// @Property			role			body	string	true	"用户角色"
+// @Property			totp_enabled	body	bool	true	"是否已启用双因素认证"
+// @Property			gmt_create		body	string	true	"注册时间"
+type ExportProfileVO struct {
+	ID          string `json:"id"`
+	Email       string `json:"email"`
+	Nickname    string `json:"nickname"`
+	Phone       string `json:"phone"`
+	Avatar      string `json:"avatar"`
+	Role        string `json:"role"`
+	TotpEnabled bool   `json:"totp_enabled"`
+	GmtCreate   string `json:"gmt_create"`
+}
+
+// ExportPostVO     导出的文章
+// @Description	账户数据导出中账户撰写的文章，正文另以 Markdown 文件保存
+// @Property			id					body	string	true	"文章ID"
+// @Property			title				body	string	true	"标题"
+// @Property			slug				body	string	true	"别名"
+// @Property			image				body	string	true	"图片"
+// @Property			visibility			body	bool	true	"是否可见"
+// @Property			content_markdown	body	string	true	"Markdown 内容"
+// @Property			gmt_create			body	string	true	"创建时间"
+// @Property			gmt_modified		body	string	true	"修改时间"
+type ExportPostVO struct {
+	ID              string `json:"id"`
+	Title           string `json:"title"`
+	Slug            string `json:"slug"`
+	Image           string `json:"image"`
+	Visibility      bool   `json:"visibility"`
+	ContentMarkdown string `json:"content_markdown"`
+	GmtCreate       string `json:"gmt_create"`
+	GmtModified     string `json:"gmt_modified"`
+}
+
+// ExportCommentVO     导出的评论
+// @Description	账户数据导出中账户发表的评论，包括以账户邮箱发表的游客评论
+// @Property			id					body	string	true	"评论ID"
+// @Property			post_id				body	string	true	"所属文章ID"
+// @Property			reply_to_comment_id	body	string	true	"回复的评论ID"
+// @Property			content				body	string	true	"评论内容（Markdown 源文本）"
+// @Property			author_name			body	string	true	"评论者昵称"
+// @Property			author_email		body	string	true	"评论者邮箱"
+// @Property			author_website		body	string	true	"评论者个人网站"
+// @Property			status				body	string	true	"审核状态"
+// @Property			is_edited			body	bool	true	"是否被编辑过"
+// @Property			gmt_create			body	string	true	"发表时间"
+type ExportCommentVO struct {
+	ID               string `json:"id"`
+	PostId           string `json:"post_id"`
+	ReplyToCommentId string `json:"reply_to_comment_id"`
+	Content          string `json:"content"`
+	AuthorName       string `json:"author_name"`
+	AuthorEmail      string `json:"author_email"`
+	AuthorWebsite    string `json:"author_website"`
+	Status           string `json:"status"`
+	IsEdited         bool   `json:"is_edited"`
+	GmtCreate        string `json:"gmt_create"`
+}
+
+// ExportFileVO     导出的上传文件
+// @Description	账户数据导出中账户上传的文件
+// @Property			bucket_name		body	string	true	"存储桶名称"
+// @Property			object_name		body	string	true	"对象名称"
+// @Property			file_name		body	string	true	"原始文件名"
+// @Property			size			body	int64	true	"文件大小，单位字节"
+// @Property			content_type	body	string	true	"文件 MIME 类型"
+// @Property			gmt_create		body	string	true	"上传时间"
+// @Property			archive_path	body	string	true	"文件在压缩包中的路径，为空表示对象存储不可用或文件已不存在，未能打包"
+type ExportFileVO struct {
+	BucketName  string `json:"bucket_name"`
+	ObjectName  string `json:"object_name"`
+	FileName    string `json:"file_name"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type"`
+	GmtCreate   string `json:"gmt_create"`
+	ArchivePath string `json:"archive_path"`
+}
+
+// AccountExportVO     账户数据导出
+// @Description	打包为压缩包下载的账户数据
+// @Property			profile		body	ExportProfileVO		true	"个人资料"
+// @Property			posts		body	[]ExportPostVO		true	"撰写的文章"
+// @Property			comments	body	[]ExportCommentVO	true	"发表的评论"
+// @Property			files		body	[]ExportFileVO		true	"上传的文件"
+// @Property			exported_at	body	string				true	"导出时间"
+type AccountExportVO struct {
+	Profile    *ExportProfileVO   `json:"profile"`
+	Posts      []*ExportPostVO    `json:"posts"`
+	Comments   []*ExportCommentVO `json:"comments"`
+	Files      []*ExportFileVO    `json:"files"`
+	ExportedAt string             `json:"exported_at"`
+}
+
+// OAuthProviderVO     第三方登录提供方
+// @Description	已启用的第三方登录提供方，供前端展示登录按钮
+// @Property			name			body	string	true	"提供方名称，发起授权时作为 provider 参数"
+// @Property			type			body	string	true	"提供方类型：github、google、oidc"
+// @Property			display_name	body	string	true	"登录按钮展示名称"
+type OAuthProviderVO struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	DisplayName string `json:"display_name"`
+}
+
+// OAuthAuthorizationVO     第三方授权地址
+// @Description	前端跳转至该地址完成第三方授权，授权后提供方携带 code 与 state 重定向回前端回调页面
+// @Property			authorization_url	body	string	true	"授权地址"
+type OAuthAuthorizationVO struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// OAuthReauthVO     身份验证令牌
+// @Description	通过已绑定的第三方账号重新验证身份后获得，仅可在当前会话中使用一次
+// @Property			reauth_token	body	string	true	"身份验证令牌，注销账户、关闭双因素认证时代替密码提交"
+// @Property			expires_in		body	int		true	"有效期（秒）"
+type OAuthReauthVO struct {
+	ReauthToken string `json:"reauth_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// OAuthIdentityVO     已绑定的第三方账号
+// @Description	当前账户绑定的第三方账号
+// @Property			id				body	string	true	"绑定记录ID"
+// @Property			provider		body	string	true	"提供方名称"
+// @Property			email			body	string	true	"第三方账号邮箱"
+// @Property			display_name	body	string	true	"第三方账号名称"
+// @Property			last_login_at	body	string	true	"最近一次通过该账号登录的时间，为空表示从未登录"
+// @Property			gmt_create		body	string	true	"绑定时间"
+type OAuthIdentityVO struct {
+	ID          string `json:"id"`
+	Provider    string `json:"provider"`
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+	LastLoginAt string `json:"last_login_at"`
+	GmtCreate   string `json:"gmt_create"`
+}
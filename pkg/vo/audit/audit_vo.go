@@ -0,0 +1,46 @@
+// Package audit 提供审计日志相关的视图对象定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package audit
+
+// AuditLogVO 审计日志
+// @Description 单条审计日志
+// @Property id          body string true  "审计日志唯一标识"
+// @Property action      body string true  "审计事件"
+// @Property actor_id    body string true  "操作者账户ID，游客或系统操作为 0"
+// @Property actor_email body string false "操作者邮箱，登录相关事件记录提交的邮箱"
+// @Property ip          body string false "操作者 IP"
+// @Property request_id  body string false "请求ID"
+// @Property target_type body string false "审计对象类型"
+// @Property target_id   body string false "审计对象标识"
+// @Property before      body object false "变更前摘要"
+// @Property after       body object false "变更后摘要"
+// @Property detail      body string false "补充说明"
+// @Property gmt_create  body string true  "发生时间"
+type AuditLogVO struct {
+	ID         string                 `json:"id"`
+	Action     string                 `json:"action"`
+	ActorID    string                 `json:"actor_id"`
+	ActorEmail string                 `json:"actor_email"`
+	IP         string                 `json:"ip"`
+	RequestID  string                 `json:"request_id"`
+	TargetType string                 `json:"target_type"`
+	TargetID   string                 `json:"target_id"`
+	Before     map[string]interface{} `json:"before"`
+	After      map[string]interface{} `json:"after"`
+	Detail     string                 `json:"detail"`
+	GmtCreate  string                 `json:"gmt_create"`
+}
+
+// AuditLogPageVO 审计日志分页结果
+// @Description 分页的审计日志
+// @Property logs         body []*AuditLogVO true "审计日志列表"
+// @Property total        body int64 true "日志总数"
+// @Property total_pages  body int   true "总页数"
+// @Property current_page body int   true "当前页码"
+type AuditLogPageVO struct {
+	Logs        []*AuditLogVO `json:"logs"`
+	Total       int64         `json:"total"`
+	TotalPages  int           `json:"total_pages"`
+	CurrentPage int           `json:"current_page"`
+}
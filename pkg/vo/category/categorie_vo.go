@@ -10,12 +10,68 @@ package category
 // @Property		description	body	string	true	"类目描述"
 // @Property		parent_id	body	string	true	"父类目ID"
 // @Property		path		body	string	true	"类目路径"
+// @Property		position	body	int		true	"同级排序位置"
+// @Property		post_count	body	int64	true	"类目下直接关联的文章数量"
+// @Property		total_post_count	body	int64	true	"包含所有后代类目的文章数量"
 // @Property		children	body	[]*CategoriesVO	true	"子类目列表"
 type CategoriesVO struct {
-	ID          string          `json:"id"`
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	ParentID    string          `json:"parent_id"`
-	Path        string          `json:"path"`
-	Children    []*CategoriesVO `json:"children"`
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	ParentID       string          `json:"parent_id"`
+	Path           string          `json:"path"`
+	Position       int             `json:"position"`
+	PostCount      int64           `json:"post_count"`
+	TotalPostCount int64           `json:"total_post_count"`
+	Children       []*CategoriesVO `json:"children"`
+}
+
+// DeleteCategoryVO 删除类目响应
+// @Description 删除类目响应
+// @Property		deleted_categories		body	[]*CategoriesVO	true	"被删除的类目树"
+// @Property		strategy				body	string	true	"文章处理策略"
+// @Property		reassigned_category_id	body	string	true	"文章转移到的类目ID，未转移时为空"
+// @Property		affected_post_count		body	int64	true	"受影响的文章数量"
+type DeleteCategoryVO struct {
+	DeletedCategories    []*CategoriesVO `json:"deleted_categories"`
+	Strategy             string          `json:"strategy"`
+	ReassignedCategoryID string          `json:"reassigned_category_id"`
+	AffectedPostCount    int64           `json:"affected_post_count"`
+}
+
+// MergeCategoriesVO 合并类目响应
+// @Description 合并类目响应
+// @Property		target				body	*CategoriesVO	true	"合并后的目标类目"
+// @Property		moved_child_count	body	int		true	"转移的直接子类目数量"
+// @Property		affected_post_count	body	int64	true	"转移的文章数量"
+type MergeCategoriesVO struct {
+	Target            *CategoriesVO `json:"target"`
+	MovedChildCount   int           `json:"moved_child_count"`
+	AffectedPostCount int64         `json:"affected_post_count"`
+}
+
+// CategoryTreeIssueVO 类目树完整性问题
+// @Description 类目树完整性问题
+// @Property		type			body	string	true	"问题类型：path_mismatch、orphan、cycle、dangling_post_category"
+// @Property		category_id		body	string	true	"相关类目ID"
+// @Property		post_id			body	string	false	"相关文章ID，仅 dangling_post_category 时存在"
+// @Property		expected		body	string	false	"期望值"
+// @Property		actual			body	string	false	"实际值"
+// @Property		detail			body	string	true	"问题描述"
+type CategoryTreeIssueVO struct {
+	Type       string `json:"type"`
+	CategoryID string `json:"category_id"`
+	PostID     string `json:"post_id,omitempty"`
+	Expected   string `json:"expected,omitempty"`
+	Actual     string `json:"actual,omitempty"`
+	Detail     string `json:"detail"`
+}
+
+// CategoryTreeReportVO 类目树完整性检查报告
+// @Description 类目树完整性检查报告
+// @Property		issues		body	[]*CategoryTreeIssueVO	true	"检测到的问题列表"
+// @Property		repaired	body	bool	true	"是否已修复"
+type CategoryTreeReportVO struct {
+	Issues   []*CategoryTreeIssueVO `json:"issues"`
+	Repaired bool                   `json:"repaired"`
 }
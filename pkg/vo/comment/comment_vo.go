@@ -3,19 +3,195 @@
 // 创建时间：2025-05-10
 package comment
 
+import "jank.com/jank_blog/pkg/vo/webmention"
+
 // CommentsVO 获取评论响应
 // @Description 获取单个评论的响应
 // @Property id                  body string  			 true  "评论唯一标识"
-// @Property content             body string  			 true  "评论内容"
+// @Property content             body string  			 true  "评论内容（Markdown 源文本）"
+// @Property content_html        body string  			 true  "渲染后的评论 HTML"
 // @Property account_id          body string              true  "评论所属用户ID"
 // @Property post_id             body string              true  "评论所属文章ID"
 // @Property reply_to_comment_id body string              false "回复的目标评论ID"
+// @Property author_name         body string              true  "评论者昵称"
+// @Property author_website      body string              false "评论者个人网站"
+// @Property is_author_reply     body bool                true  "是否为博主回复"
+// @Property status              body string              true  "审核状态"
+// @Property is_edited           body bool                true  "是否被编辑过"
+// @Property edit_token          body string              false "游客编辑凭证，仅在游客创建评论时返回一次"
+// @Property reaction_count      body int64               true  "互动（点赞）次数"
+// @Property reply_count         body int64               true  "直接回复数"
+// @Property replies_cursor      body string              false "继续加载剩余回复的游标，回复已全部内联时为空"
+// @Property gmt_create          body string              true  "创建时间"
 // @Property replies             body []*CommentsVO true  "子评论列表"
 type CommentsVO struct {
 	ID               string        `json:"id"`
 	Content          string        `json:"content"`
+	ContentHTML      string        `json:"content_html"`
 	AccountId        string        `json:"account_id"`
 	PostId           string        `json:"post_id"`
 	ReplyToCommentId string        `json:"reply_to_comment_id"`
+	AuthorName       string        `json:"author_name"`
+	AuthorWebsite    string        `json:"author_website"`
+	IsAuthorReply    bool          `json:"is_author_reply"`
+	Status           string        `json:"status"`
+	IsEdited         bool          `json:"is_edited"`
+	EditToken        string        `json:"edit_token,omitempty"`
+	ReactionCount    int64         `json:"reaction_count"`
+	ReplyCount       int64         `json:"reply_count"`
+	RepliesCursor    string        `json:"replies_cursor"`
+	GmtCreate        string        `json:"gmt_create"`
 	Replies          []*CommentsVO `json:"replies"`
 }
+
+// CommentRevisionVO 评论历史版本
+// @Description 评论编辑前的历史版本
+// @Property id                body string true "历史版本唯一标识"
+// @Property comment_id        body string true "所属评论ID"
+// @Property content           body string true "编辑前的评论内容"
+// @Property editor_account_id body string true "编辑者账户ID，游客编辑为 0"
+// @Property editor_ip         body string true "编辑者 IP"
+// @Property gmt_create        body string true "编辑时间"
+type CommentRevisionVO struct {
+	ID              string `json:"id"`
+	CommentId       string `json:"comment_id"`
+	Content         string `json:"content"`
+	EditorAccountId string `json:"editor_account_id"`
+	EditorIP        string `json:"editor_ip"`
+	GmtCreate       string `json:"gmt_create"`
+}
+
+// CommentPageVO 分页的评论列表
+// @Description 分页的顶层评论及按层级内联的回复，附带文章下校验通过的 Webmention
+// @Property comments     body []*CommentsVO true "顶层评论列表"
+// @Property webmentions  body []*webmention.WebmentionsVO true "校验通过的 Webmention 列表"
+// @Property total        body int64 true "顶层评论总数"
+// @Property total_pages  body int   true "总页数"
+// @Property current_page body int   true "当前页码"
+type CommentPageVO struct {
+	Comments    []*CommentsVO               `json:"comments"`
+	Webmentions []*webmention.WebmentionsVO `json:"webmentions"`
+	Total       int64                       `json:"total"`
+	TotalPages  int                         `json:"total_pages"`
+	CurrentPage int                         `json:"current_page"`
+}
+
+// CommentRepliesVO 按游标加载的评论回复
+// @Description 按游标加载的直接回复
+// @Property replies     body []*CommentsVO true "回复列表"
+// @Property next_cursor body string true "下一页游标，没有更多回复时为空"
+// @Property has_more    body bool   true "是否还有更多回复"
+type CommentRepliesVO struct {
+	Replies    []*CommentsVO `json:"replies"`
+	NextCursor string        `json:"next_cursor"`
+	HasMore    bool          `json:"has_more"`
+}
+
+// CommentReactionVO 评论互动结果
+// @Description 评论互动结果
+// @Property id             body string true "评论唯一标识"
+// @Property reaction_count body int64  true "互动（点赞）次数"
+// @Property counted        body bool   true "本次互动是否被计入，同一访客重复互动不重复计数"
+type CommentReactionVO struct {
+	ID            string `json:"id"`
+	ReactionCount int64  `json:"reaction_count"`
+	Counted       bool   `json:"counted"`
+}
+
+// ModerationCommentVO 审核队列中的评论
+// @Description 审核队列中的评论，包含评论者邮箱与 IP 供博主判断
+// @Property id             body string true  "评论唯一标识"
+// @Property content        body string true  "评论内容（Markdown 源文本）"
+// @Property content_html   body string true  "渲染后的评论 HTML"
+// @Property post_id        body string true  "评论所属文章ID"
+// @Property author_name    body string true  "评论者昵称"
+// @Property author_email   body string true  "评论者邮箱"
+// @Property author_website body string false "评论者个人网站"
+// @Property author_ip      body string true  "评论者 IP"
+// @Property status         body string true  "审核状态"
+// @Property gmt_create     body string true  "创建时间"
+type ModerationCommentVO struct {
+	ID            string `json:"id"`
+	Content       string `json:"content"`
+	ContentHTML   string `json:"content_html"`
+	PostId        string `json:"post_id"`
+	AuthorName    string `json:"author_name"`
+	AuthorEmail   string `json:"author_email"`
+	AuthorWebsite string `json:"author_website"`
+	AuthorIP      string `json:"author_ip"`
+	Status        string `json:"status"`
+	GmtCreate     string `json:"gmt_create"`
+}
+
+// ModerationQueueVO 评论审核队列
+// @Description 分页的评论审核队列
+// @Property comments     body []*ModerationCommentVO true "评论列表"
+// @Property total        body int64 true "评论总数"
+// @Property total_pages  body int   true "总页数"
+// @Property current_page body int   true "当前页码"
+type ModerationQueueVO struct {
+	Comments    []*ModerationCommentVO `json:"comments"`
+	Total       int64                  `json:"total"`
+	TotalPages  int                    `json:"total_pages"`
+	CurrentPage int                    `json:"current_page"`
+}
+
+// ModerateCommentsVO 批量审核结果
+// @Description 批量审核结果
+// @Property status         body string true "审核后的状态"
+// @Property affected_count body int64  true "更新的评论数量"
+type ModerateCommentsVO struct {
+	Status        string `json:"status"`
+	AffectedCount int64  `json:"affected_count"`
+}
+
+// CommentImportReportVO 评论导入报告
+// @Description 从 Disqus 或 WordPress 导出文件导入评论的结果
+// @Property source            body string                       true "导入来源：disqus、wordpress"
+// @Property dry_run           body bool                         true "是否仅预览而未写入"
+// @Property threads           body int                          true "包含评论的来源讨论串数"
+// @Property matched_threads   body int                          true "匹配到文章的讨论串数"
+// @Property imported          body int                          true "本次导入的评论数"
+// @Property existing          body int                          true "此前已导入而跳过的评论数"
+// @Property owner_comments    body int                          true "本次导入中关联到博主账户的评论数"
+// @Property skipped           body int                          true "按来源状态跳过的评论数（已删除、Pingback 等）"
+// @Property unmatched_threads body []*UnmatchedThreadVO         true "未匹配到文章的讨论串"
+// @Property issues            body []*CommentImportIssueVO      true "导入过程中需要关注的评论"
+type CommentImportReportVO struct {
+	Source           string                  `json:"source"`
+	DryRun           bool                    `json:"dry_run"`
+	Threads          int                     `json:"threads"`
+	MatchedThreads   int                     `json:"matched_threads"`
+	Imported         int                     `json:"imported"`
+	Existing         int                     `json:"existing"`
+	OwnerComments    int                     `json:"owner_comments"`
+	Skipped          int                     `json:"skipped"`
+	UnmatchedThreads []*UnmatchedThreadVO    `json:"unmatched_threads"`
+	Issues           []*CommentImportIssueVO `json:"issues"`
+}
+
+// UnmatchedThreadVO 未匹配到文章的讨论串
+// @Description 未匹配到文章的讨论串
+// @Property thread_id     body string true "来源讨论串 ID"
+// @Property title         body string true "来源文章标题"
+// @Property link          body string true "来源文章地址"
+// @Property comment_count body int    true "讨论串中的评论数"
+// @Property reason        body string true "未匹配原因"
+type UnmatchedThreadVO struct {
+	ThreadID     string `json:"thread_id"`
+	Title        string `json:"title"`
+	Link         string `json:"link"`
+	CommentCount int    `json:"comment_count"`
+	Reason       string `json:"reason"`
+}
+
+// CommentImportIssueVO 导入过程中需要关注的评论
+// @Description 导入过程中被跳过或调整的评论
+// @Property thread_id  body string true "来源讨论串 ID"
+// @Property comment_id body string true "来源评论 ID"
+// @Property reason     body string true "原因"
+type CommentImportIssueVO struct {
+	ThreadID  string `json:"thread_id"`
+	CommentID string `json:"comment_id"`
+	Reason    string `json:"reason"`
+}
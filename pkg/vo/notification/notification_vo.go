@@ -0,0 +1,15 @@
+// Package notification 提供邮件通知相关的视图对象定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package notification
+
+// EmailPreferenceVO 邮件通知偏好
+// @Description 邮箱地址的邮件通知偏好
+// @Property email           body string true "邮箱地址"
+// @Property notify_replies  body bool   true "是否接收评论回复通知"
+// @Property notify_comments body bool   true "是否接收新评论通知"
+type EmailPreferenceVO struct {
+	Email          string `json:"email"`
+	NotifyReplies  bool   `json:"notify_replies"`
+	NotifyComments bool   `json:"notify_comments"`
+}
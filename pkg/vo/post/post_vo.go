@@ -6,16 +6,25 @@ package post
 // PostsVO    获取帖子的响应结构
 // @Description	获取帖子时返回的响应数据
 // @Property			id			    	body	string	true	"帖子唯一标识"
+// @Property			author_id		    body	string	true	"作者账户 ID"
 // @Property			title			    body	string	true	"帖子标题"
+// @Property			slug			    body	string	true	"帖子别名"
 // @Property			image			    body	string	true	"帖子封面图片 URL"
 // @Property			visibility		    body	bool	true	"帖子可见性状态"
 // @Property			content_html		body	string	true	"帖子 HTML 格式内容"
 // @Property			category_id	    	body	string	true	"帖子所属分类 ID"
 // @Property			gmt_create	    	body	string	true	"创建时间（格式化时间）"
 // @Property			gmt_modified	    body	string	true	"更新时间（格式化时间）"
+// @Property			comments_disabled	body	bool	true	"是否关闭评论"
+// @Property			comments_close_after_days	body	int	true	"发布多少天后关闭评论，0 表示不限"
+// @Property			comments_login_required	body	bool	true	"是否仅允许登录用户评论"
+// @Property			comments_open		body	bool	true	"当前是否开放评论"
+// @Property			comment_count		body	int64	true	"审核通过的评论数"
 type PostsVO struct {
 	ID         string `json:"id"`
+	AuthorID   string `json:"author_id"`
 	Title      string `json:"title"`
+	Slug       string `json:"slug"`
 	Image      string `json:"image"`
 	Visibility bool   `json:"visibility"`
 	// ContentMarkdown string `json:"content_markdown"`
@@ -23,4 +32,10 @@ type PostsVO struct {
 	CategoryID  string `json:"category_id"`
 	GmtCreate   string `json:"gmt_create"`
 	GmtModified string `json:"gmt_modified"`
+
+	CommentsDisabled       bool  `json:"comments_disabled"`
+	CommentsCloseAfterDays int   `json:"comments_close_after_days"`
+	CommentsLoginRequired  bool  `json:"comments_login_required"`
+	CommentsOpen           bool  `json:"comments_open"`
+	CommentCount           int64 `json:"comment_count"`
 }
@@ -5,6 +5,7 @@ package vo
 
 import (
 	"errors"
+	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -62,3 +63,30 @@ func Fail(c echo.Context, data interface{}, err error) Result {
 		TimeStamp: time.Now().Unix(),
 	}
 }
+
+// FailWithBizErr 按业务错误码返回对应的 HTTP 状态码，非业务错误按服务端错误处理
+// 参数：
+//   - c: Echo 上下文
+//   - err: 服务层返回的错误
+//
+// 返回值：
+//   - error: 响应写入过程中的错误
+func FailWithBizErr(c echo.Context, err error) error {
+	var e *bizErr.Err
+	if !errors.As(err, &e) {
+		return c.JSON(http.StatusInternalServerError, Fail(c, err, bizErr.New(bizErr.SERVER_ERR, err.Error())))
+	}
+
+	status := http.StatusInternalServerError
+	switch e.Code {
+	case bizErr.BAD_REQUEST:
+		status = http.StatusBadRequest
+	case bizErr.FORBIDDEN:
+		status = http.StatusForbidden
+	case bizErr.UNAUTHORIZED:
+		status = http.StatusUnauthorized
+	case bizErr.TOO_MANY_REQUESTS, bizErr.ACCOUNT_LOCKED:
+		status = http.StatusTooManyRequests
+	}
+	return c.JSON(status, Fail(c, nil, e))
+}
@@ -0,0 +1,27 @@
+// Package webmention 提供 Webmention 相关的视图对象定义
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package webmention
+
+// WebmentionsVO 获取 Webmention 响应
+// @Description 获取单个 Webmention 的响应
+// @Property id          body string true  "Webmention 唯一标识"
+// @Property source      body string true  "来源页面地址"
+// @Property target      body string true  "目标文章地址"
+// @Property post_id     body string true  "所属文章ID"
+// @Property type        body string true  "互动类型：mention、reply、like、repost、bookmark"
+// @Property status      body string true  "校验状态：pending、verified、rejected"
+// @Property title       body string false "来源页面标题"
+// @Property author      body string false "来源页面作者"
+// @Property gmt_create  body string true  "接收时间"
+type WebmentionsVO struct {
+	ID        string `json:"id"`
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	PostId    string `json:"post_id"`
+	Type      string `json:"type"`
+	Status    string `json:"status"`
+	Title     string `json:"title"`
+	Author    string `json:"author"`
+	GmtCreate string `json:"gmt_create"`
+}